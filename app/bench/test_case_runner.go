@@ -75,6 +75,7 @@ func newTestingServerLocal(serverParams *config.TBenchmarkServerParams) (common.
 				UseUnsafeConverters: true,
 			},
 		),
+		server.WithClickHouseArrowStream(serverParams.GetClickhouseUseArrowStream()),
 	)
 }
 
@@ -233,12 +234,19 @@ func (tcr *testCaseRunner) finish() *report {
 func (tcr *testCaseRunner) name() string {
 	switch tcr.cfg.Server.(type) {
 	case *config.TBenchmarkConfig_ServerLocal:
-		return fmt.Sprintf(
+		name := fmt.Sprintf(
 			"bytes_per_page_%d-prefetch_queue_capacity_%d-columns_%d",
 			tcr.testCase.ServerParams.Paging.BytesPerPage,
 			tcr.testCase.ServerParams.Paging.PrefetchQueueCapacity,
 			len(tcr.testCase.Columns),
 		)
+
+		// the reports of the same test case read in different ways must not overwrite each other
+		if tcr.testCase.ServerParams.GetClickhouseUseArrowStream() {
+			name += "-arrow_stream"
+		}
+
+		return name
 	case *config.TBenchmarkConfig_ServerRemote:
 		return "remote"
 	default:
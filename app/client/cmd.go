@@ -15,6 +15,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(connector.Cmd)
+	Cmd.AddCommand(connector.ShellCmd)
 	Cmd.AddCommand(metrics.Cmd)
 	Cmd.AddCommand(ydb.Cmd)
 }
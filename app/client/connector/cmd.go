@@ -36,9 +36,45 @@ var listSplitsCmd = &cobra.Command{
 	},
 }
 
+var describeTableCmd = &cobra.Command{
+	Use:   "describe_table",
+	Short: "Describe table schema in the external data source",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := describeTable(cmd, args); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var listTablesCmd = &cobra.Command{
+	Use:   "list_tables",
+	Short: "List tables in the external data source",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listTables(cmd, args); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// ShellCmd is registered directly in the client command, because it's not bound to a single table
+var ShellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Interactive shell for the external data source",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runShell(cmd, args); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
 func init() {
 	Cmd.AddCommand(readTableCmd)
 	Cmd.AddCommand(listSplitsCmd)
+	Cmd.AddCommand(describeTableCmd)
+	Cmd.AddCommand(listTablesCmd)
 
 	Cmd.Flags().StringP(utils.ConfigFlag, "c", "", "path to client config file")
 
@@ -54,11 +90,34 @@ func init() {
 		os.Exit(1)
 	}
 
+	Cmd.Flags().StringP(utils.OutputFlag, "o", string(outputFormatTable), "output format: table, json or yaml")
+
 	// inherit parent flags
 	readTableCmd.Flags().AddFlagSet(Cmd.Flags())
 	listSplitsCmd.Flags().AddFlagSet(Cmd.Flags())
+	describeTableCmd.Flags().AddFlagSet(Cmd.Flags())
+
+	// tables are listed without the table flag
+	listTablesCmd.Flags().AddFlag(Cmd.Flags().Lookup(utils.ConfigFlag))
+	listTablesCmd.Flags().AddFlag(Cmd.Flags().Lookup(utils.OutputFlag))
+	listTablesCmd.Flags().StringP(utils.PatternFlag, "p", "", "table name pattern")
+
+	readTableCmd.Flags().StringP(utils.OutputFormatFlag, "f", string(exportFormatCSV), "data format: "+exportFormatsSupported)
+	readTableCmd.Flags().String(utils.OutputFileFlag, "", "file to write data to (stdout by default)")
+	readTableCmd.Flags().StringP(utils.WhereFlag, "w", "", "filter, e.g. \"id > 10 AND name IS NOT NULL\"")
+	readTableCmd.Flags().StringSlice(utils.ColumnsFlag, nil, "comma-separated list of columns to read (all by default)")
+
+	ShellCmd.Flags().AddFlag(Cmd.Flags().Lookup(utils.ConfigFlag))
+	ShellCmd.Flags().AddFlag(Cmd.Flags().Lookup(utils.OutputFlag))
+	ShellCmd.Flags().String(utils.ServerConfigFlag, "", "path to the config of the connector server, required by \\explain to render SQL queries")
 
-	readTableCmd.Flags().StringP(utils.UserIDFlag, "u", "", "user-id")
-	readTableCmd.Flags().StringP(utils.SessionIDFlag, "s", "", "flag-id")
-	readTableCmd.Flags().StringP(utils.DateTimeFormatFlag, "", "YQL_FORMAT", "date-time-format")
+	for _, cmd := range []*cobra.Command{readTableCmd, ShellCmd} {
+		cmd.Flags().String(utils.CompressionFlag, "", "Arrow IPC compression of the pages: none, lz4_frame or zstd (server default if empty)")
+	}
+
+	for _, cmd := range []*cobra.Command{readTableCmd, describeTableCmd, ShellCmd} {
+		cmd.Flags().StringP(utils.UserIDFlag, "u", "", "user-id")
+		cmd.Flags().StringP(utils.SessionIDFlag, "s", "", "flag-id")
+		cmd.Flags().StringP(utils.DateTimeFormatFlag, "", "YQL_FORMAT", "date-time-format")
+	}
 }
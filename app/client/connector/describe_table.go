@@ -0,0 +1,74 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/client/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func describeTable(cmd *cobra.Command, _ []string) error {
+	format, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	typeMappingSettings, err := getTypeMappingSettings(cmd)
+	if err != nil {
+		return err
+	}
+
+	md, err := getRequestMetadata(cmd)
+	if err != nil {
+		return err
+	}
+
+	preset, err := utils.MakePreset(cmd)
+	if err != nil {
+		return fmt.Errorf("make preset: %w", err)
+	}
+
+	defer preset.Close()
+
+	client, err := common.NewClientBufferingFromClientConfig(preset.Logger, preset.Cfg)
+	if err != nil {
+		return fmt.Errorf("new client buffering from client config: %w", err)
+	}
+
+	defer client.Close()
+
+	ctx := metadata.NewOutgoingContext(context.Background(), md.toGRPC())
+
+	response, err := client.DescribeTable(ctx, preset.Cfg.DataSourceInstance, typeMappingSettings, preset.TableName)
+	if err != nil {
+		return fmt.Errorf("describe table: %w", err)
+	}
+
+	if !common.IsSuccess(response.Error) {
+		return common.NewSTDErrorFromAPIError(response.Error)
+	}
+
+	return writeSchema(os.Stdout, format, response.Schema)
+}
+
+func getTypeMappingSettings(cmd *cobra.Command) (*api_service_protos.TTypeMappingSettings, error) {
+	dateTimeFormatStr, err := cmd.Flags().GetString(utils.DateTimeFormatFlag)
+	if err != nil {
+		return nil, fmt.Errorf("get date-time-format flag: %v", err)
+	}
+
+	dateTimeFormat, exists := api_service_protos.EDateTimeFormat_value[dateTimeFormatStr]
+	if !exists {
+		return nil, fmt.Errorf("unknown date-time-format: %s", dateTimeFormatStr)
+	}
+
+	return &api_service_protos.TTypeMappingSettings{
+		DateTimeFormat: api_service_protos.EDateTimeFormat(dateTimeFormat),
+	}, nil
+}
@@ -0,0 +1,284 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/csv"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/parquet"
+	"github.com/apache/arrow/go/v13/parquet/compress"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// exportFormat is a file format used to dump the data read from the table
+type exportFormat string
+
+const (
+	exportFormatCSV        exportFormat = "csv"
+	exportFormatJSONLines  exportFormat = "jsonl"
+	exportFormatArrow      exportFormat = "arrow"
+	exportFormatParquet    exportFormat = "parquet"
+	exportFormatsSupported              = "csv, jsonl, arrow or parquet"
+)
+
+func parseExportFormat(value string) (exportFormat, error) {
+	switch format := exportFormat(value); format {
+	case exportFormatCSV, exportFormatJSONLines, exportFormatArrow, exportFormatParquet:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format '%s', expected %s", value, exportFormatsSupported)
+	}
+}
+
+// recordWriter converts Arrow records into the file format
+type recordWriter interface {
+	write(record arrow.Record) error
+	// close flushes buffers and writes footers if any, but doesn't close the underlying writer
+	close() error
+}
+
+var _ recordWriter = (*recordWriterCSV)(nil)
+
+type recordWriterCSV struct {
+	writer *csv.Writer
+}
+
+func (rw *recordWriterCSV) write(record arrow.Record) error {
+	if err := rw.writer.Write(record); err != nil {
+		return fmt.Errorf("write CSV: %w", err)
+	}
+
+	return nil
+}
+
+func (rw *recordWriterCSV) close() error {
+	if err := rw.writer.Flush(); err != nil {
+		return fmt.Errorf("flush CSV: %w", err)
+	}
+
+	return nil
+}
+
+var _ recordWriter = (*recordWriterJSONLines)(nil)
+
+type recordWriterJSONLines struct {
+	writer io.Writer
+}
+
+func (rw *recordWriterJSONLines) write(record arrow.Record) error {
+	if err := array.RecordToJSON(record, rw.writer); err != nil {
+		return fmt.Errorf("write JSON lines: %w", err)
+	}
+
+	return nil
+}
+
+func (*recordWriterJSONLines) close() error { return nil }
+
+var _ recordWriter = (*recordWriterArrow)(nil)
+
+type recordWriterArrow struct {
+	writer *ipc.Writer
+}
+
+func (rw *recordWriterArrow) write(record arrow.Record) error {
+	if err := rw.writer.Write(record); err != nil {
+		return fmt.Errorf("write Arrow IPC: %w", err)
+	}
+
+	return nil
+}
+
+func (rw *recordWriterArrow) close() error {
+	// writes end-of-stream marker
+	if err := rw.writer.Close(); err != nil {
+		return fmt.Errorf("close Arrow IPC writer: %w", err)
+	}
+
+	return nil
+}
+
+var _ recordWriter = (*recordWriterParquet)(nil)
+
+type recordWriterParquet struct {
+	writer *pqarrow.FileWriter
+}
+
+func (rw *recordWriterParquet) write(record arrow.Record) error {
+	// Every page becomes a separate row group, so that memory consumption doesn't depend on the table size
+	if err := rw.writer.Write(record); err != nil {
+		return fmt.Errorf("write Parquet: %w", err)
+	}
+
+	return nil
+}
+
+func (rw *recordWriterParquet) close() error {
+	// footer is written on close
+	if err := rw.writer.Close(); err != nil {
+		return fmt.Errorf("close Parquet file writer: %w", err)
+	}
+
+	return nil
+}
+
+func newRecordWriter(format exportFormat, w io.Writer, schema *arrow.Schema) (recordWriter, error) {
+	switch format {
+	case exportFormatCSV:
+		// Arrow CSV writer panics on the types it doesn't know
+		for _, field := range schema.Fields() {
+			if !isCSVSupportedType(field.Type) {
+				return nil, fmt.Errorf("column '%s' of type %s can't be written to CSV", field.Name, field.Type)
+			}
+		}
+
+		return &recordWriterCSV{writer: csv.NewWriter(w, schema, csv.WithHeader(true), csv.WithNullWriter("NULL"))}, nil
+	case exportFormatJSONLines:
+		return &recordWriterJSONLines{writer: w}, nil
+	case exportFormatArrow:
+		return &recordWriterArrow{writer: ipc.NewWriter(w, ipc.WithSchema(schema))}, nil
+	case exportFormatParquet:
+		writer, err := pqarrow.NewFileWriter(
+			schema,
+			// hide the Close method, otherwise Parquet writer would close the caller's writer
+			struct{ io.Writer }{w},
+			parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Zstd)),
+			pqarrow.DefaultWriterProps(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("new Parquet file writer: %w", err)
+		}
+
+		return &recordWriterParquet{writer: writer}, nil
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+func isCSVSupportedType(dataType arrow.DataType) bool {
+	switch t := dataType.(type) {
+	case *arrow.BooleanType,
+		*arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
+		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type,
+		*arrow.Float32Type, *arrow.Float64Type,
+		*arrow.StringType, *arrow.BinaryType,
+		*arrow.Date32Type, *arrow.Date64Type, *arrow.TimestampType,
+		*arrow.Decimal128Type, *arrow.Decimal256Type:
+		return true
+	case *arrow.ListType:
+		return isCSVSupportedType(t.Elem())
+	default:
+		return false
+	}
+}
+
+// exportStats describes the amount of data written
+type exportStats struct {
+	pages int
+	rows  int64
+}
+
+// exportReadSplitsResponses converts the pages of the ReadSplits stream one by one,
+// so that only a single page is kept in memory at a time.
+func exportReadSplitsResponses(
+	ctx context.Context,
+	results <-chan *common.StreamResult[*api_service_protos.TReadSplitsResponse],
+	format exportFormat,
+	w io.Writer,
+) (*exportStats, error) {
+	var (
+		writer recordWriter
+		stats  exportStats
+	)
+
+	for {
+		var (
+			result *common.StreamResult[*api_service_protos.TReadSplitsResponse]
+			ok     bool
+		)
+
+		select {
+		case result, ok = <-results:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if !ok {
+			break
+		}
+
+		if result.Err != nil {
+			return nil, fmt.Errorf("read splits result: %w", result.Err)
+		}
+
+		if !common.IsSuccess(result.Response.Error) {
+			return nil, fmt.Errorf("read splits result: %w", common.NewSTDErrorFromAPIError(result.Response.Error))
+		}
+
+		var err error
+
+		// the writer is created lazily, because the Arrow schema is known only when the first page arrives
+		writer, err = exportPage(writer, result.Response, format, w, &stats)
+		if err != nil {
+			return nil, fmt.Errorf("export page #%d: %w", stats.pages, err)
+		}
+	}
+
+	if writer == nil {
+		return &stats, nil
+	}
+
+	if err := writer.close(); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func exportPage(
+	writer recordWriter,
+	response *api_service_protos.TReadSplitsResponse,
+	format exportFormat,
+	w io.Writer,
+	stats *exportStats,
+) (recordWriter, error) {
+	reader, err := ipc.NewReader(bytes.NewReader(response.GetArrowIpcStreaming()))
+	if err != nil {
+		return writer, fmt.Errorf("new reader: %w", err)
+	}
+
+	defer reader.Release()
+
+	for reader.Next() {
+		record := reader.Record()
+
+		if writer == nil {
+			writer, err = newRecordWriter(format, w, record.Schema())
+			if err != nil {
+				return nil, fmt.Errorf("new record writer: %w", err)
+			}
+		}
+
+		if err := writer.write(record); err != nil {
+			return writer, err
+		}
+
+		stats.rows += record.NumRows()
+	}
+
+	if err := reader.Err(); err != nil {
+		return writer, fmt.Errorf("read Arrow IPC: %w", err)
+	}
+
+	stats.pages++
+
+	return writer, nil
+}
@@ -0,0 +1,108 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/file"
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var exportTestSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+	{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+}, nil)
+
+func makeExportTestPage(t *testing.T, ids []int32, names []string) *common.StreamResult[*api_service_protos.TReadSplitsResponse] {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, exportTestSchema)
+	defer builder.Release()
+
+	builder.Field(0).(*array.Int32Builder).AppendValues(ids, nil)
+
+	for _, name := range names {
+		if name == "" {
+			builder.Field(1).(*array.StringBuilder).AppendNull()
+		} else {
+			builder.Field(1).(*array.StringBuilder).Append(name)
+		}
+	}
+
+	record := builder.NewRecord()
+	defer record.Release()
+
+	var buf bytes.Buffer
+
+	writer := ipc.NewWriter(&buf, ipc.WithSchema(exportTestSchema))
+	require.NoError(t, writer.Write(record))
+	require.NoError(t, writer.Close())
+
+	return &common.StreamResult[*api_service_protos.TReadSplitsResponse]{
+		Response: &api_service_protos.TReadSplitsResponse{
+			Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{ArrowIpcStreaming: buf.Bytes()},
+			Error:   common.NewSuccess(),
+		},
+	}
+}
+
+func exportTestPages(t *testing.T, format exportFormat) (*exportStats, []byte) {
+	results := make(chan *common.StreamResult[*api_service_protos.TReadSplitsResponse], 2)
+	results <- makeExportTestPage(t, []int32{1, 2}, []string{"a", ""})
+	results <- makeExportTestPage(t, []int32{3}, []string{"c"})
+	close(results)
+
+	var buf bytes.Buffer
+
+	stats, err := exportReadSplitsResponses(context.Background(), results, format, &buf)
+	require.NoError(t, err)
+
+	return stats, buf.Bytes()
+}
+
+func TestExportReadSplitsResponses(t *testing.T) {
+	stats, data := exportTestPages(t, exportFormatCSV)
+	require.Equal(t, &exportStats{pages: 2, rows: 3}, stats)
+	require.Equal(t, "id,name\n1,a\n2,NULL\n3,c\n", string(data))
+
+	_, data = exportTestPages(t, exportFormatJSONLines)
+	require.Equal(t, "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":null}\n{\"id\":3,\"name\":\"c\"}\n", string(data))
+
+	_, data = exportTestPages(t, exportFormatArrow)
+	reader, err := ipc.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	var rows int64
+	for reader.Next() {
+		rows += reader.Record().NumRows()
+	}
+
+	reader.Release()
+	require.Equal(t, int64(3), rows)
+
+	_, data = exportTestPages(t, exportFormatParquet)
+	parquetReader, err := file.NewParquetReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), parquetReader.NumRows())
+	require.NoError(t, parquetReader.Close())
+}
+
+func TestExportReadSplitsResponsesError(t *testing.T) {
+	results := make(chan *common.StreamResult[*api_service_protos.TReadSplitsResponse], 1)
+	results <- &common.StreamResult[*api_service_protos.TReadSplitsResponse]{
+		Response: &api_service_protos.TReadSplitsResponse{
+			Error: common.NewAPIErrorFromStdError(common.ErrTableDoesNotExist, api_common.EGenericDataSourceKind_POSTGRESQL),
+		},
+	}
+	close(results)
+
+	_, err := exportReadSplitsResponses(context.Background(), results, exportFormatCSV, &bytes.Buffer{})
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package connector
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -13,6 +14,11 @@ import (
 )
 
 func listSplits(cmd *cobra.Command, _ []string) error {
+	format, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
 	preset, err := utils.MakePreset(cmd)
 	if err != nil {
 		return fmt.Errorf("make preset: %w", err)
@@ -43,24 +49,11 @@ func listSplits(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("list splits: %w", err)
 	}
 
-	for responseId, resp := range listSplitsResponse {
+	for _, resp := range listSplitsResponse {
 		if !common.IsSuccess(resp.Error) {
 			return common.NewSTDErrorFromAPIError(resp.Error)
 		}
-
-		for splitId, split := range resp.Splits {
-			logger.Info(
-				"Table split",
-				zap.Int("response_id", responseId),
-				zap.Int("split_id", splitId),
-			)
-
-			fmt.Println("Split select: ", common.MustProtobufToJSONString(split.Select, false, ""))
-			// fq-connector-go serializes split descriptions to JSON, so they're always human-readable
-			fmt.Println("Split description: ", string(split.GetDescription()))
-			fmt.Printf("\n")
-		}
 	}
 
-	return nil
+	return writeSplits(os.Stdout, format, common.ListSplitsResponsesToSplits(listSplitsResponse))
 }
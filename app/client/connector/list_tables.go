@@ -0,0 +1,72 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/client/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func listTables(cmd *cobra.Command, _ []string) error {
+	format, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	pattern, err := cmd.Flags().GetString(utils.PatternFlag)
+	if err != nil {
+		return fmt.Errorf("get pattern flag: %v", err)
+	}
+
+	preset, err := utils.MakePreset(cmd)
+	if err != nil {
+		return fmt.Errorf("make preset: %w", err)
+	}
+
+	defer preset.Close()
+
+	client, err := common.NewClientBufferingFromClientConfig(preset.Logger, preset.Cfg)
+	if err != nil {
+		return fmt.Errorf("new client buffering from client config: %w", err)
+	}
+
+	defer client.Close()
+
+	responses, err := client.ListTables(context.Background(), preset.Cfg.DataSourceInstance, pattern)
+	if err != nil {
+		return fmt.Errorf("list tables: %w", err)
+	}
+
+	if err := checkListTablesResponses(responses); err != nil {
+		return err
+	}
+
+	return writeTables(os.Stdout, format, responses)
+}
+
+// checkListTablesResponses fails if the server has not listed the tables,
+// the servers that do not support the method may answer with the empty stream
+func checkListTablesResponses(responses []*api_service_protos.TListTablesResponse) error {
+	if len(responses) == 0 {
+		return fmt.Errorf("list tables: %w", common.ErrMethodNotSupported)
+	}
+
+	for _, response := range responses {
+		if response.GetError().GetStatus() == Ydb.StatusIds_UNSUPPORTED {
+			return fmt.Errorf("list tables: %w: %s", common.ErrMethodNotSupported, response.GetError().GetMessage())
+		}
+
+		if !common.IsSuccess(response.Error) {
+			return common.NewSTDErrorFromAPIError(response.Error)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestCheckListTablesResponses(t *testing.T) {
+	// the empty stream must not be shown as the empty list of tables
+	require.ErrorIs(t, checkListTablesResponses(nil), common.ErrMethodNotSupported)
+
+	unsupported := []*api_service_protos.TListTablesResponse{
+		{Error: &api_service_protos.TError{Status: Ydb.StatusIds_UNSUPPORTED, Message: "list tables: method not supported"}},
+	}
+	require.ErrorIs(t, checkListTablesResponses(unsupported), common.ErrMethodNotSupported)
+
+	failed := []*api_service_protos.TListTablesResponse{
+		{Error: &api_service_protos.TError{Status: Ydb.StatusIds_UNAVAILABLE, Message: "connection refused"}},
+	}
+	require.EqualError(t, checkListTablesResponses(failed), "connection refused")
+
+	succeeded := []*api_service_protos.TListTablesResponse{
+		{Tables: []string{"a"}, Error: common.NewSuccess()},
+	}
+	require.NoError(t, checkListTablesResponses(succeeded))
+}
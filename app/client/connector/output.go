@@ -0,0 +1,214 @@
+package connector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/client/utils"
+)
+
+type outputFormat string
+
+const (
+	outputFormatTable outputFormat = "table"
+	outputFormatJSON  outputFormat = "json"
+	outputFormatYAML  outputFormat = "yaml"
+)
+
+func getOutputFormat(cmd *cobra.Command) (outputFormat, error) {
+	value, err := cmd.Flags().GetString(utils.OutputFlag)
+	if err != nil {
+		return "", fmt.Errorf("get output flag: %v", err)
+	}
+
+	return parseOutputFormat(value)
+}
+
+func parseOutputFormat(value string) (outputFormat, error) {
+	switch format := outputFormat(value); format {
+	case outputFormatTable, outputFormatJSON, outputFormatYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format: %s", value)
+	}
+}
+
+// writeStructured renders the value as JSON or YAML document
+func writeStructured(w io.Writer, format outputFormat, value any) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	// Protobuf messages must be marshaled with protojson to keep the field names from the proto files
+	if message, ok := value.(proto.Message); ok {
+		data, err = protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(message)
+	} else {
+		data, err = json.MarshalIndent(value, "", "  ")
+	}
+
+	if err != nil {
+		return fmt.Errorf("marshal to JSON: %w", err)
+	}
+
+	if format == outputFormatYAML {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return fmt.Errorf("convert JSON to YAML: %w", err)
+		}
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	if format == outputFormatJSON {
+		_, err = fmt.Fprintln(w)
+	}
+
+	return err
+}
+
+// writeTable renders rows with aligned columns
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if _, err := fmt.Fprintln(tw, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeSchema(w io.Writer, format outputFormat, schema *api_service_protos.TSchema) error {
+	if format != outputFormatTable {
+		return writeStructured(w, format, schema)
+	}
+
+	rows := make([][]string, 0, len(schema.GetColumns()))
+	for _, column := range schema.GetColumns() {
+		rows = append(rows, []string{column.GetName(), typeToYQLString(column.GetType())})
+	}
+
+	return writeTable(w, []string{"COLUMN", "TYPE"}, rows)
+}
+
+func writeTables(w io.Writer, format outputFormat, responses []*api_service_protos.TListTablesResponse) error {
+	tables := []string{}
+	for _, response := range responses {
+		tables = append(tables, response.GetTables()...)
+	}
+
+	if format != outputFormatTable {
+		return writeStructured(w, format, map[string][]string{"tables": tables})
+	}
+
+	rows := make([][]string, 0, len(tables))
+	for _, table := range tables {
+		rows = append(rows, []string{table})
+	}
+
+	return writeTable(w, []string{"TABLE"}, rows)
+}
+
+func writeSplits(w io.Writer, format outputFormat, splits []*api_service_protos.TSplit) error {
+	if format != outputFormatTable {
+		// json.RawMessage keeps protojson representation of the splits within the list
+		items := make([]json.RawMessage, 0, len(splits))
+
+		for _, split := range splits {
+			data, err := protojson.Marshal(split)
+			if err != nil {
+				return fmt.Errorf("marshal split: %w", err)
+			}
+
+			items = append(items, data)
+		}
+
+		return writeStructured(w, format, items)
+	}
+
+	rows := make([][]string, 0, len(splits))
+	for i, split := range splits {
+		// fq-connector-go serializes split descriptions to JSON, so they're always human-readable
+		rows = append(rows, []string{fmt.Sprint(i), split.GetSelect().GetFrom().GetTable(), string(split.GetDescription())})
+	}
+
+	return writeTable(w, []string{"ID", "TABLE", "DESCRIPTION"}, rows)
+}
+
+// typeToYQLString renders the type the way it's written in YQL, e.g. Optional<Timestamp>
+func typeToYQLString(t *Ydb.Type) string {
+	switch t.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		return primitiveTypeToYQLString(t.GetTypeId())
+	case *Ydb.Type_DecimalType:
+		return fmt.Sprintf("Decimal(%d,%d)", t.GetDecimalType().GetPrecision(), t.GetDecimalType().GetScale())
+	case *Ydb.Type_OptionalType:
+		return fmt.Sprintf("Optional<%s>", typeToYQLString(t.GetOptionalType().GetItem()))
+	case *Ydb.Type_ListType:
+		return fmt.Sprintf("List<%s>", typeToYQLString(t.GetListType().GetItem()))
+	case *Ydb.Type_TupleType:
+		elements := make([]string, 0, len(t.GetTupleType().GetElements()))
+		for _, element := range t.GetTupleType().GetElements() {
+			elements = append(elements, typeToYQLString(element))
+		}
+
+		return fmt.Sprintf("Tuple<%s>", strings.Join(elements, ","))
+	case *Ydb.Type_StructType:
+		members := make([]string, 0, len(t.GetStructType().GetMembers()))
+		for _, member := range t.GetStructType().GetMembers() {
+			members = append(members, fmt.Sprintf("'%s':%s", member.GetName(), typeToYQLString(member.GetType())))
+		}
+
+		return fmt.Sprintf("Struct<%s>", strings.Join(members, ","))
+	case *Ydb.Type_DictType:
+		return fmt.Sprintf("Dict<%s,%s>", typeToYQLString(t.GetDictType().GetKey()), typeToYQLString(t.GetDictType().GetPayload()))
+	case *Ydb.Type_TaggedType:
+		return fmt.Sprintf("Tagged<%s,'%s'>", typeToYQLString(t.GetTaggedType().GetType()), t.GetTaggedType().GetTag())
+	case *Ydb.Type_PgType:
+		return fmt.Sprintf("pg%s", t.GetPgType().GetTypeName())
+	case *Ydb.Type_VoidType:
+		return "Void"
+	case *Ydb.Type_NullType:
+		return "Null"
+	case *Ydb.Type_EmptyListType:
+		return "EmptyList"
+	case *Ydb.Type_EmptyDictType:
+		return "EmptyDict"
+	default:
+		// fallback for the types that are never returned by connector
+		return t.String()
+	}
+}
+
+// primitiveTypeToYQLString converts the enum names like TZ_DATETIME to YQL names like TzDatetime
+func primitiveTypeToYQLString(typeID Ydb.Type_PrimitiveTypeId) string {
+	if typeID == Ydb.Type_DYNUMBER {
+		return "DyNumber"
+	}
+
+	var sb strings.Builder
+
+	for _, word := range strings.Split(typeID.String(), "_") {
+		sb.WriteString(word[:1])
+		sb.WriteString(strings.ToLower(word[1:]))
+	}
+
+	return sb.String()
+}
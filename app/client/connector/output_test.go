@@ -0,0 +1,49 @@
+package connector
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestTypeToYQLString(t *testing.T) {
+	testCases := []struct {
+		ydbType  *Ydb.Type
+		expected string
+	}{
+		{common.MakePrimitiveType(Ydb.Type_INT32), "Int32"},
+		{common.MakePrimitiveType(Ydb.Type_TZ_DATETIME), "TzDatetime"},
+		{common.MakePrimitiveType(Ydb.Type_DYNUMBER), "DyNumber"},
+		{common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)), "Optional<Utf8>"},
+		{common.MakeListType(common.MakePrimitiveType(Ydb.Type_TIMESTAMP)), "List<Timestamp>"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, typeToYQLString(tc.ydbType))
+	}
+}
+
+func TestWriteTables(t *testing.T) {
+	responses := []*api_service_protos.TListTablesResponse{
+		{Tables: []string{"a", "b"}},
+		{Tables: []string{"c"}},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, writeTables(&buf, outputFormatTable, responses))
+	require.Equal(t, "TABLE\na\nb\nc\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeTables(&buf, outputFormatJSON, responses))
+	require.JSONEq(t, `{"tables":["a","b","c"]}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeTables(&buf, outputFormatYAML, responses))
+	require.Equal(t, "tables:\n- a\n- b\n- c\n", buf.String())
+}
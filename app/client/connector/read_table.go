@@ -3,8 +3,10 @@ package connector
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
-	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
@@ -12,7 +14,6 @@ import (
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/client/utils"
-	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -20,27 +21,103 @@ import (
 type requestMetadata struct {
 	userID    string
 	sessionID string
+	// Arrow IPC compression codec requested from the server
+	arrowCompression string
 }
 
-func readTable(cmd *cobra.Command, _ []string) error {
-	dateTimeFormatStr, err := cmd.Flags().GetString(utils.DateTimeFormatFlag)
-	if err != nil {
-		return fmt.Errorf("get date-time-format flag: %v", err)
-	}
+func (md requestMetadata) toGRPC() metadata.MD {
+	result := metadata.New(map[string]string{"user_id": md.userID, "session_id": md.sessionID})
 
-	dateTimeFormat, exists := api_service_protos.EDateTimeFormat_value[dateTimeFormatStr]
-	if !exists {
-		return fmt.Errorf("unknown date-time-format: %s", dateTimeFormatStr)
+	if md.arrowCompression != "" {
+		result.Set(common.ArrowCompression, md.arrowCompression)
 	}
 
+	return result
+}
+
+func getRequestMetadata(cmd *cobra.Command) (requestMetadata, error) {
 	userID, err := cmd.Flags().GetString(utils.UserIDFlag)
 	if err != nil {
-		return fmt.Errorf("get user flag: %v", err)
+		return requestMetadata{}, fmt.Errorf("get user flag: %v", err)
 	}
 
 	sessionID, err := cmd.Flags().GetString(utils.SessionIDFlag)
 	if err != nil {
-		return fmt.Errorf("get session flag: %v", err)
+		return requestMetadata{}, fmt.Errorf("get session flag: %v", err)
+	}
+
+	md := requestMetadata{userID: userID, sessionID: sessionID}
+
+	// compression makes sense only for the commands reading data
+	if cmd.Flags().Lookup(utils.CompressionFlag) != nil {
+		if md.arrowCompression, err = cmd.Flags().GetString(utils.CompressionFlag); err != nil {
+			return requestMetadata{}, fmt.Errorf("get compression flag: %v", err)
+		}
+	}
+
+	return md, nil
+}
+
+// readTableParams describes what to read from the table and where to write it
+type readTableParams struct {
+	tableName           string
+	typeMappingSettings *api_service_protos.TTypeMappingSettings
+	// columns to read; empty list means all the columns
+	columns []string
+	// filter in the YQL-like syntax, see parseWhere
+	where        string
+	schemaFormat outputFormat
+	exportFormat exportFormat
+	metainfo     requestMetadata
+}
+
+func getReadTableParams(cmd *cobra.Command) (*readTableParams, error) {
+	var (
+		params = &readTableParams{}
+		err    error
+	)
+
+	if params.schemaFormat, err = getOutputFormat(cmd); err != nil {
+		return nil, err
+	}
+
+	if params.typeMappingSettings, err = getTypeMappingSettings(cmd); err != nil {
+		return nil, err
+	}
+
+	if params.metainfo, err = getRequestMetadata(cmd); err != nil {
+		return nil, err
+	}
+
+	exportFormatStr, err := cmd.Flags().GetString(utils.OutputFormatFlag)
+	if err != nil {
+		return nil, fmt.Errorf("get output-format flag: %v", err)
+	}
+
+	if params.exportFormat, err = parseExportFormat(exportFormatStr); err != nil {
+		return nil, err
+	}
+
+	if params.where, err = cmd.Flags().GetString(utils.WhereFlag); err != nil {
+		return nil, fmt.Errorf("get where flag: %v", err)
+	}
+
+	if params.columns, err = cmd.Flags().GetStringSlice(utils.ColumnsFlag); err != nil {
+		return nil, fmt.Errorf("get columns flag: %v", err)
+	}
+
+	return params, nil
+}
+
+func readTable(cmd *cobra.Command, _ []string) error {
+	params, err := getReadTableParams(cmd)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := cmd.Flags().GetString(utils.OutputFileFlag)
+	if err != nil {
+		return fmt.Errorf("get output-file flag: %v", err)
 	}
 
 	preset, err := utils.MakePreset(cmd)
@@ -50,89 +127,87 @@ func readTable(cmd *cobra.Command, _ []string) error {
 
 	defer preset.Close()
 
-	client, err := common.NewClientBufferingFromClientConfig(preset.Logger, preset.Cfg)
-	if err != nil {
-		return fmt.Errorf("new client buffering from client config: %w", err)
-	}
+	params.tableName = preset.TableName
+
+	var w io.Writer = os.Stdout
+
+	if outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
 
-	defer client.Close()
+		defer common.LogCloserError(preset.Logger, f, "output file")
 
-	md := requestMetadata{
-		userID:    userID,
-		sessionID: sessionID,
+		w = f
 	}
 
-	if err := doReadTable(
-		preset.Logger,
-		preset.Cfg,
-		preset.TableName,
-		api_service_protos.EDateTimeFormat(dateTimeFormat),
-		md); err != nil {
+	if err := doReadTable(preset, params, w); err != nil {
 		return fmt.Errorf("call server: %w", err)
 	}
 
 	return nil
 }
 
-func doReadTable(
-	logger *zap.Logger,
-	cfg *config.TClientConfig,
-	tableName string,
-	dateTimeFormat api_service_protos.EDateTimeFormat,
-	metainfo requestMetadata) error {
-	cl, err := common.NewClientBufferingFromClientConfig(logger, cfg)
+func doReadTable(preset *utils.Preset, params *readTableParams, w io.Writer) error {
+	logger, dsi := preset.Logger, preset.Cfg.DataSourceInstance
+
+	cl, err := common.NewClientStreamingFromClientConfig(logger, preset.Cfg)
 	if err != nil {
-		return fmt.Errorf("new client buffering from client config: %w", err)
+		return fmt.Errorf("new client streaming from client config: %w", err)
 	}
 
 	defer cl.Close()
 
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), params.metainfo.toGRPC()))
+	defer cancel()
+
 	var splits []*api_service_protos.TSplit
 
-	switch cfg.DataSourceInstance.Kind {
+	switch dsi.Kind {
 	case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
 		api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
 		api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM,
 		api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_LOGGING,
 		api_common.EGenericDataSourceKind_MONGO_DB, api_common.EGenericDataSourceKind_REDIS,
 		api_common.EGenericDataSourceKind_OPENSEARCH:
-		typeMappingSettings := &api_service_protos.TTypeMappingSettings{
-			DateTimeFormat: dateTimeFormat,
-		}
-
-		splits, err = describeTableAndListSplits(logger, cl, cfg.DataSourceInstance, typeMappingSettings, tableName, metainfo)
+		splits, err = describeTableAndListSplits(ctx, logger, cl, dsi, params)
 		if err != nil {
 			return fmt.Errorf("prepare splits: %w", err)
 		}
 
 		logger.Info("got splits", zap.Int("total", len(splits)))
 	default:
-		return fmt.Errorf("unexpected data source kind %v", cfg.DataSourceInstance.Kind)
+		return fmt.Errorf("unexpected data source kind %v", dsi.Kind)
 	}
 
 	// ReadSplits
-	if err := readSplits(logger, cl, splits, metainfo); err != nil {
+	results, err := cl.ReadSplits(ctx, splits)
+	if err != nil {
 		return fmt.Errorf("read splits: %w", err)
 	}
 
+	stats, err := exportReadSplitsResponses(ctx, results, params.exportFormat, w)
+	if err != nil {
+		return fmt.Errorf("export read splits responses: %w", err)
+	}
+
+	logger.Info("table has been read", zap.Int("pages", stats.pages), zap.Int64("rows", stats.rows))
+
 	return nil
 }
 
 func describeTableAndListSplits(
+	ctx context.Context,
 	logger *zap.Logger,
-	cl *common.ClientBuffering,
+	cl *common.ClientStreaming,
 	dsi *api_common.TGenericDataSourceInstance,
-	typeMappingSettings *api_service_protos.TTypeMappingSettings,
-	tableName string,
-	metainfo requestMetadata,
+	params *readTableParams,
 ) ([]*api_service_protos.TSplit, error) {
-	md := metadata.New(map[string]string{"user_id": metainfo.userID, "session_id": metainfo.sessionID})
-	ctx := metadata.NewOutgoingContext(context.Background(), md)
-
 	// DescribeTable
 	logger.Debug("describing table")
 
-	describeTableResponse, err := cl.DescribeTable(ctx, dsi, typeMappingSettings, tableName)
+	describeTableResponse, err := cl.DescribeTable(ctx, dsi, params.typeMappingSettings, params.tableName)
 	if err != nil {
 		return nil, fmt.Errorf("describe table: %w", err)
 	}
@@ -141,76 +216,97 @@ func describeTableAndListSplits(
 		return nil, fmt.Errorf("describe table: %v", describeTableResponse.Error)
 	}
 
-	logger.Info("got table schema", zap.String("result", describeTableResponse.Schema.String()))
-
-	// ListSplits - we want to SELECT *
-	slct := &api_service_protos.TSelect{
-		DataSourceInstance: dsi,
-		What:               common.SchemaToSelectWhatItems(describeTableResponse.Schema, nil),
-		From: &api_service_protos.TSelect_TFrom{
-			Table: tableName,
-		},
+	// stdout may be occupied by the data
+	if err := writeSchema(os.Stderr, params.schemaFormat, describeTableResponse.Schema); err != nil {
+		return nil, fmt.Errorf("write schema: %w", err)
 	}
 
-	logger.Debug("listing splits", zap.String("select", slct.String()))
+	var predicate *api_service_protos.TPredicate
+
+	if params.where != "" {
+		predicate, err = parseWhere(params.where, describeTableResponse.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parse where: %w", err)
+		}
+	}
 
-	listSplitsResponse, err := cl.ListSplits(ctx, slct)
+	slct, err := makeSelect(dsi, describeTableResponse.Schema, params.tableName, params.columns, predicate)
 	if err != nil {
-		return nil, fmt.Errorf("list splits: %w", err)
+		return nil, fmt.Errorf("make select: %w", err)
 	}
 
-	logger.Info("got ListSplits responses", zap.Int("total_responses", len(listSplitsResponse)))
+	logger.Debug("listing splits", zap.String("select", slct.String()))
 
-	return common.ListSplitsResponsesToSplits(listSplitsResponse), nil
+	return collectSplits(ctx, cl, slct)
 }
 
-func readSplits(
-	logger *zap.Logger,
-	cl *common.ClientBuffering,
-	splits []*api_service_protos.TSplit,
-	metainfo requestMetadata,
-) error {
-	md := metadata.New(map[string]string{"user_id": metainfo.userID, "session_id": metainfo.sessionID})
-	ctx := metadata.NewOutgoingContext(context.Background(), md)
-
-	readSplitsResponses, err := cl.ReadSplits(ctx, splits)
+func collectSplits(
+	ctx context.Context,
+	cl *common.ClientStreaming,
+	slct *api_service_protos.TSelect,
+) ([]*api_service_protos.TSplit, error) {
+	results, err := cl.ListSplits(ctx, slct)
 	if err != nil {
-		return fmt.Errorf("read splits: %w", err)
+		return nil, fmt.Errorf("list splits: %w", err)
 	}
 
-	if err = common.ExtractErrorFromReadResponses(readSplitsResponses); err != nil {
-		return fmt.Errorf("extract error from read responses: %w", err)
-	}
+	var splits []*api_service_protos.TSplit
 
-	logger.Info("got ReadSplits responses", zap.Int("total_responses", len(readSplitsResponses)))
+	for result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("list splits result: %w", result.Err)
+		}
 
-	records, err := common.ReadResponsesToArrowRecords(readSplitsResponses)
-	if err != nil {
-		return fmt.Errorf("read responses to arrow records: %w", err)
-	}
+		if !common.IsSuccess(result.Response.Error) {
+			return nil, fmt.Errorf("list splits result: %w", common.NewSTDErrorFromAPIError(result.Response.Error))
+		}
 
-	dumpReadResponses(logger, records)
+		splits = append(splits, result.Response.Splits...)
+	}
 
-	return nil
+	return splits, nil
 }
 
-func dumpReadResponses(
-	logger *zap.Logger,
-	records []arrow.Record,
-) {
-	for i, record := range records {
-		logger.Info(
-			"dumping record",
-			zap.Int("id", i),
-			zap.Int("num_columns", int(record.NumCols())),
-			zap.Int("num_rows", int(record.NumRows())),
-		)
-
-		for i, column := range record.Columns() {
-			logger.Debug("column",
-				zap.Int("id", i),
-				zap.String("column_name", record.ColumnName(i)),
-				zap.String("data", column.String()))
+// makeSelect builds the same select that YQ makes for the query like
+// SELECT $columns FROM $table WHERE $predicate
+func makeSelect(
+	dsi *api_common.TGenericDataSourceInstance,
+	schema *api_service_protos.TSchema,
+	tableName string,
+	columns []string,
+	predicate *api_service_protos.TPredicate,
+) (*api_service_protos.TSelect, error) {
+	var whitelist map[string]struct{}
+
+	if len(columns) > 0 {
+		whitelist = make(map[string]struct{}, len(columns))
+
+		known := make(map[string]struct{}, len(schema.Columns))
+		for _, column := range schema.Columns {
+			known[column.Name] = struct{}{}
 		}
+
+		for _, column := range columns {
+			column = strings.TrimSpace(column)
+			if _, exists := known[column]; !exists {
+				return nil, fmt.Errorf("unknown column '%s'", column)
+			}
+
+			whitelist[column] = struct{}{}
+		}
+	}
+
+	slct := &api_service_protos.TSelect{
+		DataSourceInstance: dsi,
+		What:               common.SchemaToSelectWhatItems(schema, whitelist),
+		From: &api_service_protos.TSelect_TFrom{
+			Table: tableName,
+		},
 	}
+
+	if predicate != nil {
+		slct.Where = &api_service_protos.TSelect_TWhere{FilterTyped: predicate}
+	}
+
+	return slct, nil
 }
@@ -0,0 +1,370 @@
+package connector
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/client/utils"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	app_server_config "github.com/ydb-platform/fq-connector-go/app/server/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+const shellHelp = `Commands:
+  \tables [pattern]        list tables
+  \describe <table>        show table schema
+  \explain <select>        show approximate SQL queries of the connector, requires --server-config
+  \output table|json|yaml  change output format for \tables and \describe
+  \help                    show this help
+  \quit                    exit
+  select <columns|*> from <table> [where <filter>] [limit <n>]
+
+Filter supports comparisons, IS [NOT] NULL, [NOT] IN, AND, OR, NOT and parentheses.
+Identifiers that are not plain words (e.g. schema.table) must be quoted with backticks.
+`
+
+// shellDefaultLimit is the number of rows printed for the queries without LIMIT
+const shellDefaultLimit = 100
+
+// shell is an interactive session bound to the data source instance from the client config
+type shell struct {
+	logger              *zap.Logger
+	cl                  *common.ClientStreaming
+	dsi                 *api_common.TGenericDataSourceInstance
+	typeMappingSettings *api_service_protos.TTypeMappingSettings
+	// local copy of the connector config used to render the SQL queries, nil if not provided
+	serverCfg        *config.TServerConfig
+	serverConfigPath string
+	format           outputFormat
+	metainfo         requestMetadata
+	out              io.Writer
+}
+
+var errShellQuit = errors.New("quit")
+
+func runShell(cmd *cobra.Command, _ []string) error {
+	format, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	typeMappingSettings, err := getTypeMappingSettings(cmd)
+	if err != nil {
+		return err
+	}
+
+	md, err := getRequestMetadata(cmd)
+	if err != nil {
+		return err
+	}
+
+	serverConfigPath, err := cmd.Flags().GetString(utils.ServerConfigFlag)
+	if err != nil {
+		return fmt.Errorf("get server-config flag: %v", err)
+	}
+
+	var serverCfg *config.TServerConfig
+
+	if serverConfigPath != "" {
+		if serverCfg, err = app_server_config.NewConfigFromFile(serverConfigPath); err != nil {
+			return fmt.Errorf("new server config from file: %w", err)
+		}
+	}
+
+	preset, err := utils.MakePreset(cmd)
+	if err != nil {
+		return fmt.Errorf("make preset: %w", err)
+	}
+
+	defer preset.Close()
+
+	cl, err := common.NewClientStreamingFromClientConfig(preset.Logger, preset.Cfg)
+	if err != nil {
+		return fmt.Errorf("new client streaming from client config: %w", err)
+	}
+
+	defer cl.Close()
+
+	s := &shell{
+		logger:              preset.Logger,
+		cl:                  cl,
+		dsi:                 preset.Cfg.DataSourceInstance,
+		typeMappingSettings: typeMappingSettings,
+		serverCfg:           serverCfg,
+		serverConfigPath:    serverConfigPath,
+		format:              format,
+		metainfo:            md,
+		out:                 os.Stdout,
+	}
+
+	return s.run(os.Stdin)
+}
+
+func (s *shell) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+
+	for {
+		fmt.Fprintf(s.out, "%s> ", strings.ToLower(s.dsi.Kind.String()))
+
+		if !scanner.Scan() {
+			fmt.Fprintln(s.out)
+			return scanner.Err()
+		}
+
+		line := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(scanner.Text()), ";"))
+		if line == "" {
+			continue
+		}
+
+		err := s.execute(line)
+		if errors.Is(err, errShellQuit) {
+			return nil
+		}
+
+		if err != nil {
+			fmt.Fprintf(s.out, "ERROR: %v\n", err)
+		}
+	}
+}
+
+func (s *shell) execute(line string) error {
+	// every command has its own context, so that the streams are closed when the command is done
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), s.metainfo.toGRPC()))
+	defer cancel()
+
+	command, argument, _ := strings.Cut(line, " ")
+	argument = strings.TrimSpace(argument)
+
+	switch command {
+	case `\q`, `\quit`:
+		return errShellQuit
+	case `\?`, `\h`, `\help`:
+		_, err := fmt.Fprint(s.out, shellHelp)
+
+		return err
+	case `\tables`:
+		return s.listTables(ctx, argument)
+	case `\describe`, `\d`:
+		if argument == "" {
+			return fmt.Errorf("table name is required")
+		}
+
+		return s.describeTable(ctx, argument)
+	case `\explain`:
+		return s.explain(ctx, argument)
+	case `\output`:
+		format, err := parseOutputFormat(argument)
+		if err != nil {
+			return err
+		}
+
+		s.format = format
+
+		return nil
+	}
+
+	if strings.HasPrefix(command, `\`) {
+		return fmt.Errorf("unknown command %s, type \\help for help", command)
+	}
+
+	return s.query(ctx, line)
+}
+
+func (s *shell) listTables(ctx context.Context, pattern string) error {
+	results, err := s.cl.ListTables(ctx, s.dsi, pattern)
+	if err != nil {
+		return fmt.Errorf("list tables: %w", err)
+	}
+
+	var responses []*api_service_protos.TListTablesResponse
+
+	for result := range results {
+		if result.Err != nil {
+			return fmt.Errorf("list tables result: %w", result.Err)
+		}
+
+		responses = append(responses, result.Response)
+	}
+
+	if err := checkListTablesResponses(responses); err != nil {
+		return err
+	}
+
+	return writeTables(s.out, s.format, responses)
+}
+
+func (s *shell) describeTable(ctx context.Context, table string) error {
+	schema, err := s.getSchema(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	return writeSchema(s.out, s.format, schema)
+}
+
+func (s *shell) getSchema(ctx context.Context, table string) (*api_service_protos.TSchema, error) {
+	response, err := s.cl.DescribeTable(ctx, s.dsi, s.typeMappingSettings, table)
+	if err != nil {
+		return nil, fmt.Errorf("describe table: %w", err)
+	}
+
+	if !common.IsSuccess(response.Error) {
+		return nil, common.NewSTDErrorFromAPIError(response.Error)
+	}
+
+	return response.Schema, nil
+}
+
+// prepareSplits parses the query and lists the splits for it
+func (s *shell) prepareSplits(ctx context.Context, query string) (*selectStatement, []*api_service_protos.TSplit, error) {
+	stmt, err := parseSelectStatement(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse query: %w", err)
+	}
+
+	schema, err := s.getSchema(ctx, stmt.table)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var predicate *api_service_protos.TPredicate
+
+	if stmt.where != nil {
+		if predicate, err = parsePredicate(stmt.where, schema); err != nil {
+			return nil, nil, fmt.Errorf("parse where: %w", err)
+		}
+	}
+
+	slct, err := makeSelect(s.dsi, schema, stmt.table, stmt.columns, predicate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("make select: %w", err)
+	}
+
+	splits, err := collectSplits(ctx, s.cl, slct)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stmt, splits, nil
+}
+
+func (s *shell) query(ctx context.Context, query string) error {
+	stmt, splits, err := s.prepareSplits(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	limit, truncated := stmt.limit, false
+	if limit == 0 {
+		limit = shellDefaultLimit
+	}
+
+	results, err := s.cl.ReadSplits(ctx, splits)
+	if err != nil {
+		return fmt.Errorf("read splits: %w", err)
+	}
+
+	var (
+		header []string
+		rows   [][]string
+	)
+
+	for result := range results {
+		if result.Err != nil {
+			return fmt.Errorf("read splits result: %w", result.Err)
+		}
+
+		if !common.IsSuccess(result.Response.Error) {
+			return common.NewSTDErrorFromAPIError(result.Response.Error)
+		}
+
+		records, err := common.ReadResponsesToArrowRecords([]*api_service_protos.TReadSplitsResponse{result.Response})
+		if err != nil {
+			return fmt.Errorf("read response to Arrow records: %w", err)
+		}
+
+		for _, record := range records {
+			if header == nil {
+				header = recordHeader(record)
+			}
+
+			rows = appendRecordRows(rows, record, limit)
+			record.Release()
+		}
+
+		// the rest of the stream is dropped when the context is cancelled
+		if int64(len(rows)) >= limit {
+			truncated = stmt.limit == 0
+
+			break
+		}
+	}
+
+	if header == nil {
+		_, err := fmt.Fprintln(s.out, "(0 rows)")
+
+		return err
+	}
+
+	if err := writeTable(s.out, header, rows); err != nil {
+		return err
+	}
+
+	if truncated {
+		_, err = fmt.Fprintf(s.out, "(first %d rows, use LIMIT to change)\n", len(rows))
+	} else {
+		_, err = fmt.Fprintf(s.out, "(%d rows)\n", len(rows))
+	}
+
+	return err
+}
+
+func recordHeader(record arrow.Record) []string {
+	header := make([]string, 0, record.NumCols())
+	for _, field := range record.Schema().Fields() {
+		header = append(header, field.Name)
+	}
+
+	return header
+}
+
+func appendRecordRows(rows [][]string, record arrow.Record, limit int64) [][]string {
+	for i := 0; i < int(record.NumRows()) && int64(len(rows)) < limit; i++ {
+		row := make([]string, 0, record.NumCols())
+
+		for _, column := range record.Columns() {
+			row = append(row, formatArrowValue(column, i))
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func formatArrowValue(column arrow.Array, i int) string {
+	if column.IsNull(i) {
+		return "NULL"
+	}
+
+	// YQL String values are often texts, print them as is when possible
+	if binary, ok := column.(*array.Binary); ok && utf8.Valid(binary.Value(i)) {
+		return string(binary.Value(i))
+	}
+
+	return column.ValueStr(i)
+}
@@ -0,0 +1,152 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/client/utils"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/clickhouse"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/logging"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ms_sql_server"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/mysql"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/oracle"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/postgresql"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb"
+	"github.com/ydb-platform/fq-connector-go/app/server/policy"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// explain lists the splits for the query on the connector and renders the SQL query for every split
+// with the same code the connector uses, so the effect of the predicate pushdown can be observed.
+// The pushdown and data policy settings are taken from the local copy of the server config,
+// so the output is an approximation: it's up to the user to keep the copy in sync with the server.
+func (s *shell) explain(ctx context.Context, query string) error {
+	if s.serverCfg == nil {
+		return fmt.Errorf(
+			"explain requires --%s flag: SQL queries depend on the pushdown and data policy settings of the server",
+			utils.ServerConfigFlag,
+		)
+	}
+
+	formatter, err := newSQLFormatter(s.dsi.Kind, s.serverCfg.GetDatasources())
+	if err != nil {
+		return err
+	}
+
+	enforcer, err := policy.NewEnforcer(s.serverCfg.GetDataPolicy())
+	if err != nil {
+		return fmt.Errorf("new data policy enforcer: %w", err)
+	}
+
+	_, splits, err := s.prepareSplits(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(s.out, "-- approximation rendered with the server config %s\n", s.serverConfigPath); err != nil {
+		return err
+	}
+
+	for i, split := range splits {
+		if err := s.explainSplit(ctx, formatter, enforcer, i, split); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// explainSplit renders the query for the split restricted by the data policy in the same way as the connector does
+func (s *shell) explainSplit(
+	ctx context.Context,
+	formatter rdbms_utils.SQLFormatter,
+	enforcer policy.Enforcer,
+	i int,
+	split *api_service_protos.TSplit,
+) error {
+	// YQ asks connector to apply filters when it's possible, the unsupported ones are omitted
+	filtering := api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL
+
+	restrictions, err := enforcer.ApplyToSelect(split.GetSelect(), filtering)
+	if err != nil {
+		return fmt.Errorf("apply data policy to split #%d: %w", i, err)
+	}
+
+	if restrictions.MandatoryFiltering {
+		filtering = api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY
+	}
+
+	split = proto.Clone(split).(*api_service_protos.TSplit)
+	split.Select = restrictions.Select
+
+	selectQuery, err := rdbms_utils.MakeSelectQuery(ctx, s.logger, formatter, split, filtering, split.GetSelect().GetFrom().GetTable())
+	if err != nil {
+		return fmt.Errorf("make select query for split #%d: %w", i, err)
+	}
+
+	if _, err := fmt.Fprintf(s.out, "-- split #%d\n", i); err != nil {
+		return err
+	}
+
+	if len(restrictions.Decisions) > 0 {
+		if _, err := fmt.Fprintf(s.out, "-- data policy: %s\n", restrictions.Decisions); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintln(s.out, selectQuery.QueryText); err != nil {
+		return err
+	}
+
+	for j, arg := range selectQuery.QueryArgs.GetAll() {
+		if _, err := fmt.Fprintf(s.out, "-- $%d = %s (%s)\n", j+1, formatQueryArg(arg.Value), typeToYQLString(arg.YdbType)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatQueryArg dereferences the values of optional types
+func formatQueryArg(value any) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Pointer {
+		return fmt.Sprintf("%v", value)
+	}
+
+	if v.IsNil() {
+		return "NULL"
+	}
+
+	return fmt.Sprintf("%v", v.Elem().Interface())
+}
+
+func newSQLFormatter(kind api_common.EGenericDataSourceKind, cfg *config.TDatasourcesConfig) (rdbms_utils.SQLFormatter, error) {
+	switch kind {
+	case api_common.EGenericDataSourceKind_CLICKHOUSE:
+		return clickhouse.NewSQLFormatter(cfg.GetClickhouse().GetPushdown()), nil
+	case api_common.EGenericDataSourceKind_POSTGRESQL:
+		return postgresql.NewSQLFormatter(cfg.GetPostgresql().GetPushdown()), nil
+	case api_common.EGenericDataSourceKind_GREENPLUM:
+		return postgresql.NewSQLFormatter(cfg.GetGreenplum().GetPushdown()), nil
+	case api_common.EGenericDataSourceKind_MYSQL:
+		return mysql.NewSQLFormatter(cfg.GetMysql().GetPushdown()), nil
+	case api_common.EGenericDataSourceKind_MS_SQL_SERVER:
+		return ms_sql_server.NewSQLFormatter(cfg.GetMsSqlServer().GetPushdown()), nil
+	case api_common.EGenericDataSourceKind_ORACLE:
+		return oracle.NewSQLFormatter(cfg.GetOracle().GetPushdown()), nil
+	case api_common.EGenericDataSourceKind_YDB:
+		return ydb.NewSQLFormatter(cfg.GetYdb().GetMode(), cfg.GetYdb().GetPushdown()), nil
+	case api_common.EGenericDataSourceKind_LOGGING:
+		return logging.NewSQLFormatter(ydb.NewSQLFormatter(cfg.GetLogging().GetYdb().GetMode(), cfg.GetLogging().GetYdb().GetPushdown())), nil
+	default:
+		return nil, fmt.Errorf("explain is not supported for %v: %w", kind, common.ErrDataSourceNotSupported)
+	}
+}
@@ -0,0 +1,95 @@
+package connector
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// selectStatement is a parsed query like
+// SELECT a, b FROM t WHERE a > 5 LIMIT 10
+type selectStatement struct {
+	// nil means all the columns
+	columns []string
+	table   string
+	// tokens of the WHERE clause terminated with tokenEOF; nil if there is no filter
+	where []token
+	// zero means no limit
+	limit int64
+}
+
+func parseSelectStatement(query string) (*selectStatement, error) {
+	tokens, err := tokenizeWhere(query)
+	if err != nil {
+		return nil, fmt.Errorf("tokenize: %w", err)
+	}
+
+	p := &whereParser{tokens: tokens}
+	stmt := &selectStatement{}
+
+	if err := p.expect(tokenKeyword, "SELECT"); err != nil {
+		return nil, err
+	}
+
+	if p.peek().is(tokenPunctuation, "*") {
+		p.next()
+	} else if stmt.columns, err = parseIdentifierList(p); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenKeyword, "FROM"); err != nil {
+		return nil, err
+	}
+
+	table := p.next()
+	if table.kind != tokenIdentifier {
+		return nil, fmt.Errorf("expected table name, got %s at position %d", table, table.pos)
+	}
+
+	stmt.table = table.value
+
+	if p.peek().is(tokenKeyword, "WHERE") {
+		p.next()
+
+		// the filter is parsed later, when the table schema is known
+		start := p.pos
+		for p.peek().kind != tokenEOF && !p.peek().is(tokenKeyword, "LIMIT") {
+			p.next()
+		}
+
+		stmt.where = append(append([]token{}, tokens[start:p.pos]...), token{kind: tokenEOF, pos: p.peek().pos})
+	}
+
+	if p.peek().is(tokenKeyword, "LIMIT") {
+		p.next()
+
+		limit := p.next()
+		if stmt.limit, err = strconv.ParseInt(limit.value, 10, 64); limit.kind != tokenNumber || err != nil || stmt.limit <= 0 {
+			return nil, fmt.Errorf("expected positive integer, got %s at position %d", limit, limit.pos)
+		}
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+	}
+
+	return stmt, nil
+}
+
+func parseIdentifierList(p *whereParser) ([]string, error) {
+	var identifiers []string
+
+	for {
+		t := p.next()
+		if t.kind != tokenIdentifier {
+			return nil, fmt.Errorf("expected column name, got %s at position %d", t, t.pos)
+		}
+
+		identifiers = append(identifiers, t.value)
+
+		if !p.peek().is(tokenPunctuation, ",") {
+			return identifiers, nil
+		}
+
+		p.next()
+	}
+}
@@ -0,0 +1,76 @@
+package connector
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+)
+
+func TestParseSelectStatement(t *testing.T) {
+	stmt, err := parseSelectStatement("select a, `b c` from `public.t` where a > 5 and (b is null) limit 10")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b c"}, stmt.columns)
+	require.Equal(t, "public.t", stmt.table)
+	require.Equal(t, int64(10), stmt.limit)
+
+	predicate, err := parsePredicate(stmt.where, whereTestSchema)
+	require.ErrorContains(t, err, "unknown column 'a'")
+	require.Nil(t, predicate)
+
+	stmt, err = parseSelectStatement("SELECT * FROM t WHERE id = 1")
+	require.NoError(t, err)
+	require.Nil(t, stmt.columns)
+	require.Zero(t, stmt.limit)
+
+	predicate, err = parsePredicate(stmt.where, whereTestSchema)
+	require.NoError(t, err)
+	require.Equal(t, "id", predicate.GetComparison().GetLeftValue().GetColumn())
+
+	for _, query := range []string{
+		"select from t",
+		"select * t",
+		"select * from t limit 0",
+		"select * from t limit 10 where id = 1",
+		"update t set a = 1",
+	} {
+		_, err := parseSelectStatement(query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestShellCommands(t *testing.T) {
+	var out bytes.Buffer
+
+	s := &shell{
+		dsi:    &api_common.TGenericDataSourceInstance{Kind: api_common.EGenericDataSourceKind_POSTGRESQL},
+		format: outputFormatTable,
+		out:    &out,
+	}
+
+	in := strings.NewReader("\\help\n\n\\unknown\n\\output xml\n\\output json;\n\\quit\n\\help\n")
+	require.NoError(t, s.run(in))
+
+	require.Equal(t, outputFormatJSON, s.format)
+	require.Equal(t, 1, strings.Count(out.String(), shellHelp), "commands after quit are not executed")
+	require.Contains(t, out.String(), "ERROR: unknown command \\unknown")
+	require.Contains(t, out.String(), "ERROR: unknown output format: xml")
+	require.True(t, strings.HasPrefix(out.String(), "postgresql> "))
+}
+
+func TestShellExplainRequiresServerConfig(t *testing.T) {
+	var out bytes.Buffer
+
+	s := &shell{
+		dsi:    &api_common.TGenericDataSourceInstance{Kind: api_common.EGenericDataSourceKind_POSTGRESQL},
+		format: outputFormatTable,
+		out:    &out,
+	}
+
+	// the queries rendered with the default config may differ from the ones the connector runs
+	require.NoError(t, s.run(strings.NewReader("\\explain select * from t\n")))
+	require.Contains(t, out.String(), "ERROR: explain requires --server-config flag")
+}
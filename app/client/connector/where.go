@@ -0,0 +1,577 @@
+package connector
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// parseWhere converts the filter written in a small subset of YQL into the typed predicate.
+// Supported syntax:
+//
+//	column (= | == | != | <> | < | <= | > | >=) literal
+//	column IS [NOT] NULL
+//	column [NOT] IN (literal, ...)
+//	NOT predicate, predicate AND predicate, predicate OR predicate, (predicate)
+//
+// Literals are integers, floats, 'strings', TRUE and FALSE. They are typed after the column they are compared with,
+// so the resulting predicate is the same as the one YQ would send for the equivalent query.
+func parseWhere(where string, schema *api_service_protos.TSchema) (*api_service_protos.TPredicate, error) {
+	tokens, err := tokenizeWhere(where)
+	if err != nil {
+		return nil, fmt.Errorf("tokenize: %w", err)
+	}
+
+	return parsePredicate(tokens, schema)
+}
+
+// parsePredicate parses the tokens of the filter, the last token must be tokenEOF
+func parsePredicate(tokens []token, schema *api_service_protos.TSchema) (*api_service_protos.TPredicate, error) {
+	p := &whereParser{tokens: tokens, columns: make(map[string]*Ydb.Type, len(schema.GetColumns()))}
+
+	for _, column := range schema.GetColumns() {
+		p.columns[column.Name] = column.Type
+	}
+
+	predicate, err := p.parseDisjunction()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := p.peek(); token.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", token, token.pos)
+	}
+
+	return predicate, nil
+}
+
+type tokenKind int8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenKeyword
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenPunctuation
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+
+	return fmt.Sprintf("'%s'", t.value)
+}
+
+func (t token) is(kind tokenKind, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+// Keywords must be quoted with backticks to be used as column names
+var whereKeywords = map[string]struct{}{
+	"AND": {}, "OR": {}, "NOT": {}, "IS": {}, "NULL": {}, "IN": {}, "TRUE": {}, "FALSE": {},
+	"SELECT": {}, "FROM": {}, "WHERE": {}, "LIMIT": {},
+}
+
+//nolint:gocyclo
+func tokenizeWhere(input string) ([]token, error) {
+	var (
+		tokens []token
+		runes  = []rune(input)
+	)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',' || r == '*':
+			tokens = append(tokens, token{kind: tokenPunctuation, value: string(r), pos: start})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			i++
+			if i < len(runes) && strings.ContainsRune("=>", runes[i]) {
+				i++
+			}
+
+			op := string(runes[start:i])
+			if op == "!" || op == "=>" {
+				return nil, fmt.Errorf("unknown operator '%s' at position %d", op, start)
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: start})
+		case r == '\'':
+			value, next, err := scanQuoted(runes, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: start})
+			i = next
+		case r == '`':
+			value, next, err := scanQuoted(runes, i, '`')
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenIdentifier, value: value, pos: start})
+			i = next
+		case unicode.IsDigit(r) || r == '-' || r == '.':
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(".eE+-", runes[i])) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+
+			word := string(runes[start:i])
+			if _, ok := whereKeywords[strings.ToUpper(word)]; ok {
+				tokens = append(tokens, token{kind: tokenKeyword, value: strings.ToUpper(word), pos: start})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdentifier, value: word, pos: start})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", r, start)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+// scanQuoted reads the quoted value; the quote is escaped by doubling it
+func scanQuoted(runes []rune, start int, quote rune) (string, int, error) {
+	var sb strings.Builder
+
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] != quote {
+			sb.WriteRune(runes[i])
+			continue
+		}
+
+		if i+1 < len(runes) && runes[i+1] == quote {
+			sb.WriteRune(quote)
+			i++
+
+			continue
+		}
+
+		return sb.String(), i + 1, nil
+	}
+
+	return "", 0, fmt.Errorf("unterminated quote at position %d", start)
+}
+
+type whereParser struct {
+	tokens  []token
+	pos     int
+	columns map[string]*Ydb.Type
+}
+
+func (p *whereParser) peek() token { return p.tokens[p.pos] }
+
+func (p *whereParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *whereParser) expect(kind tokenKind, value string) error {
+	if t := p.next(); !t.is(kind, value) {
+		return fmt.Errorf("expected '%s', got %s at position %d", value, t, t.pos)
+	}
+
+	return nil
+}
+
+func (p *whereParser) parseDisjunction() (*api_service_protos.TPredicate, error) {
+	operands, err := p.parseOperands("OR", p.parseConjunction)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Disjunction{
+			Disjunction: &api_service_protos.TPredicate_TDisjunction{Operands: operands},
+		},
+	}, nil
+}
+
+func (p *whereParser) parseConjunction() (*api_service_protos.TPredicate, error) {
+	operands, err := p.parseOperands("AND", p.parseNegation)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Conjunction{
+			Conjunction: &api_service_protos.TPredicate_TConjunction{Operands: operands},
+		},
+	}, nil
+}
+
+func (p *whereParser) parseOperands(
+	keyword string,
+	parseOperand func() (*api_service_protos.TPredicate, error),
+) ([]*api_service_protos.TPredicate, error) {
+	var operands []*api_service_protos.TPredicate
+
+	for {
+		operand, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, operand)
+
+		if !p.peek().is(tokenKeyword, keyword) {
+			return operands, nil
+		}
+
+		p.next()
+	}
+}
+
+func (p *whereParser) parseNegation() (*api_service_protos.TPredicate, error) {
+	if !p.peek().is(tokenKeyword, "NOT") {
+		return p.parsePrimary()
+	}
+
+	p.next()
+
+	operand, err := p.parseNegation()
+	if err != nil {
+		return nil, err
+	}
+
+	return makeNegation(operand), nil
+}
+
+func (p *whereParser) parsePrimary() (*api_service_protos.TPredicate, error) {
+	if p.peek().is(tokenPunctuation, "(") {
+		p.next()
+
+		predicate, err := p.parseDisjunction()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunctuation, ")"); err != nil {
+			return nil, err
+		}
+
+		return predicate, nil
+	}
+
+	column := p.next()
+	if column.kind != tokenIdentifier {
+		return nil, fmt.Errorf("expected column name, got %s at position %d", column, column.pos)
+	}
+
+	columnType, ok := p.columns[column.value]
+	if !ok {
+		return nil, fmt.Errorf("unknown column '%s'", column.value)
+	}
+
+	columnExpression := &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_Column{Column: column.value},
+	}
+
+	switch t := p.peek(); {
+	case t.is(tokenKeyword, "IS"):
+		return p.parseIsNull(columnExpression)
+	case t.is(tokenKeyword, "IN"), t.is(tokenKeyword, "NOT"):
+		return p.parseIn(columnExpression, columnType)
+	case t.kind == tokenOperator:
+		return p.parseComparison(columnExpression, columnType)
+	default:
+		return nil, fmt.Errorf("expected operator, got %s at position %d", t, t.pos)
+	}
+}
+
+func (p *whereParser) parseIsNull(column *api_service_protos.TExpression) (*api_service_protos.TPredicate, error) {
+	p.next()
+
+	negated := p.peek().is(tokenKeyword, "NOT")
+	if negated {
+		p.next()
+	}
+
+	if err := p.expect(tokenKeyword, "NULL"); err != nil {
+		return nil, err
+	}
+
+	if negated {
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_IsNotNull{
+				IsNotNull: &api_service_protos.TPredicate_TIsNotNull{Value: column},
+			},
+		}, nil
+	}
+
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_IsNull{
+			IsNull: &api_service_protos.TPredicate_TIsNull{Value: column},
+		},
+	}, nil
+}
+
+func (p *whereParser) parseIn(column *api_service_protos.TExpression, columnType *Ydb.Type) (*api_service_protos.TPredicate, error) {
+	negated := p.peek().is(tokenKeyword, "NOT")
+	if negated {
+		p.next()
+	}
+
+	if err := p.expect(tokenKeyword, "IN"); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenPunctuation, "("); err != nil {
+		return nil, err
+	}
+
+	var set []*api_service_protos.TExpression
+
+	for {
+		value, err := p.parseLiteral(columnType)
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, value)
+
+		if !p.peek().is(tokenPunctuation, ",") {
+			break
+		}
+
+		p.next()
+	}
+
+	if err := p.expect(tokenPunctuation, ")"); err != nil {
+		return nil, err
+	}
+
+	predicate := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_In{
+			In: &api_service_protos.TPredicate_TIn{Value: column, Set: set},
+		},
+	}
+
+	if negated {
+		return makeNegation(predicate), nil
+	}
+
+	return predicate, nil
+}
+
+var whereOperations = map[string]api_service_protos.TPredicate_TComparison_EOperation{
+	"=":  api_service_protos.TPredicate_TComparison_EQ,
+	"==": api_service_protos.TPredicate_TComparison_EQ,
+	"!=": api_service_protos.TPredicate_TComparison_NE,
+	"<>": api_service_protos.TPredicate_TComparison_NE,
+	"<":  api_service_protos.TPredicate_TComparison_L,
+	"<=": api_service_protos.TPredicate_TComparison_LE,
+	">":  api_service_protos.TPredicate_TComparison_G,
+	">=": api_service_protos.TPredicate_TComparison_GE,
+}
+
+func (p *whereParser) parseComparison(
+	column *api_service_protos.TExpression,
+	columnType *Ydb.Type,
+) (*api_service_protos.TPredicate, error) {
+	operator := p.next()
+
+	operation, ok := whereOperations[operator.value]
+	if !ok {
+		return nil, fmt.Errorf("unknown operator '%s' at position %d", operator.value, operator.pos)
+	}
+
+	value, err := p.parseLiteral(columnType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{
+				LeftValue:  column,
+				Operation:  operation,
+				RightValue: value,
+			},
+		},
+	}, nil
+}
+
+func (p *whereParser) parseLiteral(columnType *Ydb.Type) (*api_service_protos.TExpression, error) {
+	literal := p.next()
+
+	switch literal.kind {
+	case tokenNumber, tokenString:
+	case tokenKeyword:
+		if literal.value != "TRUE" && literal.value != "FALSE" {
+			return nil, fmt.Errorf("expected literal, got %s at position %d", literal, literal.pos)
+		}
+	default:
+		return nil, fmt.Errorf("expected literal, got %s at position %d", literal, literal.pos)
+	}
+
+	typeID, err := common.YdbTypeToYdbPrimitiveTypeID(columnType)
+	if err != nil {
+		return nil, fmt.Errorf("column type: %w", err)
+	}
+
+	value, err := makeLiteralValue(typeID, literal)
+	if err != nil {
+		return nil, fmt.Errorf("literal %s at position %d: %w", literal, literal.pos, err)
+	}
+
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_TypedValue{
+			TypedValue: &Ydb.TypedValue{Type: common.MakePrimitiveType(typeID), Value: value},
+		},
+	}, nil
+}
+
+// makeLiteralValue converts the literal to the value of the column type,
+// following the representation used by YQ in the pushed down predicates
+//
+//nolint:gocyclo
+func makeLiteralValue(typeID Ydb.Type_PrimitiveTypeId, literal token) (*Ydb.Value, error) {
+	switch typeID {
+	case Ydb.Type_BOOL:
+		if literal.kind != tokenKeyword {
+			return nil, fmt.Errorf("boolean literal expected")
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_BoolValue{BoolValue: literal.value == "TRUE"}}, nil
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32:
+		v, err := parseIntegerLiteral(literal, math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: int32(v)}}, nil
+	case Ydb.Type_INT64:
+		v, err := parseIntegerLiteral(literal, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: v}}, nil
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32:
+		v, err := parseIntegerLiteral(literal, 0, math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: uint32(v)}}, nil
+	case Ydb.Type_UINT64:
+		if literal.kind != tokenNumber {
+			return nil, fmt.Errorf("number literal expected")
+		}
+
+		v, err := strconv.ParseUint(literal.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse unsigned integer: %w", err)
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: v}}, nil
+	case Ydb.Type_FLOAT, Ydb.Type_DOUBLE:
+		if literal.kind != tokenNumber {
+			return nil, fmt.Errorf("number literal expected")
+		}
+
+		v, err := strconv.ParseFloat(literal.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse float: %w", err)
+		}
+
+		if typeID == Ydb.Type_FLOAT {
+			return &Ydb.Value{Value: &Ydb.Value_FloatValue{FloatValue: float32(v)}}, nil
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_DoubleValue{DoubleValue: v}}, nil
+	case Ydb.Type_STRING:
+		if literal.kind != tokenString {
+			return nil, fmt.Errorf("string literal expected")
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_BytesValue{BytesValue: []byte(literal.value)}}, nil
+	case Ydb.Type_UTF8, Ydb.Type_JSON:
+		if literal.kind != tokenString {
+			return nil, fmt.Errorf("string literal expected")
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: literal.value}}, nil
+	case Ydb.Type_TIMESTAMP:
+		if literal.kind != tokenString {
+			return nil, fmt.Errorf("RFC 3339 timestamp literal expected")
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, literal.value)
+		if err != nil {
+			return nil, fmt.Errorf("parse timestamp: %w", err)
+		}
+
+		return &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: t.UnixMicro()}}, nil
+	default:
+		return nil, fmt.Errorf("filtering by columns of type %v: %w", typeID, common.ErrDataTypeNotSupported)
+	}
+}
+
+func parseIntegerLiteral(literal token, minValue, maxValue int64) (int64, error) {
+	if literal.kind != tokenNumber {
+		return 0, fmt.Errorf("number literal expected")
+	}
+
+	v, err := strconv.ParseInt(literal.value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse integer: %w", err)
+	}
+
+	if v < minValue || v > maxValue {
+		return 0, fmt.Errorf("value is out of range")
+	}
+
+	return v, nil
+}
+
+func makeNegation(operand *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Negation{
+			Negation: &api_service_protos.TPredicate_TNegation{Operand: operand},
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/tests/utils"
+)
+
+var whereTestSchema = &api_service_protos.TSchema{
+	Columns: []*Ydb.Column{
+		{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+		{Name: "name", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+		{Name: "ts", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_TIMESTAMP))},
+	},
+}
+
+func TestParseWhere(t *testing.T) {
+	id42 := &api_service_protos.TPredicate{
+		Payload: utils.MakePredicateComparisonColumn(
+			"id", api_service_protos.TPredicate_TComparison_GE, common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(42)),
+		),
+	}
+
+	nameIsNull := &api_service_protos.TPredicate{Payload: utils.MakePredicateIsNullColumn("name")}
+	nameIsNotNull := &api_service_protos.TPredicate{Payload: utils.MakePredicateIsNotNullColumn("name")}
+
+	nameEq := &api_service_protos.TPredicate{
+		Payload: utils.MakePredicateComparisonColumn(
+			"name", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_UTF8), "it's"),
+		),
+	}
+
+	testCases := []struct {
+		where    string
+		expected *api_service_protos.TPredicate
+	}{
+		{where: "id >= 42", expected: id42},
+		{where: "name is null", expected: nameIsNull},
+		{where: "`name` = 'it''s'", expected: nameEq},
+		{
+			where: "id >= 42 AND name IS NOT NULL OR NOT (name IS NULL)",
+			expected: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Disjunction{
+					Disjunction: &api_service_protos.TPredicate_TDisjunction{
+						Operands: []*api_service_protos.TPredicate{
+							{
+								Payload: &api_service_protos.TPredicate_Conjunction{
+									Conjunction: &api_service_protos.TPredicate_TConjunction{
+										Operands: []*api_service_protos.TPredicate{id42, nameIsNotNull},
+									},
+								},
+							},
+							makeNegation(nameIsNull),
+						},
+					},
+				},
+			},
+		},
+		{
+			where: "ts < '2024-01-02T03:04:05Z'",
+			expected: &api_service_protos.TPredicate{
+				Payload: utils.MakePredicateComparisonColumn(
+					"ts",
+					api_service_protos.TPredicate_TComparison_L,
+					&Ydb.TypedValue{
+						Type:  common.MakePrimitiveType(Ydb.Type_TIMESTAMP),
+						Value: &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: 1704164645000000}},
+					},
+				),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		actual, err := parseWhere(tc.where, whereTestSchema)
+		require.NoError(t, err, tc.where)
+		require.True(t, proto.Equal(tc.expected, actual), "%s: %v", tc.where, actual)
+	}
+
+	actual, err := parseWhere("id NOT IN (1, 2)", whereTestSchema)
+	require.NoError(t, err)
+	require.Len(t, actual.GetNegation().GetOperand().GetIn().GetSet(), 2)
+
+	for _, where := range []string{
+		"unknown = 1",
+		"id = 'text'",
+		"id = 3000000000",
+		"name = 'a' AND",
+		"(id = 1",
+		"id = 1 id = 2",
+		"name = 'unterminated",
+	} {
+		_, err := parseWhere(where, whereTestSchema)
+		require.Error(t, err, where)
+	}
+}
@@ -6,4 +6,12 @@ const (
 	DateTimeFormatFlag = "date-time-format"
 	UserIDFlag         = "user-id"
 	SessionIDFlag      = "session"
+	OutputFlag         = "output"
+	PatternFlag        = "pattern"
+	OutputFormatFlag   = "output-format"
+	OutputFileFlag     = "output-file"
+	WhereFlag          = "where"
+	ColumnsFlag        = "columns"
+	ServerConfigFlag   = "server-config"
+	CompressionFlag    = "compression"
 )
@@ -28,9 +28,14 @@ func MakePreset(cmd *cobra.Command) (*Preset, error) {
 		return nil, fmt.Errorf("get config flag: %v", err)
 	}
 
-	tableName, err := cmd.Flags().GetString(TableFlag)
-	if err != nil {
-		return nil, fmt.Errorf("get table flag: %v", err)
+	// some commands are not related to the particular table
+	var tableName string
+
+	if cmd.Flags().Lookup(TableFlag) != nil {
+		tableName, err = cmd.Flags().GetString(TableFlag)
+		if err != nil {
+			return nil, fmt.Errorf("get table flag: %v", err)
+		}
 	}
 
 	var cfg config.TClientConfig
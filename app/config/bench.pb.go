@@ -212,10 +212,13 @@ func (x *TBenchmarkTestCase) GetColumns() []string {
 // TBenchmarkServerParams contains server config params that will be applied
 // to embedded Connector server at the time of start.
 type TBenchmarkServerParams struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Paging        *TPagingConfig         `protobuf:"bytes,1,opt,name=paging,proto3" json:"paging,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Paging *TPagingConfig         `protobuf:"bytes,1,opt,name=paging,proto3" json:"paging,omitempty"`
+	// Read ClickHouse tables in ArrowStream format (HTTP protocol only),
+	// so that the throughput could be compared with the row-by-row reading.
+	ClickhouseUseArrowStream bool `protobuf:"varint,2,opt,name=clickhouse_use_arrow_stream,json=clickhouseUseArrowStream,proto3" json:"clickhouse_use_arrow_stream,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
 }
 
 func (x *TBenchmarkServerParams) Reset() {
@@ -255,6 +258,13 @@ func (x *TBenchmarkServerParams) GetPaging() *TPagingConfig {
 	return nil
 }
 
+func (x *TBenchmarkServerParams) GetClickhouseUseArrowStream() bool {
+	if x != nil {
+		return x.ClickhouseUseArrowStream
+	}
+	return false
+}
+
 // TBenchmarkLoadParams contains settings for network client used
 // within benchmarking tool.
 type TBenchmarkClientParams struct {
@@ -363,13 +373,17 @@ var file_app_config_bench_proto_rawDesc = string([]byte{
 	0x68, 0x6d, 0x61, 0x72, 0x6b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d,
 	0x73, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
 	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x22, 0x5a, 0x0a, 0x16, 0x54, 0x42, 0x65,
-	0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x12, 0x40, 0x0a, 0x06, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x4e, 0x59, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x54, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x70,
-	0x61, 0x67, 0x69, 0x6e, 0x67, 0x22, 0x62, 0x0a, 0x16, 0x54, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d,
+	0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x16, 0x54, 0x42,
+	0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x40, 0x0a, 0x06, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x4e, 0x59, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x54, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06,
+	0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x3d, 0x0a, 0x1b, 0x63, 0x6c, 0x69, 0x63, 0x6b, 0x68,
+	0x6f, 0x75, 0x73, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x61, 0x72, 0x72, 0x6f, 0x77, 0x5f, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x63, 0x6c, 0x69,
+	0x63, 0x6b, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x55, 0x73, 0x65, 0x41, 0x72, 0x72, 0x6f, 0x77, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x22, 0x62, 0x0a, 0x16, 0x54, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d,
 	0x61, 0x72, 0x6b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
 	0x2c, 0x0a, 0x12, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73,
 	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10, 0x71, 0x75, 0x65,
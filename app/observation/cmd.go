@@ -0,0 +1,177 @@
+package observation
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	app_config "github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/observation"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "observation",
+	Short: "Query observation system toolkit",
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export query records created within the time window",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportQueries(cmd, args); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+const (
+	configFlag = "config"
+	tableFlag  = "table"
+	formatFlag = "format"
+	fromFlag   = "from"
+	toFlag     = "to"
+	outputFlag = "output"
+)
+
+func init() {
+	Cmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP(configFlag, "c", "", "Path to server config file")
+	exportCmd.Flags().StringP(tableFlag, "t", string(observation.ExportTableIncoming), "Queries to export: incoming, outgoing")
+	exportCmd.Flags().StringP(formatFlag, "f", string(observation.ExportFormatJSONLines), "Output format: jsonl, parquet")
+	exportCmd.Flags().String(fromFlag, "", "Start of the time window (RFC3339), defaults to 24 hours before the end")
+	exportCmd.Flags().String(toFlag, "", "End of the time window (RFC3339), defaults to now")
+	exportCmd.Flags().StringP(outputFlag, "o", "-", "Output file path, '-' stands for stdout")
+
+	if err := exportCmd.MarkFlagRequired(configFlag); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func parseTimeWindow(cmd *cobra.Command) (time.Time, time.Time, error) {
+	fromStr, err := cmd.Flags().GetString(fromFlag)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("get from flag: %v", err)
+	}
+
+	toStr, err := cmd.Flags().GetString(toFlag)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("get to flag: %v", err)
+	}
+
+	to := time.Now()
+
+	if toStr != "" {
+		if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse to flag: %v", err)
+		}
+	}
+
+	from := to.Add(-24 * time.Hour)
+
+	if fromStr != "" {
+		if from, err = time.Parse(time.RFC3339, fromStr); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parse from flag: %v", err)
+		}
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("empty time window: [%v, %v)", from, to)
+	}
+
+	return from, to, nil
+}
+
+func makeExportParams(cmd *cobra.Command) (*observation.ExportParams, error) {
+	table, err := cmd.Flags().GetString(tableFlag)
+	if err != nil {
+		return nil, fmt.Errorf("get table flag: %v", err)
+	}
+
+	format, err := cmd.Flags().GetString(formatFlag)
+	if err != nil {
+		return nil, fmt.Errorf("get format flag: %v", err)
+	}
+
+	from, to, err := parseTimeWindow(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("parse time window: %w", err)
+	}
+
+	return &observation.ExportParams{
+		Table:  observation.ExportTable(table),
+		Format: observation.ExportFormat(format),
+		From:   from,
+		To:     to,
+	}, nil
+}
+
+func exportQueries(cmd *cobra.Command, _ []string) error {
+	configPath, err := cmd.Flags().GetString(configFlag)
+	if err != nil {
+		return fmt.Errorf("get config flag: %v", err)
+	}
+
+	outputPath, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return fmt.Errorf("get output flag: %v", err)
+	}
+
+	params, err := makeExportParams(cmd)
+	if err != nil {
+		return fmt.Errorf("make export params: %w", err)
+	}
+
+	cfg, err := config.NewConfigFromFile(configPath)
+	if err != nil {
+		return fmt.Errorf("new config: %w", err)
+	}
+
+	if cfg.Observation == nil {
+		return fmt.Errorf("query observation system is not configured")
+	}
+
+	// export must not modify the storage, so the retention policy is not applied
+	observationCfg := proto.Clone(cfg.Observation).(*app_config.TObservationConfig)
+	observationCfg.Storage.Retention = nil
+
+	logger := common.NewDefaultLogger()
+
+	storage, err := observation.NewStorage(logger, observationCfg)
+	if err != nil {
+		return fmt.Errorf("new storage: %w", err)
+	}
+
+	defer common.LogCloserError(logger, storage, "close storage")
+
+	var output io.Writer = os.Stdout
+
+	if outputPath != "-" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+
+		defer common.LogCloserError(logger, file, "close output file")
+
+		output = file
+	}
+
+	total, err := observation.Export(storage, params, output)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	logger.Info("queries exported", zap.Int("total", total), zap.String("table", string(params.Table)))
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package admission
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+)
+
+// Request describes the read request that needs to be admitted
+type Request struct {
+	Kind     api_common.EGenericDataSourceKind
+	Endpoint *api_common.TGenericEndpoint
+	UserID   string
+}
+
+// Ticket is issued for every admitted request.
+// It must be released when the request is finished.
+type Ticket interface {
+	// Throttler restricts the reading speed according to the user rate limits
+	paging.Throttler
+	// Release returns the request slot back to the controller
+	Release()
+}
+
+// Controller decides whether the read request can be executed right now,
+// should wait for some time or must be rejected.
+type Controller interface {
+	// Admit blocks until the request fits into concurrency limits or the queue timeout expires.
+	// Rejected requests get the error wrapping common.ErrTooManyRequests.
+	Admit(ctx context.Context, logger *zap.Logger, request *Request) (Ticket, error)
+}
+
+var _ Controller = controllerNoop{}
+
+type controllerNoop struct{}
+
+func (controllerNoop) Admit(context.Context, *zap.Logger, *Request) (Ticket, error) {
+	return ticketNoop{}, nil
+}
+
+var _ Ticket = ticketNoop{}
+
+type ticketNoop struct {
+	paging.ThrottlerNoop
+}
+
+func (ticketNoop) Release() {}
@@ -0,0 +1,305 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// Names of the limits, also used as metric tags
+const (
+	limitDataSourceKind = "data_source_kind"
+	limitEndpoint       = "endpoint"
+	limitUser           = "user"
+)
+
+type controllerMetrics struct {
+	activeRequests   metrics.IntGauge
+	queuedRequests   metrics.IntGauge
+	admittedRequests metrics.Counter
+	rejectedRequests metrics.CounterVec
+	throttlingTime   metrics.Counter
+}
+
+func newControllerMetrics(registry metrics.Registry) *controllerMetrics {
+	return &controllerMetrics{
+		activeRequests:   registry.IntGauge("active_requests"),
+		queuedRequests:   registry.IntGauge("queued_requests"),
+		admittedRequests: registry.Counter("admitted_requests"),
+		rejectedRequests: registry.CounterVec("rejected_requests", []string{"limit"}),
+		throttlingTime:   registry.Counter("throttling_time_ms"),
+	}
+}
+
+// idleUserTTL is the time the state of the user without active requests is kept for,
+// so that the rate limits apply to the sequential requests as well
+const idleUserTTL = time.Minute
+
+// userState keeps the resources shared by all requests of the same user
+type userState struct {
+	activeRequests uint32
+	rowsLimiter    *rate.Limiter
+	bytesLimiter   *rate.Limiter
+	// the time the last active request was released at
+	idleSince time.Time
+}
+
+var _ Controller = (*controllerImpl)(nil)
+
+type controllerImpl struct {
+	cfg          *config.TAdmissionControlConfig
+	queueTimeout time.Duration
+	metrics      *controllerMetrics
+
+	mutex     sync.Mutex
+	kinds     map[api_common.EGenericDataSourceKind]uint32
+	endpoints map[string]uint32
+	users     map[string]*userState
+	// closed and replaced every time some request releases its slot
+	released chan struct{}
+	// the time the idle users were expired last time
+	lastExpiration time.Time
+	now            func() time.Time
+}
+
+func (c *controllerImpl) Admit(ctx context.Context, logger *zap.Logger, request *Request) (Ticket, error) {
+	endpoint := common.EndpointToString(request.Endpoint)
+
+	c.mutex.Lock()
+	ticket, limit := c.tryAdmit(request.Kind, endpoint, request.UserID)
+	released := c.released
+	c.mutex.Unlock()
+
+	if ticket != nil {
+		return ticket, nil
+	}
+
+	if c.queueTimeout == 0 {
+		return nil, c.reject(logger, limit)
+	}
+
+	logger.Debug("request is queued", zap.String("limit", limit))
+
+	c.metrics.queuedRequests.Add(1)
+	defer c.metrics.queuedRequests.Add(-1)
+
+	timer := time.NewTimer(c.queueTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-released:
+		case <-timer.C:
+			return nil, c.reject(logger, limit)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for admission: %w", ctx.Err())
+		}
+
+		c.mutex.Lock()
+		ticket, limit = c.tryAdmit(request.Kind, endpoint, request.UserID)
+		released = c.released
+		c.mutex.Unlock()
+
+		if ticket != nil {
+			return ticket, nil
+		}
+	}
+}
+
+func (c *controllerImpl) reject(logger *zap.Logger, limit string) error {
+	c.metrics.rejectedRequests.With(map[string]string{"limit": limit}).Inc()
+
+	logger.Warn("request is rejected", zap.String("limit", limit))
+
+	return fmt.Errorf("concurrency limit per %s is exceeded: %w", limit, common.ErrTooManyRequests)
+}
+
+// tryAdmit returns a ticket if the request fits into all the limits,
+// otherwise it returns the name of the exceeded limit. Must be called under the mutex.
+func (c *controllerImpl) tryAdmit(kind api_common.EGenericDataSourceKind, endpoint, userID string) (*ticketImpl, string) {
+	limits := c.cfg.GetConcurrencyLimits()
+
+	if limit := limits.GetPerDataSourceKind(); limit != 0 && c.kinds[kind] >= limit {
+		return nil, limitDataSourceKind
+	}
+
+	if limit := limits.GetPerEndpoint(); limit != 0 && c.endpoints[endpoint] >= limit {
+		return nil, limitEndpoint
+	}
+
+	user, exists := c.users[userID]
+	if !exists {
+		user = c.newUserState()
+	}
+
+	if limit := limits.GetPerUser(); limit != 0 && user.activeRequests >= limit {
+		return nil, limitUser
+	}
+
+	c.kinds[kind]++
+	c.endpoints[endpoint]++
+	user.activeRequests++
+	c.users[userID] = user
+
+	c.metrics.activeRequests.Add(1)
+	c.metrics.admittedRequests.Inc()
+
+	return &ticketImpl{
+		controller: c,
+		kind:       kind,
+		endpoint:   endpoint,
+		userID:     userID,
+		user:       user,
+	}, ""
+}
+
+func (c *controllerImpl) newUserState() *userState {
+	var (
+		limits = c.cfg.GetRateLimitsPerUser()
+		user   = &userState{}
+	)
+
+	// burst equals to one second of reading, so that the user is able to read a page at once
+	if limit := limits.GetRowsPerSecond(); limit != 0 {
+		user.rowsLimiter = rate.NewLimiter(rate.Limit(limit), int(limit))
+	}
+
+	if limit := limits.GetBytesPerSecond(); limit != 0 {
+		user.bytesLimiter = rate.NewLimiter(rate.Limit(limit), int(limit))
+	}
+
+	return user
+}
+
+func (c *controllerImpl) release(t *ticketImpl) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.kinds[t.kind]--; c.kinds[t.kind] == 0 {
+		delete(c.kinds, t.kind)
+	}
+
+	if c.endpoints[t.endpoint]--; c.endpoints[t.endpoint] == 0 {
+		delete(c.endpoints, t.endpoint)
+	}
+
+	if t.user.activeRequests--; t.user.activeRequests == 0 {
+		// without the rate limiters there's nothing to keep
+		if t.user.rowsLimiter == nil && t.user.bytesLimiter == nil {
+			delete(c.users, t.userID)
+		} else {
+			t.user.idleSince = c.now()
+		}
+	}
+
+	c.expireIdleUsers()
+
+	c.metrics.activeRequests.Add(-1)
+
+	// wake up the queued requests
+	close(c.released)
+	c.released = make(chan struct{})
+}
+
+// expireIdleUsers drops the states of the users that have been idle for too long.
+// The users are checked at most once per TTL. Must be called under the mutex.
+func (c *controllerImpl) expireIdleUsers() {
+	now := c.now()
+	if now.Sub(c.lastExpiration) < idleUserTTL {
+		return
+	}
+
+	for userID, user := range c.users {
+		if user.activeRequests == 0 && now.Sub(user.idleSince) >= idleUserTTL {
+			delete(c.users, userID)
+		}
+	}
+
+	c.lastExpiration = now
+}
+
+var _ Ticket = (*ticketImpl)(nil)
+
+type ticketImpl struct {
+	controller  *controllerImpl
+	kind        api_common.EGenericDataSourceKind
+	endpoint    string
+	userID      string
+	user        *userState
+	releaseOnce sync.Once
+}
+
+func (t *ticketImpl) Throttle(ctx context.Context, rows, bytes uint64) error {
+	startTime := time.Now()
+
+	defer func() {
+		t.controller.metrics.throttlingTime.Add(time.Since(startTime).Milliseconds())
+	}()
+
+	if err := waitN(ctx, t.user.rowsLimiter, rows); err != nil {
+		return fmt.Errorf("wait for rows rate limiter: %w", err)
+	}
+
+	if err := waitN(ctx, t.user.bytesLimiter, bytes); err != nil {
+		return fmt.Errorf("wait for bytes rate limiter: %w", err)
+	}
+
+	return nil
+}
+
+// waitN takes the tokens from the limiter in several steps,
+// because a single page may exceed the limiter burst size.
+func waitN(ctx context.Context, limiter *rate.Limiter, n uint64) error {
+	if limiter == nil {
+		return nil
+	}
+
+	burst := uint64(limiter.Burst())
+
+	for n > 0 {
+		step := min(n, burst)
+
+		if err := limiter.WaitN(ctx, int(step)); err != nil {
+			return err
+		}
+
+		n -= step
+	}
+
+	return nil
+}
+
+func (t *ticketImpl) Release() {
+	t.releaseOnce.Do(func() { t.controller.release(t) })
+}
+
+func NewController(cfg *config.TAdmissionControlConfig, registry metrics.Registry) (Controller, error) {
+	if cfg == nil {
+		return controllerNoop{}, nil
+	}
+
+	queueTimeout, err := common.DurationFromString(cfg.QueueTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("parse queue timeout: %w", err)
+	}
+
+	return &controllerImpl{
+		cfg:          cfg,
+		queueTimeout: queueTimeout,
+		metrics:      newControllerMetrics(registry.WithPrefix("admission")),
+		kinds:        make(map[api_common.EGenericDataSourceKind]uint32),
+		endpoints:    make(map[string]uint32),
+		users:        make(map[string]*userState),
+		released:     make(chan struct{}),
+		now:          time.Now,
+	}, nil
+}
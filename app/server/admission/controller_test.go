@@ -0,0 +1,228 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	app_utils "github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+func TestControllerConcurrencyLimits(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	const (
+		pg = api_common.EGenericDataSourceKind_POSTGRESQL
+		ch = api_common.EGenericDataSourceKind_CLICKHOUSE
+	)
+
+	var (
+		host1 = &api_common.TGenericEndpoint{Host: "host1", Port: 5432}
+		host2 = &api_common.TGenericEndpoint{Host: "host2", Port: 5432}
+	)
+
+	testCases := []struct {
+		name     string
+		limits   *config.TAdmissionControlConfig_TConcurrencyLimits
+		first    *Request
+		rejected *Request
+		admitted *Request
+	}{
+		{
+			name:     "per data source kind",
+			limits:   &config.TAdmissionControlConfig_TConcurrencyLimits{PerDataSourceKind: 1},
+			first:    &Request{Kind: pg, Endpoint: host1, UserID: "user1"},
+			rejected: &Request{Kind: pg, Endpoint: host2, UserID: "user2"},
+			admitted: &Request{Kind: ch, Endpoint: host1, UserID: "user1"},
+		},
+		{
+			name:     "per endpoint",
+			limits:   &config.TAdmissionControlConfig_TConcurrencyLimits{PerEndpoint: 1},
+			first:    &Request{Kind: pg, Endpoint: host1, UserID: "user1"},
+			rejected: &Request{Kind: ch, Endpoint: host1, UserID: "user2"},
+			admitted: &Request{Kind: pg, Endpoint: host2, UserID: "user1"},
+		},
+		{
+			name:     "per user",
+			limits:   &config.TAdmissionControlConfig_TConcurrencyLimits{PerUser: 1},
+			first:    &Request{Kind: pg, Endpoint: host1, UserID: "user1"},
+			rejected: &Request{Kind: ch, Endpoint: host2, UserID: "user1"},
+			admitted: &Request{Kind: pg, Endpoint: host1, UserID: "user2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			controller, err := NewController(&config.TAdmissionControlConfig{ConcurrencyLimits: tc.limits, QueueTimeout: "0s"}, nop.Registry{})
+			require.NoError(t, err)
+
+			first, err := controller.Admit(ctx, logger, tc.first)
+			require.NoError(t, err)
+
+			_, err = controller.Admit(ctx, logger, tc.rejected)
+			require.ErrorIs(t, err, common.ErrTooManyRequests)
+
+			admitted, err := controller.Admit(ctx, logger, tc.admitted)
+			require.NoError(t, err)
+			admitted.Release()
+
+			// the slot is free after release, double release changes nothing
+			first.Release()
+			first.Release()
+
+			ticket, err := controller.Admit(ctx, logger, tc.rejected)
+			require.NoError(t, err)
+			ticket.Release()
+
+			require.Empty(t, controller.(*controllerImpl).kinds)
+			require.Empty(t, controller.(*controllerImpl).endpoints)
+			require.Empty(t, controller.(*controllerImpl).users)
+		})
+	}
+}
+
+func TestControllerQueue(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	request := &Request{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: &api_common.TGenericEndpoint{Host: "host", Port: 5432},
+		UserID:   "user",
+	}
+
+	controller, err := NewController(&config.TAdmissionControlConfig{
+		ConcurrencyLimits: &config.TAdmissionControlConfig_TConcurrencyLimits{PerUser: 1},
+		QueueTimeout:      "50ms",
+	}, nop.Registry{})
+	require.NoError(t, err)
+
+	first, err := controller.Admit(ctx, logger, request)
+	require.NoError(t, err)
+
+	// queue timeout expires
+	_, err = controller.Admit(ctx, logger, request)
+	require.ErrorIs(t, err, common.ErrTooManyRequests)
+
+	// request context expires
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = controller.Admit(cancelledCtx, logger, request)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the queued request gets the slot when it's released
+	admitted := make(chan error)
+
+	go func() {
+		ticket, err := controller.Admit(ctx, logger, request)
+		if err == nil {
+			ticket.Release()
+		}
+
+		admitted <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	first.Release()
+
+	require.NoError(t, <-admitted)
+}
+
+func TestControllerRateLimits(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	controller, err := NewController(&config.TAdmissionControlConfig{
+		RateLimitsPerUser: &config.TAdmissionControlConfig_TRateLimits{RowsPerSecond: 1000},
+		QueueTimeout:      "0s",
+	}, nop.Registry{})
+	require.NoError(t, err)
+
+	ticket, err := controller.Admit(ctx, logger, &Request{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: &api_common.TGenericEndpoint{Host: "host", Port: 5432},
+		UserID:   "user",
+	})
+	require.NoError(t, err)
+
+	defer ticket.Release()
+
+	// the first second of reading is available immediately, the rest is throttled
+	startTime := time.Now()
+	require.NoError(t, ticket.Throttle(ctx, 1500, 1<<30))
+	require.GreaterOrEqual(t, time.Since(startTime), 400*time.Millisecond)
+
+	// throttling is interrupted by the request context
+	shortCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	require.Error(t, ticket.Throttle(shortCtx, 1000, 0))
+}
+
+func TestControllerRateLimitsSequentialRequests(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	request := &Request{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: &api_common.TGenericEndpoint{Host: "host", Port: 5432},
+		UserID:   "user",
+	}
+
+	c, err := NewController(&config.TAdmissionControlConfig{
+		RateLimitsPerUser: &config.TAdmissionControlConfig_TRateLimits{RowsPerSecond: 1000},
+		QueueTimeout:      "0s",
+	}, nop.Registry{})
+	require.NoError(t, err)
+
+	controller := c.(*controllerImpl)
+	clock := app_utils.NewManualClock()
+	controller.now = clock.Now
+
+	ticket, err := controller.Admit(ctx, logger, request)
+	require.NoError(t, err)
+	require.NoError(t, ticket.Throttle(ctx, 1000, 0))
+	ticket.Release()
+
+	// the next request of the same user doesn't get the burst again
+	ticket, err = controller.Admit(ctx, logger, request)
+	require.NoError(t, err)
+
+	shortCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	require.Error(t, ticket.Throttle(shortCtx, 1000, 0))
+	ticket.Release()
+	require.Len(t, controller.users, 1)
+
+	// the idle users are forgotten after a while
+	clock.Advance(idleUserTTL)
+
+	ticket, err = controller.Admit(ctx, logger, &Request{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: &api_common.TGenericEndpoint{Host: "host", Port: 5432},
+		UserID:   "other",
+	})
+	require.NoError(t, err)
+	ticket.Release()
+	require.Len(t, controller.users, 1)
+	require.Contains(t, controller.users, "other")
+}
+
+func TestControllerNoop(t *testing.T) {
+	controller, err := NewController(nil, nop.Registry{})
+	require.NoError(t, err)
+
+	ticket, err := controller.Admit(context.Background(), zap.NewNop(), &Request{})
+	require.NoError(t, err)
+	require.NoError(t, ticket.Throttle(context.Background(), 1<<30, 1<<30))
+	ticket.Release()
+}
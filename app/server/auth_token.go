@@ -0,0 +1,300 @@
+package server
+
+import (
+	"bufio"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+// tokenVerifier checks the bearer token passed by a client
+type tokenVerifier interface {
+	verify(token string) error
+}
+
+var errInvalidToken = errors.New("invalid token")
+
+var _ tokenVerifier = (*staticTokenVerifier)(nil)
+
+// staticTokenVerifier accepts tokens from the predefined list.
+// Only token hashes are kept in memory.
+type staticTokenVerifier struct {
+	hashes map[[sha256.Size]byte]struct{}
+}
+
+func (v *staticTokenVerifier) verify(token string) error {
+	if _, exists := v.hashes[sha256.Sum256([]byte(token))]; !exists {
+		return errInvalidToken
+	}
+
+	return nil
+}
+
+func newStaticTokenVerifier(cfg *config.TClientAuthConfig_TTokenAuth_TStaticTokens) (tokenVerifier, error) {
+	tokens := append([]string(nil), cfg.Tokens...)
+
+	if cfg.TokensFile != "" {
+		fileTokens, err := readTokensFile(cfg.TokensFile)
+		if err != nil {
+			return nil, fmt.Errorf("read tokens file: %w", err)
+		}
+
+		tokens = append(tokens, fileTokens...)
+	}
+
+	v := &staticTokenVerifier{hashes: make(map[[sha256.Size]byte]struct{}, len(tokens))}
+
+	for _, token := range tokens {
+		if token == "" {
+			return nil, errors.New("empty token")
+		}
+
+		v.hashes[sha256.Sum256([]byte(token))] = struct{}{}
+	}
+
+	if len(v.hashes) == 0 {
+		return nil, errors.New("no tokens provided")
+	}
+
+	return v, nil
+}
+
+func readTokensFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+
+	defer f.Close()
+
+	var tokens []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			tokens = append(tokens, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan file: %w", err)
+	}
+
+	return tokens, nil
+}
+
+var _ tokenVerifier = (*jwtVerifier)(nil)
+
+// jwtVerifier accepts JSON Web Tokens signed with the keys from the local JWKS file
+type jwtVerifier struct {
+	keys     map[string]crypto.PublicKey
+	parser   *jwt.Parser
+	issuer   string
+	audience string
+}
+
+func (v *jwtVerifier) verify(token string) error {
+	var claims jwt.RegisteredClaims
+
+	// expiration time and other time-based claims are checked by parser
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.getKey); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidToken, err)
+	}
+
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return fmt.Errorf("%w: unexpected issuer", errInvalidToken)
+	}
+
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return fmt.Errorf("%w: unexpected audience", errInvalidToken)
+	}
+
+	return nil
+}
+
+func (v *jwtVerifier) getKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, exists := v.keys[kid]
+	if !exists {
+		// tokens without key id are allowed if there is the only key in the set
+		if kid == "" && len(v.keys) == 1 {
+			for _, key := range v.keys {
+				return key, nil
+			}
+		}
+
+		return nil, fmt.Errorf("unknown key id '%s'", kid)
+	}
+
+	return key, nil
+}
+
+func newJWTVerifier(cfg *config.TClientAuthConfig_TTokenAuth_TJWT) (tokenVerifier, error) {
+	data, err := os.ReadFile(cfg.JwksFile)
+	if err != nil {
+		return nil, fmt.Errorf("read JWKS file: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse JWKS: %w", err)
+	}
+
+	// symmetric algorithms are not allowed, because the JWKS contains only public keys
+	validMethods := []string{
+		"RS256", "RS384", "RS512",
+		"PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512",
+		"EdDSA",
+	}
+
+	return &jwtVerifier{
+		keys:     keys,
+		parser:   jwt.NewParser(jwt.WithValidMethods(validMethods)),
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+	}, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC and OKP
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+
+	for i, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %w", i, err)
+		}
+
+		if _, exists := keys[jwk.Kid]; exists {
+			return nil, fmt.Errorf("key #%d: duplicated key id '%s'", i, jwk.Kid)
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys found")
+	}
+
+	return keys, nil
+}
+
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode modulus: %w", err)
+		}
+
+		e, err := decodeBase64URLInt(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode exponent: %w", err)
+		}
+
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent is too large")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%s'", jwk.Crv)
+		}
+
+		x, err := decodeBase64URLInt(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+
+		y, err := decodeBase64URLInt(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode y: %w", err)
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve '%s'", jwk.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode x: %w", err)
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid key size: %d", len(x))
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", jwk.Kty)
+	}
+}
+
+func decodeBase64URLInt(src string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// Key identifies the cached result of the metadata request.
+// The request parameters are hashed, so that the credentials are not kept in memory as is.
+type Key struct {
+	digest [sha256.Size]byte
+	// the fields below make it possible to invalidate the entries selectively
+	kind     api_common.EGenericDataSourceKind
+	database string
+}
+
+// MakeKey builds the key from the method name, the data source instance and the other request parameters.
+// Every field of the messages including credentials affects the key.
+func MakeKey(method string, dsi *api_common.TGenericDataSourceInstance, params ...proto.Message) (Key, error) {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+
+	marshaler := proto.MarshalOptions{Deterministic: true}
+
+	var length [8]byte
+
+	for _, msg := range append([]proto.Message{dsi}, params...) {
+		data, err := marshaler.Marshal(msg)
+		if err != nil {
+			return Key{}, fmt.Errorf("marshal %T: %w", msg, err)
+		}
+
+		// length prefix prevents the collisions of the adjacent messages
+		binary.LittleEndian.PutUint64(length[:], uint64(len(data)))
+		hash.Write(length[:])
+		hash.Write(data)
+	}
+
+	key := Key{kind: dsi.GetKind(), database: dsi.GetDatabase()}
+	copy(key.digest[:], hash.Sum(nil))
+
+	return key, nil
+}
+
+// Cache keeps the results of the expensive requests for a while
+type Cache[V any] interface {
+	// Get returns the cached value, it must not be modified by the caller
+	Get(key Key) (V, bool)
+	Put(key Key, value V)
+	// Invalidate drops the entries of the data source instances matching the arguments,
+	// empty arguments match any value. Returns the number of the dropped entries.
+	Invalidate(kind api_common.EGenericDataSourceKind, database string) int
+}
+
+var _ Cache[any] = cacheNoop[any]{}
+
+type cacheNoop[V any] struct{}
+
+func (cacheNoop[V]) Get(Key) (V, bool) {
+	var value V
+
+	return value, false
+}
+
+func (cacheNoop[V]) Put(Key, V) {}
+
+func (cacheNoop[V]) Invalidate(api_common.EGenericDataSourceKind, string) int { return 0 }
+
+// MetadataCache keeps the table schemas and splits
+type MetadataCache struct {
+	DescribeTable Cache[*api_service_protos.TDescribeTableResponse]
+	// Every select of ListSplits request is cached separately
+	ListSplits Cache[[]*api_service_protos.TListSplitsResponse]
+}
+
+// Invalidate drops the entries of the data source instances matching the arguments from all the caches
+func (c *MetadataCache) Invalidate(kind api_common.EGenericDataSourceKind, database string) int {
+	return c.DescribeTable.Invalidate(kind, database) + c.ListSplits.Invalidate(kind, database)
+}
+
+// NewMetadataCache makes the cache of metadata requests; if config is empty, nothing is cached
+func NewMetadataCache(cfg *config.TMetadataCacheConfig, registry metrics.Registry) (*MetadataCache, error) {
+	if cfg == nil {
+		return &MetadataCache{
+			DescribeTable: cacheNoop[*api_service_protos.TDescribeTableResponse]{},
+			ListSplits:    cacheNoop[[]*api_service_protos.TListSplitsResponse]{},
+		}, nil
+	}
+
+	describeTable, err := newCacheLRU[*api_service_protos.TDescribeTableResponse](
+		cfg, registry.WithTags(map[string]string{"method": "DescribeTable"}), time.Now)
+	if err != nil {
+		return nil, fmt.Errorf("new DescribeTable cache: %w", err)
+	}
+
+	result := &MetadataCache{
+		DescribeTable: describeTable,
+		ListSplits:    cacheNoop[[]*api_service_protos.TListSplitsResponse]{},
+	}
+
+	if !cfg.DisableListSplits {
+		result.ListSplits, err = newCacheLRU[[]*api_service_protos.TListSplitsResponse](
+			cfg, registry.WithTags(map[string]string{"method": "ListSplits"}), time.Now)
+		if err != nil {
+			return nil, fmt.Errorf("new ListSplits cache: %w", err)
+		}
+	}
+
+	return result, nil
+}
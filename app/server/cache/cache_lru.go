@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils/lru"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+var _ Cache[any] = (*cacheLRU[any])(nil)
+
+// cacheLRU limits the number of entries, each of them is accounted in the index with the unit size.
+type cacheLRU[V any] struct {
+	ttl time.Duration
+
+	mutex sync.Mutex
+	index *lru.Index[Key, V]
+}
+
+func (c *cacheLRU[V]) Get(key Key) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.index.Get(key)
+}
+
+func (c *cacheLRU[V]) Put(key Key, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.index.Put(key, value, 1, c.ttl)
+}
+
+func (c *cacheLRU[V]) Invalidate(kind api_common.EGenericDataSourceKind, database string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.index.RemoveIf(func(key Key, _ V) bool {
+		return (kind == api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED || kind == key.kind) &&
+			(database == "" || database == key.database)
+	})
+}
+
+func newCacheLRU[V any](cfg *config.TMetadataCacheConfig, registry metrics.Registry, now func() time.Time) (*cacheLRU[V], error) {
+	ttl, err := common.DurationFromString(cfg.Ttl)
+	if err != nil {
+		return nil, fmt.Errorf("parse TTL: %w", err)
+	}
+
+	return &cacheLRU[V]{
+		ttl:   ttl,
+		index: lru.NewIndex[Key, V](uint64(cfg.MaxEntries), now, nil, registry),
+	}, nil
+}
@@ -0,0 +1,189 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	app_utils "github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+func TestMakeKey(t *testing.T) {
+	dsi := &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Database: "db",
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Basic{
+				Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: "secret"},
+			},
+		},
+	}
+
+	// tenants having different credentials never share the entries
+	otherCredentials := &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Database: "db",
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Basic{
+				Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: "other"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		otherDSI   *api_common.TGenericDataSourceInstance
+		otherTable string
+		equal      bool
+	}{
+		{name: "same request", otherDSI: dsi, otherTable: "table", equal: true},
+		{name: "different table", otherDSI: dsi, otherTable: "other", equal: false},
+		{name: "different credentials", otherDSI: otherCredentials, otherTable: "table", equal: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := MakeKey("DescribeTable", dsi, &api_service_protos.TDescribeTableRequest{Table: "table"})
+			require.NoError(t, err)
+
+			other, err := MakeKey("DescribeTable", tc.otherDSI, &api_service_protos.TDescribeTableRequest{Table: tc.otherTable})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.equal, key == other)
+		})
+	}
+}
+
+func TestCacheLRU(t *testing.T) {
+	var keys []Key
+
+	for _, table := range []string{"a", "b", "c"} {
+		key, err := MakeKey("DescribeTable", &api_common.TGenericDataSourceInstance{}, &api_service_protos.TDescribeTableRequest{Table: table})
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+
+	t.Run("eviction", func(t *testing.T) {
+		c, err := newCacheLRU[string](&config.TMetadataCacheConfig{Ttl: "1m", MaxEntries: 2}, nop.Registry{}, time.Now)
+		require.NoError(t, err)
+
+		c.Put(keys[0], "a")
+		c.Put(keys[1], "b")
+
+		// make the first entry the most recently used one
+		value, exists := c.Get(keys[0])
+		require.True(t, exists)
+		require.Equal(t, "a", value)
+
+		c.Put(keys[2], "c")
+
+		_, exists = c.Get(keys[1])
+		require.False(t, exists)
+
+		_, exists = c.Get(keys[0])
+		require.True(t, exists)
+
+		_, exists = c.Get(keys[2])
+		require.True(t, exists)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		clock := app_utils.NewManualClock()
+
+		c, err := newCacheLRU[string](&config.TMetadataCacheConfig{Ttl: "1m", MaxEntries: 2}, nop.Registry{}, clock.Now)
+		require.NoError(t, err)
+
+		c.Put(keys[0], "a")
+
+		clock.Advance(59 * time.Second)
+		_, exists := c.Get(keys[0])
+		require.True(t, exists)
+
+		clock.Advance(time.Second)
+		_, exists = c.Get(keys[0])
+		require.False(t, exists)
+		require.Equal(t, 0, c.index.Len())
+	})
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	dataSourceInstances := []*api_common.TGenericDataSourceInstance{
+		{Kind: api_common.EGenericDataSourceKind_POSTGRESQL, Database: "db1"},
+		{Kind: api_common.EGenericDataSourceKind_POSTGRESQL, Database: "db2"},
+		{Kind: api_common.EGenericDataSourceKind_MONGO_DB, Database: "db1"},
+	}
+
+	testCases := []struct {
+		name     string
+		kind     api_common.EGenericDataSourceKind
+		database string
+		dropped  int
+	}{
+		{name: "kind and database", kind: api_common.EGenericDataSourceKind_POSTGRESQL, database: "db1", dropped: 1},
+		{name: "kind", kind: api_common.EGenericDataSourceKind_POSTGRESQL, dropped: 2},
+		{name: "database", kind: api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED, database: "db1", dropped: 2},
+		{name: "everything", kind: api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED, dropped: 3},
+		{name: "nothing", kind: api_common.EGenericDataSourceKind_CLICKHOUSE, dropped: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := newCacheLRU[string](&config.TMetadataCacheConfig{Ttl: "1m", MaxEntries: 10}, nop.Registry{}, time.Now)
+			require.NoError(t, err)
+
+			for _, dsi := range dataSourceInstances {
+				key, err := MakeKey("DescribeTable", dsi, &api_service_protos.TDescribeTableRequest{Table: "table"})
+				require.NoError(t, err)
+
+				c.Put(key, "value")
+			}
+
+			require.Equal(t, tc.dropped, c.Invalidate(tc.kind, tc.database))
+			require.Equal(t, 0, c.Invalidate(tc.kind, tc.database))
+			require.Equal(t, len(dataSourceInstances)-tc.dropped, c.index.Len())
+		})
+	}
+}
+
+func TestNewMetadataCache(t *testing.T) {
+	key, err := MakeKey(
+		"DescribeTable",
+		&api_common.TGenericDataSourceInstance{Kind: api_common.EGenericDataSourceKind_POSTGRESQL, Database: "db"},
+		&api_service_protos.TDescribeTableRequest{Table: "table"},
+	)
+	require.NoError(t, err)
+
+	response := &api_service_protos.TDescribeTableResponse{}
+
+	t.Run("disabled", func(t *testing.T) {
+		c, err := NewMetadataCache(nil, nop.Registry{})
+		require.NoError(t, err)
+
+		c.DescribeTable.Put(key, response)
+
+		_, exists := c.DescribeTable.Get(key)
+		require.False(t, exists)
+	})
+
+	t.Run("schemas only", func(t *testing.T) {
+		c, err := NewMetadataCache(&config.TMetadataCacheConfig{Ttl: "1m", MaxEntries: 10, DisableListSplits: true}, nop.Registry{})
+		require.NoError(t, err)
+
+		c.DescribeTable.Put(key, response)
+		c.ListSplits.Put(key, []*api_service_protos.TListSplitsResponse{{}})
+
+		_, exists := c.DescribeTable.Get(key)
+		require.True(t, exists)
+
+		_, exists = c.ListSplits.Get(key)
+		require.False(t, exists)
+
+		require.Equal(t, 1, c.Invalidate(api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED, ""))
+	})
+}
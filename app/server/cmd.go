@@ -123,7 +123,9 @@ func runFromCLI(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("override config with flags: %w", err)
 	}
 
-	logger, err := common.NewLoggerFromConfig(cfg.Logger)
+	// log level can be changed on config reload;
+	// the logger is the same as the default one if the logger section is missing
+	logger, logLevel, err := common.NewLoggerWithLevelFromConfig(cfg.Logger)
 	if err != nil {
 		return fmt.Errorf("new logger from config: %w", err)
 	}
@@ -133,7 +135,7 @@ func runFromCLI(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("new launcher: %w", err)
 	}
 
-	startLauncherAndWaitForSignalOrError(logger, l)
+	startLauncherAndWaitForSignalOrError(logger, l, newConfigReloader(configPath, cmd.Flags(), logLevel, l, cfg))
 
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
@@ -31,6 +32,14 @@ func makeDefaultPushdownConfig() *config.TPushdownConfig {
 	}
 }
 
+func makeDefaultKeyRangeSplittingConfig() *config.TKeyRangeSplittingConfig {
+	return &config.TKeyRangeSplittingConfig{
+		Enabled:      false,
+		RowsPerSplit: 1000000,
+		MaxSplits:    64,
+	}
+}
+
 // TODO: use reflection to generalize datasource setting code
 //
 //nolint:gocyclo,funlen
@@ -39,6 +48,18 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		c.ConnectorServer.MaxRecvMessageSize = math.MaxInt32
 	}
 
+	if c.ConnectorServer.ReadinessCheckInterval == "" {
+		c.ConnectorServer.ReadinessCheckInterval = "10s"
+	}
+
+	if c.ConnectorServer.DrainTimeout == "" {
+		c.ConnectorServer.DrainTimeout = "30s"
+	}
+
+	if c.MetricsServer != nil && c.MetricsServer.Format == config.TMetricsServerConfig_FORMAT_UNSPECIFIED {
+		c.MetricsServer.Format = config.TMetricsServerConfig_SOLOMON
+	}
+
 	if c.Paging == nil {
 		c.Paging = &config.TPagingConfig{
 			BytesPerPage:          4 * 1024 * 1024,
@@ -113,6 +134,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		c.Datasources.MsSqlServer.Pushdown = makeDefaultPushdownConfig()
 	}
 
+	if c.Datasources.MsSqlServer.Splitting == nil {
+		c.Datasources.MsSqlServer.Splitting = makeDefaultKeyRangeSplittingConfig()
+	}
+
 	// MySQL
 
 	if c.Datasources.Mysql == nil {
@@ -130,6 +155,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		c.Datasources.Mysql.Pushdown = makeDefaultPushdownConfig()
 	}
 
+	if c.Datasources.Mysql.Splitting == nil {
+		c.Datasources.Mysql.Splitting = makeDefaultKeyRangeSplittingConfig()
+	}
+
 	// Oracle
 
 	if c.Datasources.Oracle == nil {
@@ -224,6 +253,86 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 	}
 
 	fillYdbConfigDefaults(c.Datasources.Logging.Ydb)
+
+	// Connection pool
+
+	if c.Datasources.ConnectionPool == nil {
+		c.Datasources.ConnectionPool = &config.TConnectionPoolConfig{
+			Enabled: false,
+		}
+	}
+
+	fillConnectionPoolConfigDefaults(c.Datasources.ConnectionPool)
+
+	// Admission control
+
+	if c.AdmissionControl != nil && c.AdmissionControl.QueueTimeout == "" {
+		c.AdmissionControl.QueueTimeout = "0s"
+	}
+
+	// Observation
+
+	if retention := c.GetObservation().GetStorage().GetRetention(); retention != nil && retention.CheckInterval == "" {
+		retention.CheckInterval = "10m"
+	}
+
+	if storage := c.GetObservation().GetStorage().GetPostgresql(); storage != nil && storage.MaxConnections == 0 {
+		storage.MaxConnections = 10
+	}
+
+	// Tracing
+
+	if c.Tracing != nil {
+		if c.Tracing.SamplingRatio == 0 {
+			c.Tracing.SamplingRatio = 1
+		}
+
+		if c.Tracing.ServiceName == "" {
+			c.Tracing.ServiceName = "fq-connector-go"
+		}
+	}
+
+	// Secrets
+
+	if c.Secrets != nil {
+		if c.Secrets.CacheTtl == "" {
+			c.Secrets.CacheTtl = "5m"
+		}
+
+		if c.Secrets.Vault != nil && c.Secrets.Vault.RequestTimeout == "" {
+			c.Secrets.Vault.RequestTimeout = "10s"
+		}
+	}
+
+	// Metadata cache
+
+	if c.MetadataCache != nil {
+		if c.MetadataCache.Ttl == "" {
+			c.MetadataCache.Ttl = "1m"
+		}
+
+		if c.MetadataCache.MaxEntries == 0 {
+			c.MetadataCache.MaxEntries = 1000
+		}
+	}
+}
+
+func fillConnectionPoolConfigDefaults(c *config.TConnectionPoolConfig) {
+	if c.MaxIdleConnectionsPerKey == 0 {
+		c.MaxIdleConnectionsPerKey = 4
+	}
+
+	if c.IdleTimeout == "" {
+		c.IdleTimeout = "5m"
+	}
+
+	if c.HealthCheckInterval == "" {
+		c.HealthCheckInterval = "0s"
+	}
+
+	if c.HealthCheckTimeout == "" {
+		c.HealthCheckTimeout = "5s"
+	}
 }
 
 func fillYdbConfigDefaults(c *config.TYdbConfig) {
@@ -264,38 +373,255 @@ func fillYdbConfigDefaults(c *config.TYdbConfig) {
 }
 
 func validateServerConfig(c *config.TServerConfig) error {
-	if err := validateConnectorServerConfig(c.ConnectorServer); err != nil {
-		return fmt.Errorf("validate `connector_server`: %w", err)
+	return errors.Join(collectServerConfigProblems(c)...)
+}
+
+// configSection binds the name of the config section to its validation function
+type configSection struct {
+	name     string
+	validate func() error
+}
+
+// collectProblems runs the validation of every section and returns all the errors found
+func collectProblems(sections []configSection) []error {
+	var problems []error
+
+	for _, section := range sections {
+		if err := section.validate(); err != nil {
+			problems = append(problems, fmt.Errorf("validate `%s`: %w", section.name, err))
+		}
 	}
 
-	if err := validateServerReadLimit(c.ReadLimit); err != nil {
-		return fmt.Errorf("validate `read_limit`: %w", err)
+	return problems
+}
+
+// collectServerConfigProblems checks all the sections of the config
+// and returns every problem found instead of stopping at the first one
+func collectServerConfigProblems(c *config.TServerConfig) []error {
+	problems := collectProblems([]configSection{
+		{"connector_server", func() error { return validateConnectorServerConfig(c.ConnectorServer, c.Tls) }},
+		{"tls", func() error { return validateServerTLSConfig(c.Tls) }},
+		{"read_limit", func() error { return validateServerReadLimit(c.ReadLimit) }},
+		{"pprof_server", func() error { return validatePprofServerConfig(c.PprofServer) }},
+		{"metrics_server", func() error { return validateMetricsServerConfig(c.MetricsServer) }},
+		{"paging", func() error { return validatePagingConfig(c.Paging) }},
+		{"conversion", func() error { return validateConversionConfig(c.Conversion) }},
+	})
+
+	// datasources are numerous, so the problems are reported for each of them separately
+	for _, err := range collectDatasourcesConfigProblems(c.Datasources) {
+		problems = append(problems, fmt.Errorf("validate `datasources`: %w", err))
+	}
+
+	return append(problems, collectProblems([]configSection{
+		{"observation", func() error { return validateObservationConfig(c.Observation) }},
+		{"admission_control", func() error { return validateAdmissionControlConfig(c.AdmissionControl) }},
+		{"tracing", func() error { return validateTracingConfig(c.Tracing) }},
+		{"secrets", func() error { return validateSecretsConfig(c.Secrets) }},
+		{"data_policy", func() error { return validateDataPolicyConfig(c.DataPolicy) }},
+		{"metadata_cache", func() error { return validateMetadataCacheConfig(c.MetadataCache) }},
+		{"split_cache", func() error { return validateSplitCacheConfig(c.SplitCache) }},
+	})...)
+}
+
+func validateSplitCacheConfig(c *config.TSplitCacheConfig) error {
+	if c == nil {
+		// It's OK to disable caching
+		return nil
 	}
 
-	if err := validatePprofServerConfig(c.PprofServer); err != nil {
-		return fmt.Errorf("validate `pprof_server`: %w", err)
+	if c.Dir == "" {
+		return fmt.Errorf("empty `dir`")
 	}
 
-	if err := validatePagingConfig(c.Paging); err != nil {
-		return fmt.Errorf("validate `paging`: %w", err)
+	if c.MaxBytes == 0 {
+		return fmt.Errorf("invalid `max_bytes` value: %v", c.MaxBytes)
 	}
 
-	if err := validateConversionConfig(c.Conversion); err != nil {
-		return fmt.Errorf("validate `conversion`: %w", err)
+	if len(c.DataSources) == 0 {
+		return fmt.Errorf("empty `data_sources`")
 	}
 
-	if err := validateDatasourcesConfig(c.Datasources); err != nil {
-		return fmt.Errorf("validate `datasources`: %w", err)
+	for i, ds := range c.DataSources {
+		if ds.Kind == api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED {
+			return fmt.Errorf("`data_sources[%d].kind` is not set", i)
+		}
+
+		ttl, err := common.DurationFromString(ds.Ttl)
+		if err != nil {
+			return fmt.Errorf("validate `data_sources[%d].ttl`: %w", i, err)
+		}
+
+		if ttl <= 0 {
+			return fmt.Errorf("invalid `data_sources[%d].ttl` value: %v", i, ttl)
+		}
 	}
 
-	if err := validateObservationConfig(c.Observation); err != nil {
-		return fmt.Errorf("validate `observation`: %w", err)
+	return nil
+}
+
+func validateMetadataCacheConfig(c *config.TMetadataCacheConfig) error {
+	if c == nil {
+		// It's OK to disable caching
+		return nil
+	}
+
+	ttl, err := common.DurationFromString(c.Ttl)
+	if err != nil {
+		return fmt.Errorf("validate `ttl`: %w", err)
+	}
+
+	if ttl <= 0 {
+		return fmt.Errorf("invalid `ttl` value: %v", ttl)
+	}
+
+	if c.MaxEntries == 0 {
+		return fmt.Errorf("invalid `max_entries` value: %v", c.MaxEntries)
+	}
+
+	return nil
+}
+
+func validateDataPolicyConfig(c *config.TDataPolicyConfig) error {
+	if c == nil {
+		// All the data is accessible
+		return nil
+	}
+
+	for i, rule := range c.Rules {
+		if err := validateDataPolicyRule(rule); err != nil {
+			return fmt.Errorf("validate `rules[%d]`: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateDataPolicyRule(c *config.TDataPolicyConfig_TRule) error {
+	if _, err := path.Match(c.Database, ""); err != nil {
+		return fmt.Errorf("invalid `database` pattern '%s': %w", c.Database, err)
+	}
+
+	if _, err := path.Match(c.Table, ""); err != nil {
+		return fmt.Errorf("invalid `table` pattern '%s': %w", c.Table, err)
+	}
+
+	for i, mask := range c.Masks {
+		if mask.Column == "" {
+			return fmt.Errorf("empty `masks[%d].column`", i)
+		}
+
+		if mask.Method == config.TDataPolicyConfig_MASKING_METHOD_UNSPECIFIED {
+			return fmt.Errorf("`masks[%d].method` is not set", i)
+		}
+	}
+
+	return nil
+}
+
+func validateSecretsConfig(c *config.TSecretsConfig) error {
+	if c == nil {
+		// It's OK to pass credentials as is
+		return nil
+	}
+
+	if c.File == nil && c.Env == nil && c.Vault == nil {
+		return fmt.Errorf("no secret providers configured")
+	}
+
+	if c.File != nil && c.File.RootDir == "" {
+		return fmt.Errorf("empty `file.root_dir`")
+	}
+
+	if c.Env != nil && c.Env.VariablePrefix == "" {
+		return fmt.Errorf("empty `env.variable_prefix`")
+	}
+
+	for i, binding := range c.Bindings {
+		if binding.Reference == "" {
+			return fmt.Errorf("empty `bindings[%d].reference`", i)
+		}
+
+		if binding.Kind == api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED {
+			return fmt.Errorf("`bindings[%d].kind` is not set", i)
+		}
+
+		if binding.Endpoint.GetHost() == "" || binding.Endpoint.GetPort() == 0 {
+			return fmt.Errorf("invalid `bindings[%d].endpoint` value: %v", i, binding.Endpoint)
+		}
+	}
+
+	if c.Vault != nil {
+		if c.Vault.Address == "" {
+			return fmt.Errorf("empty `vault.address`")
+		}
+
+		if c.Vault.TokenFile == "" {
+			return fmt.Errorf("empty `vault.token_file`")
+		}
+
+		requestTimeout, err := common.DurationFromString(c.Vault.RequestTimeout)
+		if err != nil {
+			return fmt.Errorf("validate `vault.request_timeout`: %w", err)
+		}
+
+		if requestTimeout <= 0 {
+			return fmt.Errorf("invalid `vault.request_timeout` value: %v", requestTimeout)
+		}
+	}
+
+	// Zero TTL disables caching
+	cacheTTL, err := common.DurationFromString(c.CacheTtl)
+	if err != nil {
+		return fmt.Errorf("validate `cache_ttl`: %w", err)
+	}
+
+	if cacheTTL < 0 {
+		return fmt.Errorf("invalid `cache_ttl` value: %v", cacheTTL)
 	}
 
 	return nil
 }
 
-func validateConnectorServerConfig(c *config.TConnectorServerConfig) error {
+func validateTracingConfig(c *config.TTracingConfig) error {
+	if c == nil {
+		// It's OK to disable tracing
+		return nil
+	}
+
+	switch exporter := c.Exporter.(type) {
+	case *config.TTracingConfig_Stdout:
+	case *config.TTracingConfig_File:
+		if exporter.File.GetPath() == "" {
+			return fmt.Errorf("empty `file.path`")
+		}
+	default:
+		return fmt.Errorf("exporter is not set")
+	}
+
+	if c.SamplingRatio <= 0 || c.SamplingRatio > 1 {
+		return fmt.Errorf("invalid `sampling_ratio` value: %v", c.SamplingRatio)
+	}
+
+	return nil
+}
+
+func validateAdmissionControlConfig(c *config.TAdmissionControlConfig) error {
+	if c == nil {
+		// It's OK to disable admission control
+		return nil
+	}
+
+	if _, err := common.DurationFromString(c.QueueTimeout); err != nil {
+		return fmt.Errorf("validate `queue_timeout`: %w", err)
+	}
+
+	return nil
+}
+
+// validateConnectorServerConfig takes the deprecated top-level TLS section,
+// because the server falls back to it when `connector_server.tls` is not set
+func validateConnectorServerConfig(c *config.TConnectorServerConfig, deprecatedTLS *config.TServerTLSConfig) error {
 	if c == nil {
 		return fmt.Errorf("required section is missing")
 	}
@@ -308,6 +634,74 @@ func validateConnectorServerConfig(c *config.TConnectorServerConfig) error {
 		return fmt.Errorf("validate `tls`: %w", err)
 	}
 
+	tlsConfig := c.Tls
+	if tlsConfig == nil {
+		tlsConfig = deprecatedTLS
+	}
+
+	if err := validateClientAuthConfig(c.Auth, tlsConfig); err != nil {
+		return fmt.Errorf("validate `auth`: %w", err)
+	}
+
+	readinessCheckInterval, err := common.DurationFromString(c.ReadinessCheckInterval)
+	if err != nil {
+		return fmt.Errorf("validate `readiness_check_interval`: %w", err)
+	}
+
+	if readinessCheckInterval <= 0 {
+		return fmt.Errorf("invalid value of field `readiness_check_interval`: %v", c.ReadinessCheckInterval)
+	}
+
+	if _, err := common.DurationFromString(c.DrainTimeout); err != nil {
+		return fmt.Errorf("validate `drain_timeout`: %w", err)
+	}
+
+	return nil
+}
+
+func validateClientAuthConfig(c *config.TClientAuthConfig, tlsConfig *config.TServerTLSConfig) error {
+	if c == nil {
+		// It's OK not to have auth config section
+		return nil
+	}
+
+	if c.Mtls == nil && c.Token == nil {
+		return fmt.Errorf("neither `mtls` nor `token` are set")
+	}
+
+	if c.Mtls != nil {
+		if tlsConfig == nil {
+			return fmt.Errorf("`mtls` requires server `tls` section to be set")
+		}
+
+		if c.Mtls.ClientCa == "" {
+			return fmt.Errorf("invalid value of field `mtls.client_ca`: %v", c.Mtls.ClientCa)
+		}
+	}
+
+	if c.Token != nil {
+		if err := validateTokenAuthConfig(c.Token); err != nil {
+			return fmt.Errorf("validate `token`: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func validateTokenAuthConfig(c *config.TClientAuthConfig_TTokenAuth) error {
+	switch t := c.Payload.(type) {
+	case *config.TClientAuthConfig_TTokenAuth_Static:
+		if len(t.Static.GetTokens()) == 0 && t.Static.GetTokensFile() == "" {
+			return fmt.Errorf("either `static.tokens` or `static.tokens_file` must be set")
+		}
+	case *config.TClientAuthConfig_TTokenAuth_Jwt:
+		if t.Jwt.GetJwksFile() == "" {
+			return fmt.Errorf("invalid value of field `jwt.jwks_file`: %v", t.Jwt.GetJwksFile())
+		}
+	default:
+		return fmt.Errorf("unexpected payload type: %T", c.Payload)
+	}
+
 	return nil
 }
 
@@ -375,6 +769,23 @@ func validatePprofServerConfig(c *config.TPprofServerConfig) error {
 	return nil
 }
 
+func validateMetricsServerConfig(c *config.TMetricsServerConfig) error {
+	if c == nil {
+		// It's OK to disable metrics server
+		return nil
+	}
+
+	if err := validateEndpoint(c.Endpoint); err != nil {
+		return fmt.Errorf("validate `endpoint`: %w", err)
+	}
+
+	if err := validateServerTLSConfig(c.Tls); err != nil {
+		return fmt.Errorf("validate `tls`: %w", err)
+	}
+
+	return nil
+}
+
 const maxInterconnectMessageSize = 50 * 1024 * 1024
 
 func validatePagingConfig(c *config.TPagingConfig) error {
@@ -391,64 +802,101 @@ func validatePagingConfig(c *config.TPagingConfig) error {
 		return fmt.Errorf("`bytes_per_page` limit exceeds the limits of interconnect system used by YDB engine")
 	}
 
-	return nil
-}
+	// otherwise a single page would occupy the whole budget
+	if c.MemoryBudgetBytes != 0 && c.MemoryBudgetBytes < c.BytesPerPage {
+		return fmt.Errorf("`memory_budget_bytes` must not be less than `bytes_per_page`")
+	}
 
-func validateConversionConfig(c *config.TConversionConfig) error {
-	if c == nil {
-		return fmt.Errorf("required section is missing")
+	if c.Adaptive != nil {
+		if err := validateAdaptivePagingConfig(c); err != nil {
+			return fmt.Errorf("validate `adaptive`: %w", err)
+		}
 	}
 
 	return nil
 }
 
-func validateDatasourcesConfig(c *config.TDatasourcesConfig) error {
-	if c == nil {
-		return fmt.Errorf("required section is missing")
-	}
+func validateAdaptivePagingConfig(c *config.TPagingConfig) error {
+	minBytes, maxBytes := c.Adaptive.MinBytesPerPage, c.Adaptive.MaxBytesPerPage
 
-	if err := validateRelationalDatasourceConfig(c.Clickhouse); err != nil {
-		return fmt.Errorf("validate `clickhouse`: %w", err)
+	if minBytes == 0 || maxBytes < minBytes {
+		return fmt.Errorf("invalid bounds: `min_bytes_per_page` must be positive and not greater than `max_bytes_per_page`")
 	}
 
-	if err := validateRelationalDatasourceConfig(c.Greenplum); err != nil {
-		return fmt.Errorf("validate `greenplum`: %w", err)
+	if maxBytes > maxInterconnectMessageSize {
+		return fmt.Errorf("`max_bytes_per_page` limit exceeds the limits of interconnect system used by YDB engine")
 	}
 
-	if err := validateLoggingConfig(c.Logging); err != nil {
-		return fmt.Errorf("validate `logging`: %w", err)
+	// initial page size
+	if c.BytesPerPage < minBytes || c.BytesPerPage > maxBytes {
+		return fmt.Errorf("`bytes_per_page` must be within [`min_bytes_per_page`, `max_bytes_per_page`]")
 	}
 
-	if err := validateRelationalDatasourceConfig(c.MsSqlServer); err != nil {
-		return fmt.Errorf("validate `ms_sql_server`: %w", err)
+	if c.MemoryBudgetBytes != 0 && c.MemoryBudgetBytes < maxBytes {
+		return fmt.Errorf("`memory_budget_bytes` must not be less than `max_bytes_per_page`")
 	}
 
-	if err := validateRelationalDatasourceConfig(c.Mysql); err != nil {
-		return fmt.Errorf("validate `mysql`: %w", err)
+	return nil
+}
+
+func validateConversionConfig(c *config.TConversionConfig) error {
+	if c == nil {
+		return fmt.Errorf("required section is missing")
 	}
 
-	if err := validateRelationalDatasourceConfig(c.Oracle); err != nil {
-		return fmt.Errorf("validate `oracle`: %w", err)
+	return nil
+}
+
+func collectDatasourcesConfigProblems(c *config.TDatasourcesConfig) []error {
+	if c == nil {
+		return []error{fmt.Errorf("required section is missing")}
+	}
+
+	return collectProblems([]configSection{
+		{"clickhouse", func() error { return validateRelationalDatasourceConfig(c.Clickhouse) }},
+		{"greenplum", func() error { return validateRelationalDatasourceConfig(c.Greenplum) }},
+		{"logging", func() error { return validateLoggingConfig(c.Logging) }},
+		{"ms_sql_server", func() error { return validateRelationalDatasourceConfig(c.MsSqlServer) }},
+		{"ms_sql_server.splitting", func() error { return validateKeyRangeSplittingConfig(c.MsSqlServer.GetSplitting()) }},
+		{"mysql", func() error { return validateRelationalDatasourceConfig(c.Mysql) }},
+		{"mysql.splitting", func() error { return validateKeyRangeSplittingConfig(c.Mysql.GetSplitting()) }},
+		{"oracle", func() error { return validateRelationalDatasourceConfig(c.Oracle) }},
+		{"postgresql", func() error { return validateRelationalDatasourceConfig(c.Postgresql) }},
+		{"ydb", func() error { return validateYdbConfig(c.Ydb) }},
+		{"mongodb", func() error { return validateMongoDBConfig(c.Mongodb) }},
+		{"opensearch", func() error { return validateOpenSearchConfig(c.Opensearch) }},
+		{"redis", func() error { return validateRedisConfig(c.Redis) }},
+		{"connection_pool", func() error { return validateConnectionPoolConfig(c.ConnectionPool) }},
+	})
+}
+
+func validateConnectionPoolConfig(c *config.TConnectionPoolConfig) error {
+	if c == nil || !c.Enabled {
+		return nil
 	}
 
-	if err := validateRelationalDatasourceConfig(c.Postgresql); err != nil {
-		return fmt.Errorf("validate `postgresql`: %w", err)
+	if c.MaxOpenConnectionsPerKey != 0 && c.MaxIdleConnectionsPerKey > c.MaxOpenConnectionsPerKey {
+		return fmt.Errorf(
+			"`max_idle_connections_per_key` (%d) must not exceed `max_open_connections_per_key` (%d)",
+			c.MaxIdleConnectionsPerKey, c.MaxOpenConnectionsPerKey,
+		)
 	}
 
-	if err := validateYdbConfig(c.Ydb); err != nil {
-		return fmt.Errorf("validate `ydb`: %w", err)
+	idleTimeout, err := common.DurationFromString(c.IdleTimeout)
+	if err != nil {
+		return fmt.Errorf("validate `idle_timeout`: %v", err)
 	}
 
-	if err := validateMongoDBConfig(c.Mongodb); err != nil {
-		return fmt.Errorf("validate `mongodb`: %w", err)
+	if idleTimeout <= 0 {
+		return fmt.Errorf("invalid value of field `idle_timeout`: %v", c.IdleTimeout)
 	}
 
-	if err := validateOpenSearchConfig(c.Opensearch); err != nil {
-		return fmt.Errorf("validate `opensearch`: %w", err)
+	if _, err := common.DurationFromString(c.HealthCheckInterval); err != nil {
+		return fmt.Errorf("validate `health_check_interval`: %v", err)
 	}
 
-	if err := validateRedisConfig(c.Redis); err != nil {
-		return fmt.Errorf("validate `redis`: %w", err)
+	if _, err := common.DurationFromString(c.HealthCheckTimeout); err != nil {
+		return fmt.Errorf("validate `health_check_timeout`: %v", err)
 	}
 
 	return nil
@@ -480,6 +928,22 @@ func validateRelationalDatasourceConfig(c relationalDatasourceConfig) error {
 	return nil
 }
 
+func validateKeyRangeSplittingConfig(c *config.TKeyRangeSplittingConfig) error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+
+	if c.RowsPerSplit == 0 {
+		return fmt.Errorf("invalid value of field `rows_per_split`: %v", c.RowsPerSplit)
+	}
+
+	if c.MaxSplits < 2 {
+		return fmt.Errorf("invalid value of field `max_splits`: %v", c.MaxSplits)
+	}
+
+	return nil
+}
+
 func validateYdbConfig(c *config.TYdbConfig) error {
 	if c == nil {
 		return nil
@@ -735,6 +1199,49 @@ func validateObservationStorageConfig(c *config.TObservationConfig_TStorage) err
 		}
 	}
 
+	if storage := c.GetPostgresql(); storage != nil {
+		if storage.Dsn == "" {
+			return fmt.Errorf("empty `postgresql.dsn`")
+		}
+	}
+
+	if err := validateObservationRetentionConfig(c.Retention); err != nil {
+		return fmt.Errorf("validate `retention`: %w", err)
+	}
+
+	return nil
+}
+
+func validateObservationRetentionConfig(c *config.TObservationConfig_TStorage_TRetention) error {
+	if c == nil {
+		// It's OK to keep query records forever
+		return nil
+	}
+
+	if c.MaxAge != "" {
+		maxAge, err := common.DurationFromString(c.MaxAge)
+		if err != nil {
+			return fmt.Errorf("validate `max_age`: %w", err)
+		}
+
+		if maxAge <= 0 {
+			return fmt.Errorf("invalid `max_age` value: %v", maxAge)
+		}
+	}
+
+	if c.MaxAge == "" && c.MaxRows == 0 {
+		return fmt.Errorf("either `max_age` or `max_rows` must be set")
+	}
+
+	checkInterval, err := common.DurationFromString(c.CheckInterval)
+	if err != nil {
+		return fmt.Errorf("validate `check_interval`: %w", err)
+	}
+
+	if checkInterval <= 0 {
+		return fmt.Errorf("invalid `check_interval` value: %v", checkInterval)
+	}
+
 	return nil
 }
 
@@ -786,6 +1293,31 @@ func newConfigFromYAMLFile(configPath string) (*config.TServerConfig, error) {
 }
 
 func NewConfigFromFile(configPath string) (*config.TServerConfig, error) {
+	cfg, err := parseConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateServerConfig(cfg); err != nil {
+		return nil, fmt.Errorf("validate config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// ValidateConfigFile parses the config file and returns all the problems found in it
+// as a list, so that they can be reported one by one.
+func ValidateConfigFile(configPath string) (*config.TServerConfig, []error) {
+	cfg, err := parseConfigFile(configPath)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return cfg, collectServerConfigProblems(cfg)
+}
+
+// parseConfigFile reads the config in any of the supported formats and fills the defaults
+func parseConfigFile(configPath string) (*config.TServerConfig, error) {
 	var parsers = map[string]func(string) (*config.TServerConfig, error){
 		"yaml":      newConfigFromYAMLFile,
 		"prototext": newConfigFromPrototextFile,
@@ -814,10 +1346,6 @@ func NewConfigFromFile(configPath string) (*config.TServerConfig, error) {
 
 	fillServerConfigDefaults(cfg)
 
-	if err := validateServerConfig(cfg); err != nil {
-		return nil, fmt.Errorf("validate config: %w", err)
-	}
-
 	return cfg, nil
 }
 
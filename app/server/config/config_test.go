@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 )
 
@@ -57,3 +58,59 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfigFile(t *testing.T) {
+	f, err := os.CreateTemp("", "test-config")
+	require.NoError(t, err)
+
+	path := f.Name()
+
+	defer os.Remove(path)
+
+	// several sections are broken at once
+	_, err = f.WriteString(`
+connector_server:
+  endpoint:
+    host: 0.0.0.0
+    port: 2130
+paging:
+  bytes_per_page: 0
+  rows_per_page: 0
+observation:
+  storage:
+    sqlite: {}
+  server:
+    endpoint: {host: localhost, port: 8080}
+`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cfg, problems := ValidateConfigFile(path)
+	require.NotNil(t, cfg)
+	require.Len(t, problems, 2)
+	require.ErrorContains(t, problems[0], "validate `paging`")
+	require.ErrorContains(t, problems[1], "validate `observation`: validate `storage`: empty `sqlite.path`")
+
+	_, err = NewConfigFromFile(path)
+	require.ErrorContains(t, err, "validate `paging`")
+	require.ErrorContains(t, err, "validate `observation`")
+}
+
+func TestValidateConnectorServerConfigMutualTLS(t *testing.T) {
+	makeConfig := func(tls *config.TServerTLSConfig) *config.TConnectorServerConfig {
+		return &config.TConnectorServerConfig{
+			Endpoint:               &api_common.TGenericEndpoint{Host: "0.0.0.0", Port: 2130},
+			Tls:                    tls,
+			Auth:                   &config.TClientAuthConfig{Mtls: &config.TClientAuthConfig_TMutualTLS{ClientCa: "ca.crt"}},
+			ReadinessCheckInterval: "10s",
+			DrainTimeout:           "30s",
+		}
+	}
+
+	tls := &config.TServerTLSConfig{Key: "server.key", Cert: "server.crt"}
+
+	require.NoError(t, validateConnectorServerConfig(makeConfig(tls), nil))
+	// the deprecated top-level section is used by the server as well
+	require.NoError(t, validateConnectorServerConfig(makeConfig(nil), tls))
+	require.ErrorContains(t, validateConnectorServerConfig(makeConfig(nil), nil), "`mtls` requires server `tls` section")
+}
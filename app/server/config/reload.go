@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+// sectionsPartiallyReloadable describes the sections where only some of the fields are reloadable
+var sectionsPartiallyReloadable = map[protoreflect.Name]string{
+	"paging": "paging.memory_budget_bytes",
+	"logger": "logger (except log_level)",
+}
+
+// CheckReloadable makes sure that the new config differs from the current one
+// only in the sections that can be applied without restart:
+// `datasources`, `paging` (except `memory_budget_bytes`), `read_limit`, `data_policy` and `logger.log_level`.
+func CheckReloadable(current, next *config.TServerConfig) error {
+	currentStripped := stripReloadableSections(current).ProtoReflect()
+	nextStripped := stripReloadableSections(next).ProtoReflect()
+
+	var changed []string
+
+	fields := currentStripped.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		if proto.Equal(pickField(currentStripped, field), pickField(nextStripped, field)) {
+			continue
+		}
+
+		if name, exists := sectionsPartiallyReloadable[field.Name()]; exists {
+			changed = append(changed, name)
+		} else {
+			changed = append(changed, string(field.Name()))
+		}
+	}
+
+	if len(changed) > 0 {
+		return fmt.Errorf("the following sections can't be changed without restart: %s", strings.Join(changed, ", "))
+	}
+
+	return nil
+}
+
+// stripReloadableSections returns a copy of the config without the reloadable fields
+func stripReloadableSections(cfg *config.TServerConfig) *config.TServerConfig {
+	result := proto.Clone(cfg).(*config.TServerConfig)
+
+	result.Datasources = nil
+	result.ReadLimit = nil
+	result.DataPolicy = nil
+
+	// memory budget is shared across all requests, so it's set once at startup
+	if result.Paging != nil {
+		result.Paging = &config.TPagingConfig{MemoryBudgetBytes: result.Paging.MemoryBudgetBytes}
+	}
+
+	if result.Logger != nil {
+		result.Logger.LogLevel = config.ELogLevel_TRACE
+	}
+
+	return result
+}
+
+// pickField returns a message with the only field copied from the source message
+func pickField(src protoreflect.Message, field protoreflect.FieldDescriptor) proto.Message {
+	dst := src.Type().New()
+
+	if src.Has(field) {
+		dst.Set(field, src.Get(field))
+	}
+
+	return dst.Interface()
+}
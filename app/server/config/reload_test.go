@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func TestCheckReloadable(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(cfg *config.TServerConfig)
+		err    string
+	}{
+		{
+			name:   "no changes",
+			modify: func(*config.TServerConfig) {},
+		},
+		{
+			name: "reloadable sections",
+			modify: func(cfg *config.TServerConfig) {
+				cfg.Datasources.Postgresql.Pushdown = &config.TPushdownConfig{EnableTimestampPushdown: true}
+				cfg.Datasources.Logging.Ydb.Mode = config.TYdbConfig_MODE_TABLE_SERVICE_STDLIB_SCAN_QUERIES
+				cfg.Paging.BytesPerPage = 1024
+				cfg.ReadLimit = &config.TServerReadLimit{Rows: 10}
+				cfg.Logger.LogLevel = config.ELogLevel_ERROR
+				cfg.DataPolicy = &config.TDataPolicyConfig{
+					Rules: []*config.TDataPolicyConfig_TRule{{Table: "users", DeniedColumns: []string{"email"}}},
+				}
+			},
+		},
+		{
+			name: "endpoint",
+			modify: func(cfg *config.TServerConfig) {
+				cfg.ConnectorServer.Endpoint.Port++
+			},
+			err: "connector_server",
+		},
+		{
+			name: "memory budget",
+			modify: func(cfg *config.TServerConfig) {
+				cfg.Paging.MemoryBudgetBytes = 1 << 30
+			},
+			err: "paging.memory_budget_bytes",
+		},
+		{
+			name: "logger",
+			modify: func(cfg *config.TServerConfig) {
+				cfg.Logger.EnableSqlQueryLogging = !cfg.Logger.EnableSqlQueryLogging
+			},
+			err: "logger (except log_level)",
+		},
+		{
+			name: "several sections",
+			modify: func(cfg *config.TServerConfig) {
+				cfg.ConnectorServer.Endpoint.Port++
+				cfg.Conversion.UseUnsafeConverters = !cfg.Conversion.UseUnsafeConverters
+			},
+			err: "connector_server, conversion",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			current := NewDefaultConfig()
+			next := proto.Clone(current).(*config.TServerConfig)
+			tc.modify(next)
+
+			err := CheckReloadable(current, next)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -3,14 +3,18 @@ package server
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service "github.com/ydb-platform/fq-connector-go/api/service"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/admission"
+	"github.com/ydb-platform/fq-connector-go/app/server/cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/nosql/mongodb"
@@ -20,33 +24,116 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/s3"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/policy"
+	"github.com/ydb-platform/fq-connector-go/app/server/secrets"
+	"github.com/ydb-platform/fq-connector-go/app/server/splitcache"
 	"github.com/ydb-platform/fq-connector-go/app/server/streaming"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
 )
 
 type DataSourceCollection struct {
-	rdbms               datasource.Factory[any]
-	memoryAllocator     memory.Allocator
-	readLimiterFactory  *paging.ReadLimiterFactory
+	memoryAccountant    paging.MemoryAccountant
+	queryLoggerFactory  common.QueryLoggerFactory
 	converterCollection conversion.Collection
 	observationStorage  observation.Storage
-	cfg                 *config.TServerConfig
+	queryRegistry       *observation.QueryRegistry
+	admissionController admission.Controller
+	pagingMetrics       *paging.Metrics
+	secretResolver      secrets.Resolver
+	metadataCache       *cache.MetadataCache
+	splitCache          splitcache.Store
+	registry            metrics.Registry
+	logger              *zap.Logger
+
+	// the objects depending on the reloadable sections of the config
+	stateMutex sync.Mutex
+	state      *dataSourceCollectionState
 }
 
 func (dsc *DataSourceCollection) DescribeTable(
 	ctx context.Context,
 	logger *zap.Logger,
 	request *api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TDescribeTableResponse, error) {
+	state := dsc.acquireState()
+	defer state.release(logger)
+
+	response, err := dsc.describeTableCached(ctx, logger, state, request)
+	if err != nil || response.GetSchema() == nil {
+		return response, err
+	}
+
+	// Hide the sensitive columns from the client
+	schema, decisions, err := state.policy.ApplyToSchema(request.GetDataSourceInstance(), request.GetTable(), response.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("apply data policy: %w", err)
+	}
+
+	if len(decisions) > 0 {
+		logger.Info("data policy applied", zap.String("decisions", decisions.String()))
+
+		response = proto.Clone(response).(*api_service_protos.TDescribeTableResponse)
+		response.Schema = schema
+	}
+
+	return response, nil
+}
+
+// describeTableCached serves the schema from the metadata cache if possible.
+// Only the successful responses are cached.
+func (dsc *DataSourceCollection) describeTableCached(
+	ctx context.Context,
+	logger *zap.Logger,
+	state *dataSourceCollectionState,
+	request *api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TDescribeTableResponse, error) {
+	key, err := cache.MakeKey("DescribeTable", request.GetDataSourceInstance(), request)
+	if err != nil {
+		return nil, fmt.Errorf("make cache key: %w", err)
+	}
+
+	if response, exists := dsc.metadataCache.DescribeTable.Get(key); exists {
+		logger.Debug("table schema taken from cache")
+
+		return response, nil
+	}
+
+	response, err := dsc.describeTable(ctx, logger, state, request)
+	if err == nil && common.IsSuccess(response.GetError()) {
+		dsc.metadataCache.DescribeTable.Put(key, response)
+	}
+
+	return response, err
+}
+
+func (dsc *DataSourceCollection) describeTable(
+	ctx context.Context,
+	logger *zap.Logger,
+	state *dataSourceCollectionState,
+	request *api_service_protos.TDescribeTableRequest,
 ) (*api_service_protos.TDescribeTableResponse, error) {
 	kind := request.GetDataSourceInstance().GetKind()
 
+	// Non-relational data sources dial on their own, so the secrets are resolved in advance
+	dsi, err := dsc.secretResolver.ResolveCredentials(ctx, request.GetDataSourceInstance())
+	if err != nil {
+		return nil, fmt.Errorf("resolve credentials: %w", err)
+	}
+
+	if dsi != request.GetDataSourceInstance() {
+		request = proto.Clone(request).(*api_service_protos.TDescribeTableRequest)
+		request.DataSourceInstance = dsi
+	}
+
 	switch kind {
 	case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
 		api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
 		api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM,
 		api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_LOGGING:
-		ds, err := dsc.rdbms.Make(logger, kind)
+		ds, err := state.rdbms.Make(logger, kind)
 		if err != nil {
 			return nil, fmt.Errorf("make data source: %w", err)
 		}
@@ -57,7 +144,7 @@ func (dsc *DataSourceCollection) DescribeTable(
 
 		return ds.DescribeTable(ctx, logger, request)
 	case api_common.EGenericDataSourceKind_MONGO_DB:
-		mongoDbCfg := dsc.cfg.Datasources.Mongodb
+		mongoDbCfg := state.cfg.Datasources.Mongodb
 		ds := mongodb.NewDataSource(
 			&retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -69,7 +156,7 @@ func (dsc *DataSourceCollection) DescribeTable(
 
 		return ds.DescribeTable(ctx, logger, request)
 	case api_common.EGenericDataSourceKind_REDIS:
-		redisCfg := dsc.cfg.Datasources.Redis
+		redisCfg := state.cfg.Datasources.Redis
 		ds := redis.NewDataSource(
 			&retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -81,7 +168,7 @@ func (dsc *DataSourceCollection) DescribeTable(
 
 		return ds.DescribeTable(ctx, logger, request)
 	case api_common.EGenericDataSourceKind_OPENSEARCH:
-		openSearchCfg := dsc.cfg.Datasources.Opensearch
+		openSearchCfg := state.cfg.Datasources.Opensearch
 		ds := opensearch.NewDataSource(
 			&retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -101,74 +188,116 @@ func (dsc *DataSourceCollection) ListSplits(
 	stream api_service.Connector_ListSplitsServer,
 	request *api_service_protos.TListSplitsRequest,
 ) error {
+	state := dsc.acquireState()
+	defer state.release(logger)
+
+	// the parameters of the request affecting every select
+	params := proto.Clone(request).(*api_service_protos.TListSplitsRequest)
+	params.Selects = nil
+
 	for _, slct := range request.GetSelects() {
-		kind := slct.GetDataSourceInstance().GetKind()
-
-		switch kind {
-		case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
-			api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
-			api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM,
-			api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_LOGGING:
-			ds, err := dsc.rdbms.Make(logger, kind)
-			if err != nil {
-				return fmt.Errorf("make data source: %w", err)
-			}
+		key, err := cache.MakeKey("ListSplits", slct.GetDataSourceInstance(), slct, params)
+		if err != nil {
+			return fmt.Errorf("make cache key: %w", err)
+		}
 
-			streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
+		if responses, exists := dsc.metadataCache.ListSplits.Get(key); exists {
+			logger.Debug("table splits taken from cache", zap.Int("total", len(responses)))
 
-			if err := streamer.Run(); err != nil {
-				return fmt.Errorf("run streamer: %w", err)
-			}
-		case api_common.EGenericDataSourceKind_MONGO_DB:
-			mongoDbCfg := dsc.cfg.Datasources.Mongodb
-			ds := mongodb.NewDataSource(
-				&retry.RetrierSet{
-					MakeConnection: retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
-					Query:          retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
-				},
-				dsc.converterCollection,
-				mongoDbCfg,
-			)
-
-			streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
-
-			if err := streamer.Run(); err != nil {
-				return fmt.Errorf("run streamer: %w", err)
+			for _, response := range responses {
+				if err := stream.Send(response); err != nil {
+					return fmt.Errorf("stream send: %w", err)
+				}
 			}
-		case api_common.EGenericDataSourceKind_REDIS:
-			redisCfg := dsc.cfg.Datasources.Redis
-			ds := redis.NewDataSource(
-				&retry.RetrierSet{
-					MakeConnection: retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
-					Query:          retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
-				},
-				redisCfg,
-				dsc.converterCollection,
-			)
-
-			streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
-
-			if err := streamer.Run(); err != nil {
-				return fmt.Errorf("run streamer: %w", err)
-			}
-		case api_common.EGenericDataSourceKind_OPENSEARCH:
-			openSearchCfg := dsc.cfg.Datasources.Opensearch
-			ds := opensearch.NewDataSource(
-				&retry.RetrierSet{
-					MakeConnection: retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
-					Query:          retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
-				},
-				openSearchCfg,
-			)
-
-			streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
-
-			if err := streamer.Run(); err != nil {
-				return fmt.Errorf("run streamer: %w", err)
-			}
-		default:
-			return fmt.Errorf("unsupported data source type '%v': %w", kind, common.ErrDataSourceNotSupported)
+
+			continue
+		}
+
+		recorder := &listSplitsRecorder{Connector_ListSplitsServer: stream}
+
+		if err := dsc.listSplits(logger, state, recorder, request, slct); err != nil {
+			return err
+		}
+
+		dsc.metadataCache.ListSplits.Put(key, recorder.responses)
+	}
+
+	return nil
+}
+
+func (dsc *DataSourceCollection) listSplits(
+	logger *zap.Logger,
+	state *dataSourceCollectionState,
+	stream api_service.Connector_ListSplitsServer,
+	request *api_service_protos.TListSplitsRequest,
+	slct *api_service_protos.TSelect,
+) error {
+	kind := slct.GetDataSourceInstance().GetKind()
+
+	switch kind {
+	case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
+		api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+		api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM,
+		api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_LOGGING:
+		ds, err := state.rdbms.Make(logger, kind)
+		if err != nil {
+			return fmt.Errorf("make data source: %w", err)
+		}
+
+		streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
+
+		if err := streamer.Run(); err != nil {
+			return fmt.Errorf("run streamer: %w", err)
+		}
+	case api_common.EGenericDataSourceKind_MONGO_DB:
+		mongoDbCfg := state.cfg.Datasources.Mongodb
+		ds := mongodb.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			dsc.converterCollection,
+			mongoDbCfg,
+		)
+
+		streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
+
+		if err := streamer.Run(); err != nil {
+			return fmt.Errorf("run streamer: %w", err)
 		}
+	case api_common.EGenericDataSourceKind_REDIS:
+		redisCfg := state.cfg.Datasources.Redis
+		ds := redis.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			redisCfg,
+			dsc.converterCollection,
+		)
+
+		streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
+
+		if err := streamer.Run(); err != nil {
+			return fmt.Errorf("run streamer: %w", err)
+		}
+	case api_common.EGenericDataSourceKind_OPENSEARCH:
+		openSearchCfg := state.cfg.Datasources.Opensearch
+		ds := opensearch.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			openSearchCfg,
+		)
+
+		streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
+
+		if err := streamer.Run(); err != nil {
+			return fmt.Errorf("run streamer: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported data source type '%v': %w", kind, common.ErrDataSourceNotSupported)
 	}
 
 	return nil
@@ -180,33 +309,77 @@ func (dsc *DataSourceCollection) ReadSplit(
 	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
 ) error {
+	state := dsc.acquireState()
+	defer state.release(logger)
+
 	kind := split.GetSelect().GetDataSourceInstance().GetKind()
 
+	// Non-relational data sources dial on their own, so the secrets are resolved in advance.
+	// It's safe, since the data source instance is never sent back within ReadSplits response.
+	dsi, err := dsc.secretResolver.ResolveCredentials(stream.Context(), split.GetSelect().GetDataSourceInstance())
+	if err != nil {
+		return fmt.Errorf("resolve credentials: %w", err)
+	}
+
+	if dsi != split.GetSelect().GetDataSourceInstance() {
+		split = proto.Clone(split).(*api_service_protos.TSplit)
+		split.Select.DataSourceInstance = dsi
+	}
+
+	// Wait until the request fits into concurrency limits
+	ticket, err := dsc.admissionController.Admit(stream.Context(), logger, &admission.Request{
+		Kind:     kind,
+		Endpoint: split.GetSelect().GetDataSourceInstance().GetEndpoint(),
+		UserID:   utils.UserIDFromContext(stream.Context()),
+	})
+	if err != nil {
+		return fmt.Errorf("admit request: %w", err)
+	}
+
+	defer ticket.Release()
+
 	// Register query for further analysis
 	queryID, err := dsc.observationStorage.CreateIncomingQuery(kind)
 	if err != nil {
 		return fmt.Errorf("create query: %w", err)
 	}
 
+	// Make query cancellable via observation API
+	ctx, unregister := dsc.queryRegistry.Register(stream.Context(), queryID)
+	defer unregister()
+
+	stream = &readSplitsServerWithContext{Connector_ReadSplitsServer: stream, ctx: ctx}
+
+	request, split, maskers, err := dsc.applyDataPolicy(logger, state, queryID, request, split)
+	if err != nil {
+		if cancelQueryErr := dsc.observationStorage.CancelIncomingQuery(queryID, err.Error(), nil); cancelQueryErr != nil {
+			logger.Error("observation storage cancel query", zap.Error(cancelQueryErr))
+		}
+
+		return fmt.Errorf("apply data policy: %w", err)
+	}
+
 	switch kind {
 	case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
 		api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
 		api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM,
 		api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_LOGGING:
-		ds, err := dsc.rdbms.Make(logger, kind)
+		ds, err := state.rdbms.Make(logger, kind)
 		if err != nil {
 			return fmt.Errorf("make data source: %w", err)
 		}
 
 		return doReadSplit[any](
-			logger, queryID, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, queryID, stream, request, split, ds, dsc.memoryAccountant, state.readLimiterFactory, ticket, dsc.pagingMetrics,
+			dsc.observationStorage, dsc.splitCache, maskers, state.cfg)
 	case api_common.EGenericDataSourceKind_S3:
 		ds := s3.NewDataSource()
 
 		return doReadSplit[string](
-			logger, queryID, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, queryID, stream, request, split, ds, dsc.memoryAccountant, state.readLimiterFactory, ticket, dsc.pagingMetrics,
+			dsc.observationStorage, dsc.splitCache, maskers, state.cfg)
 	case api_common.EGenericDataSourceKind_MONGO_DB:
-		mongoDbCfg := dsc.cfg.Datasources.Mongodb
+		mongoDbCfg := state.cfg.Datasources.Mongodb
 		ds := mongodb.NewDataSource(
 			&retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -217,10 +390,11 @@ func (dsc *DataSourceCollection) ReadSplit(
 		)
 
 		return doReadSplit(
-			logger, queryID, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, queryID, stream, request, split, ds, dsc.memoryAccountant, state.readLimiterFactory, ticket, dsc.pagingMetrics,
+			dsc.observationStorage, dsc.splitCache, maskers, state.cfg)
 
 	case api_common.EGenericDataSourceKind_REDIS:
-		redisCfg := dsc.cfg.Datasources.Redis
+		redisCfg := state.cfg.Datasources.Redis
 		ds := redis.NewDataSource(
 			&retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -231,9 +405,10 @@ func (dsc *DataSourceCollection) ReadSplit(
 		)
 
 		return doReadSplit(
-			logger, queryID, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, queryID, stream, request, split, ds, dsc.memoryAccountant, state.readLimiterFactory, ticket, dsc.pagingMetrics,
+			dsc.observationStorage, dsc.splitCache, maskers, state.cfg)
 	case api_common.EGenericDataSourceKind_OPENSEARCH:
-		openSearchCfg := dsc.cfg.Datasources.Opensearch
+		openSearchCfg := state.cfg.Datasources.Opensearch
 		ds := opensearch.NewDataSource(
 			&retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
@@ -243,13 +418,73 @@ func (dsc *DataSourceCollection) ReadSplit(
 		)
 
 		return doReadSplit(
-			logger, queryID, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+			logger, queryID, stream, request, split, ds, dsc.memoryAccountant, state.readLimiterFactory, ticket, dsc.pagingMetrics,
+			dsc.observationStorage, dsc.splitCache, maskers, state.cfg)
 
 	default:
 		return fmt.Errorf("unsupported data source type '%v': %w", kind, common.ErrDataSourceNotSupported)
 	}
 }
 
+// applyDataPolicy restricts the split according to the data policy and records the decisions
+// made for the incoming query. Returns the request and the split that should be actually executed.
+func (dsc *DataSourceCollection) applyDataPolicy(
+	logger *zap.Logger,
+	state *dataSourceCollectionState,
+	queryID observation.IncomingQueryID,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+) (*api_service_protos.TReadSplitsRequest, *api_service_protos.TSplit, []paging.ColumnMasker, error) {
+	restrictions, err := state.policy.ApplyToSelect(split.GetSelect(), request.GetFiltering())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(restrictions.Decisions) == 0 {
+		return request, split, nil, nil
+	}
+
+	logger.Info("data policy applied", zap.String("decisions", restrictions.Decisions.String()))
+
+	if err := dsc.observationStorage.SaveIncomingQueryPolicy(queryID, restrictions.Decisions.String()); err != nil {
+		return nil, nil, nil, fmt.Errorf("observation storage save policy: %w", err)
+	}
+
+	split = proto.Clone(split).(*api_service_protos.TSplit)
+	split.Select = restrictions.Select
+
+	// Row filters must never be skipped during pushdown
+	if restrictions.MandatoryFiltering {
+		request = proto.Clone(request).(*api_service_protos.TReadSplitsRequest)
+		request.Filtering = api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY
+	}
+
+	return request, split, restrictions.Maskers, nil
+}
+
+// listSplitsRecorder keeps the responses sent to the client, so that they could be cached
+type listSplitsRecorder struct {
+	api_service.Connector_ListSplitsServer
+	responses []*api_service_protos.TListSplitsResponse
+}
+
+func (r *listSplitsRecorder) Send(response *api_service_protos.TListSplitsResponse) error {
+	r.responses = append(r.responses, response)
+
+	return r.Connector_ListSplitsServer.Send(response)
+}
+
+// readSplitsServerWithContext substitutes the stream context,
+// so that the query could be canceled independently of the client
+type readSplitsServerWithContext struct {
+	api_service.Connector_ReadSplitsServer
+	ctx context.Context
+}
+
+func (s *readSplitsServerWithContext) Context() context.Context {
+	return s.ctx
+}
+
 //nolint:revive
 func doReadSplit[T paging.Acceptor](
 	logger *zap.Logger,
@@ -258,29 +493,46 @@ func doReadSplit[T paging.Acceptor](
 	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
 	dataSource datasource.DataSource[T],
-	memoryAllocator memory.Allocator,
+	memoryAccountant paging.MemoryAccountant,
 	readLimiterFactory *paging.ReadLimiterFactory,
+	throttler paging.Throttler,
+	pagingMetrics *paging.Metrics,
 	observationStorage observation.Storage,
+	splitCache splitcache.Store,
+	maskers []paging.ColumnMasker,
 	cfg *config.TServerConfig,
 ) error {
 	logger.Debug("split reading started", common.SelectToFields(split.Select)...)
 
-	columnarBufferFactory, err := paging.NewColumnarBufferFactory[T](
-		logger,
-		memoryAllocator,
-		request.Format,
-		split.Select.What)
+	compression, err := paging.NegotiateArrowCompression(cfg.Paging, utils.ArrowCompressionFromContext(stream.Context()))
 	if err != nil {
-		return fmt.Errorf("new columnar buffer factory: %w", err)
+		return fmt.Errorf("negotiate Arrow compression: %w", err)
 	}
 
-	sinkFactory := paging.NewSinkFactory[T](
-		stream.Context(),
-		logger,
-		cfg.Paging,
-		columnarBufferFactory,
-		readLimiterFactory.MakeReadLimiter(logger),
-	)
+	cachedPages, recorder, err := lookupSplitCache(logger, stream, splitCache, request, split, compression, maskers, cfg)
+	if err != nil {
+		return fmt.Errorf("lookup split cache: %w", err)
+	}
+
+	var sinkFactory paging.SinkFactory[T]
+
+	if cachedPages != nil {
+		defer common.LogCloserError(logger, cachedPages, "close cached pages")
+
+		logger.Info("split is read from cache")
+
+		dataSource, sinkFactory = splitcache.NewReplay[T](logger, cfg.Paging, pagingMetrics, cachedPages)
+	} else {
+		sinkFactory, err = makeSinkFactory[T](
+			logger, stream, request, split, memoryAccountant, readLimiterFactory, throttler, pagingMetrics, compression, maskers, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if recorder != nil {
+		stream = recorder
+	}
 
 	streamer := streaming.NewReadSplitsStreamer(
 		logger,
@@ -293,7 +545,10 @@ func doReadSplit[T paging.Acceptor](
 	)
 
 	// Run streaming reading
-	if err = streamer.Run(); err != nil {
+	err = streamer.Run()
+	recorder.finish(err == nil)
+
+	if err != nil {
 		// Register query error
 		cancelQueryErr := observationStorage.CancelIncomingQuery(queryID, err.Error(), sinkFactory.FinalStats())
 		if cancelQueryErr != nil {
@@ -322,29 +577,265 @@ func doReadSplit[T paging.Acceptor](
 	return nil
 }
 
+//nolint:revive
+func makeSinkFactory[T paging.Acceptor](
+	logger *zap.Logger,
+	stream api_service.Connector_ReadSplitsServer,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+	memoryAccountant paging.MemoryAccountant,
+	readLimiterFactory *paging.ReadLimiterFactory,
+	throttler paging.Throttler,
+	pagingMetrics *paging.Metrics,
+	compression config.TPagingConfig_EArrowCompression,
+	maskers []paging.ColumnMasker,
+	cfg *config.TServerConfig,
+) (paging.SinkFactory[T], error) {
+	columnarBufferFactory, err := paging.NewColumnarBufferFactory[T](
+		logger,
+		memoryAccountant.Allocator(),
+		request.Format,
+		compression,
+		split.Select.What,
+		maskers)
+	if err != nil {
+		return nil, fmt.Errorf("new columnar buffer factory: %w", err)
+	}
+
+	return paging.NewSinkFactory[T](
+		stream.Context(),
+		logger,
+		cfg.Paging,
+		columnarBufferFactory,
+		readLimiterFactory.MakeReadLimiter(logger),
+		throttler,
+		pagingMetrics,
+		memoryAccountant,
+	), nil
+}
+
+// lookupSplitCache returns either the cached pages of the split, or the recorder saving the pages
+// sent to the client into the cache. Both are nil if the split can't be cached.
+//
+//nolint:revive
+func lookupSplitCache(
+	logger *zap.Logger,
+	stream api_service.Connector_ReadSplitsServer,
+	splitCache splitcache.Store,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+	compression config.TPagingConfig_EArrowCompression,
+	maskers []paging.ColumnMasker,
+	cfg *config.TServerConfig,
+) (*splitcache.Reader, *readSplitsRecorder, error) {
+	// the masked values depend on the data policy, so such splits are never cached
+	if request.Format != api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING || maskers != nil {
+		return nil, nil, nil
+	}
+
+	key, err := splitcache.MakeKey(split, request, compression, cfg.ReadLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("make key: %w", err)
+	}
+
+	reader, err := splitCache.Open(key)
+	if err != nil {
+		// it's not a reason to fail the request
+		logger.Error("open cached pages", zap.Error(err))
+	}
+
+	if reader != nil {
+		return reader, nil, nil
+	}
+
+	writer, err := splitCache.Create(key, split.GetSelect().GetDataSourceInstance().GetKind())
+	if err != nil {
+		logger.Error("create cached pages", zap.Error(err))
+	}
+
+	if writer == nil {
+		return nil, nil, nil
+	}
+
+	return nil, &readSplitsRecorder{Connector_ReadSplitsServer: stream, writer: writer, logger: logger}, nil
+}
+
+// readSplitsRecorder saves the pages sent to the client into the split cache
+type readSplitsRecorder struct {
+	api_service.Connector_ReadSplitsServer
+	writer *splitcache.Writer // nil if the pages can't be cached anymore
+	logger *zap.Logger
+}
+
+func (r *readSplitsRecorder) Send(response *api_service_protos.TReadSplitsResponse) error {
+	if r.writer != nil {
+		if err := r.writer.WritePage(response); err != nil {
+			r.logger.Warn("split won't be cached", zap.Error(err))
+			r.writer.Abort()
+			r.writer = nil
+		}
+	}
+
+	return r.Connector_ReadSplitsServer.Send(response)
+}
+
+// finish makes the pages available to the other requests if the split was read completely
+func (r *readSplitsRecorder) finish(success bool) {
+	if r == nil || r.writer == nil {
+		return
+	}
+
+	if !success {
+		r.writer.Abort()
+
+		return
+	}
+
+	if err := r.writer.Commit(); err != nil {
+		r.logger.Error("commit cached pages", zap.Error(err))
+	}
+}
+
+// CheckReadiness returns the readiness status of every supported data source kind
+func (dsc *DataSourceCollection) CheckReadiness(ctx context.Context) map[api_common.EGenericDataSourceKind]error {
+	state := dsc.acquireState()
+	defer state.release(dsc.logger)
+
+	result := state.rdbms.CheckReadiness(ctx)
+
+	// these data sources have no dependencies inside the connector
+	for _, kind := range []api_common.EGenericDataSourceKind{
+		api_common.EGenericDataSourceKind_S3,
+		api_common.EGenericDataSourceKind_MONGO_DB,
+		api_common.EGenericDataSourceKind_REDIS,
+		api_common.EGenericDataSourceKind_OPENSEARCH,
+	} {
+		result[kind] = nil
+	}
+
+	return result
+}
+
 func (dsc *DataSourceCollection) Close() error {
-	return dsc.rdbms.Close()
+	dsc.stateMutex.Lock()
+	defer dsc.stateMutex.Unlock()
+
+	return dsc.state.retire()
+}
+
+// Reload applies the reloadable sections of the config to the new requests,
+// the requests that are already running keep working with the previous config.
+func (dsc *DataSourceCollection) Reload(cfg *config.TServerConfig) error {
+	state, err := dsc.makeState(cfg, paging.NewReadLimiterFactory(cfg.ReadLimit))
+	if err != nil {
+		return fmt.Errorf("make state: %w", err)
+	}
+
+	dsc.stateMutex.Lock()
+	previous := dsc.state
+	dsc.state = state
+	dsc.stateMutex.Unlock()
+
+	if err := previous.retire(); err != nil {
+		dsc.logger.Error("close outdated data source factory", zap.Error(err))
+	}
+
+	// data source settings affect both schemas and splits
+	dropped := dsc.metadataCache.Invalidate(api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED, "")
+	dsc.logger.Info("metadata cache invalidated", zap.Int("entries", dropped))
+
+	return nil
+}
+
+// acquireState returns the actual state, it must be released when the request is finished
+func (dsc *DataSourceCollection) acquireState() *dataSourceCollectionState {
+	dsc.stateMutex.Lock()
+	defer dsc.stateMutex.Unlock()
+
+	dsc.state.acquire()
+
+	return dsc.state
+}
+
+func (dsc *DataSourceCollection) makeState(
+	cfg *config.TServerConfig,
+	readLimiterFactory *paging.ReadLimiterFactory,
+) (*dataSourceCollectionState, error) {
+	policyEnforcer, err := policy.NewEnforcer(cfg.DataPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("new data policy enforcer: %w", err)
+	}
+
+	rdbmsFactory, err := rdbms.NewDataSourceFactory(
+		dsc.logger,
+		cfg.Datasources,
+		dsc.queryLoggerFactory,
+		dsc.converterCollection,
+		dsc.observationStorage,
+		dsc.queryRegistry,
+		dsc.secretResolver,
+		policyEnforcer,
+		dsc.memoryAccountant.Allocator(),
+		dsc.registry,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("new rdbms data source factory: %w", err)
+	}
+
+	return &dataSourceCollectionState{
+		rdbms:              rdbmsFactory,
+		readLimiterFactory: readLimiterFactory,
+		policy:             policyEnforcer,
+		cfg:                cfg,
+	}, nil
 }
 
 func NewDataSourceCollection(
+	logger *zap.Logger,
 	queryLoggerFactory common.QueryLoggerFactory,
 	memoryAllocator memory.Allocator,
 	readLimiterFactory *paging.ReadLimiterFactory,
 	converterCollection conversion.Collection,
 	observationStorage observation.Storage,
+	queryRegistry *observation.QueryRegistry,
+	metadataCache *cache.MetadataCache,
+	registry metrics.Registry,
 	cfg *config.TServerConfig,
 ) (*DataSourceCollection, error) {
-	rdbmsFactory, err := rdbms.NewDataSourceFactory(cfg.Datasources, queryLoggerFactory, converterCollection, observationStorage)
+	secretResolver, err := secrets.NewResolver(cfg.Secrets)
 	if err != nil {
-		return nil, fmt.Errorf("new rdbms data source factory: %w", err)
+		return nil, fmt.Errorf("new secret resolver: %w", err)
 	}
 
-	return &DataSourceCollection{
-		rdbms:               rdbmsFactory,
-		memoryAllocator:     memoryAllocator,
-		readLimiterFactory:  readLimiterFactory,
+	admissionController, err := admission.NewController(cfg.AdmissionControl, registry)
+	if err != nil {
+		return nil, fmt.Errorf("new admission controller: %w", err)
+	}
+
+	splitCache, err := splitcache.NewStore(logger, cfg.SplitCache, registry.WithPrefix("split_cache"))
+	if err != nil {
+		return nil, fmt.Errorf("new split cache: %w", err)
+	}
+
+	dsc := &DataSourceCollection{
+		memoryAccountant:    paging.NewMemoryAccountant(cfg.Paging, memoryAllocator, registry.WithPrefix("paging")),
+		queryLoggerFactory:  queryLoggerFactory,
 		converterCollection: converterCollection,
 		observationStorage:  observationStorage,
-		cfg:                 cfg,
-	}, nil
+		queryRegistry:       queryRegistry,
+		admissionController: admissionController,
+		pagingMetrics:       paging.NewMetrics(registry.WithPrefix("paging")),
+		secretResolver:      secretResolver,
+		metadataCache:       metadataCache,
+		splitCache:          splitCache,
+		registry:            registry,
+		logger:              logger,
+	}
+
+	dsc.state, err = dsc.makeState(cfg, readLimiterFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	return dsc, nil
 }
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/policy"
+)
+
+// dataSourceCollectionState keeps the objects built from the reloadable sections of the config.
+// Every request works with the state that was actual at the moment of the request start.
+// When the config is reloaded, the previous state is closed as soon as its last request is finished.
+type dataSourceCollectionState struct {
+	rdbms              datasource.Factory[any]
+	readLimiterFactory *paging.ReadLimiterFactory
+	policy             policy.Enforcer
+	cfg                *config.TServerConfig
+
+	mutex   sync.Mutex
+	users   int  // the number of requests using this state
+	retired bool // set when the state is replaced with the new one
+}
+
+func (s *dataSourceCollectionState) acquire() {
+	s.mutex.Lock()
+	s.users++
+	s.mutex.Unlock()
+}
+
+func (s *dataSourceCollectionState) release(logger *zap.Logger) {
+	s.mutex.Lock()
+	s.users--
+	mustClose := s.retired && s.users == 0
+	s.mutex.Unlock()
+
+	if mustClose {
+		s.close(logger)
+	}
+}
+
+// retire marks the state as outdated, so that it's closed after the last user releases it
+func (s *dataSourceCollectionState) retire() error {
+	s.mutex.Lock()
+	s.retired = true
+	mustClose := s.users == 0
+	s.mutex.Unlock()
+
+	if mustClose {
+		return s.rdbms.Close()
+	}
+
+	return nil
+}
+
+func (s *dataSourceCollectionState) close(logger *zap.Logger) {
+	if err := s.rdbms.Close(); err != nil {
+		logger.Error("close outdated data source factory", zap.Error(err))
+	}
+}
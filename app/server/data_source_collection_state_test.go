@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/cache"
+	app_server_config "github.com/ydb-platform/fq-connector-go/app/server/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/observation"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+var _ datasource.Factory[any] = (*dataSourceFactoryMock)(nil)
+
+type dataSourceFactoryMock struct {
+	closed int
+}
+
+func (*dataSourceFactoryMock) Make(*zap.Logger, api_common.EGenericDataSourceKind) (datasource.DataSource[any], error) {
+	return nil, common.ErrDataSourceNotSupported
+}
+
+func (*dataSourceFactoryMock) CheckReadiness(context.Context) map[api_common.EGenericDataSourceKind]error {
+	return map[api_common.EGenericDataSourceKind]error{}
+}
+
+func (m *dataSourceFactoryMock) Close() error {
+	m.closed++
+	return nil
+}
+
+func TestDataSourceCollectionState(t *testing.T) {
+	t.Run("closed when retired without users", func(t *testing.T) {
+		factory := &dataSourceFactoryMock{}
+		state := &dataSourceCollectionState{rdbms: factory}
+
+		require.NoError(t, state.retire())
+		require.Equal(t, 1, factory.closed)
+	})
+
+	t.Run("closed when the last user leaves", func(t *testing.T) {
+		factory := &dataSourceFactoryMock{}
+		state := &dataSourceCollectionState{rdbms: factory}
+
+		state.acquire()
+		state.acquire()
+
+		require.NoError(t, state.retire())
+		require.Zero(t, factory.closed)
+
+		state.release(zap.NewNop())
+		require.Zero(t, factory.closed)
+
+		state.release(zap.NewNop())
+		require.Equal(t, 1, factory.closed)
+	})
+
+	t.Run("not closed while actual", func(t *testing.T) {
+		factory := &dataSourceFactoryMock{}
+		state := &dataSourceCollectionState{rdbms: factory}
+
+		state.acquire()
+		state.release(zap.NewNop())
+		require.Zero(t, factory.closed)
+	})
+}
+
+func TestDataSourceCollectionReload(t *testing.T) {
+	cfg := app_server_config.NewDefaultConfig()
+	logger := zap.NewNop()
+
+	observationStorage, err := observation.NewStorage(logger, nil)
+	require.NoError(t, err)
+
+	metadataCache, err := cache.NewMetadataCache(cfg.MetadataCache, nop.Registry{})
+	require.NoError(t, err)
+
+	dsc, err := NewDataSourceCollection(
+		logger,
+		common.NewQueryLoggerFactory(cfg.Logger),
+		memory.NewGoAllocator(),
+		paging.NewReadLimiterFactory(cfg.ReadLimit),
+		conversion.NewCollection(cfg.Conversion),
+		observationStorage,
+		observation.NewQueryRegistry(),
+		metadataCache,
+		nop.Registry{},
+		cfg,
+	)
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, dsc.Close()) }()
+
+	// the request started before reload
+	previous := dsc.acquireState()
+
+	nextCfg := proto.Clone(cfg).(*config.TServerConfig)
+	nextCfg.Paging.RowsPerPage = 100
+	nextCfg.ReadLimit = &config.TServerReadLimit{Rows: 10}
+
+	require.NoError(t, dsc.Reload(nextCfg))
+
+	next := dsc.acquireState()
+	require.NotSame(t, previous, next)
+	require.Equal(t, uint64(100), next.cfg.Paging.RowsPerPage)
+	require.Equal(t, uint64(0), previous.cfg.Paging.RowsPerPage)
+
+	previous.release(logger)
+	next.release(logger)
+}
@@ -19,6 +19,9 @@ type Factory[T paging.Acceptor] interface {
 		logger *zap.Logger,
 		dataSourceType api_common.EGenericDataSourceKind,
 	) (DataSource[T], error)
+	// CheckReadiness returns the readiness status of every supported data source kind.
+	// Non-nil error means that the requests to the data source will most likely fail.
+	CheckReadiness(ctx context.Context) map[api_common.EGenericDataSourceKind]error
 	Close() error
 }
 
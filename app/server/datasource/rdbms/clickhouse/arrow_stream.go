@@ -0,0 +1,424 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// ClickHouse settings making the Arrow types of the output columns predictable
+// https://clickhouse.com/docs/en/interfaces/formats#arrow-format-settings
+var arrowStreamSettings = map[string]string{
+	"output_format_arrow_string_as_string":                 "0",
+	"output_format_arrow_fixed_string_as_fixed_byte_array": "1",
+	"output_format_arrow_low_cardinality_as_dictionary":    "0",
+}
+
+// arrowStreamClient runs queries via ClickHouse HTTP interface obtaining the results in ArrowStream format.
+// Unlike the database/sql driver, it doesn't decode the rows, so the record batches are passed to the sink as is.
+type arrowStreamClient struct {
+	httpClient *http.Client
+	url        string
+	username   string
+	password   string
+	allocator  memory.Allocator
+}
+
+func (c *arrowStreamClient) query(ctx context.Context, queryText string, ydbTypes []*Ydb.Type) (*arrowStreamRows, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, strings.NewReader(queryText+" FORMAT ArrowStream"))
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	request.Header.Set("X-ClickHouse-User", c.username)
+	request.Header.Set("X-ClickHouse-Key", c.password)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+
+		const maxMessageSize = 4096
+
+		message, _ := io.ReadAll(io.LimitReader(response.Body, maxMessageSize))
+
+		return nil, fmt.Errorf("unexpected status '%s': %s", response.Status, strings.TrimSpace(string(message)))
+	}
+
+	rows := &arrowStreamRows{body: response.Body, ydbTypes: ydbTypes, allocator: c.allocator}
+
+	rows.reader, err = ipc.NewReader(response.Body, ipc.WithAllocator(c.allocator))
+
+	switch {
+	case errors.Is(err, io.EOF):
+		// the result is empty
+		return rows, nil
+	case err != nil:
+		response.Body.Close()
+
+		return nil, fmt.Errorf("new arrow reader: %w", err)
+	}
+
+	if actual := len(rows.reader.Schema().Fields()); actual != len(ydbTypes) {
+		rows.Close()
+
+		return nil, fmt.Errorf("expected %d columns, got %d: %w", len(ydbTypes), actual, common.ErrInvariantViolation)
+	}
+
+	return rows, nil
+}
+
+func newArrowStreamClient(
+	httpClient *http.Client,
+	dsi *api_common.TGenericDataSourceInstance,
+	allocator memory.Allocator,
+) *arrowStreamClient {
+	scheme := "http"
+	if dsi.UseTls {
+		scheme = "https"
+	}
+
+	params := url.Values{}
+	params.Set("database", dsi.Database)
+
+	for name, value := range arrowStreamSettings {
+		params.Set(name, value)
+	}
+
+	endpoint := url.URL{
+		Scheme:   scheme,
+		Host:     common.EndpointToString(dsi.GetEndpoint()),
+		Path:     "/",
+		RawQuery: params.Encode(),
+	}
+
+	return &arrowStreamClient{
+		httpClient: httpClient,
+		url:        endpoint.String(),
+		username:   dsi.Credentials.GetBasic().Username,
+		password:   dsi.Credentials.GetBasic().Password,
+		allocator:  allocator,
+	}
+}
+
+func newArrowStreamHTTPClient(cfg *config.TClickHouseConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: common.MustDurationFromString(cfg.OpenConnectionTimeout)}).DialContext
+
+	return &http.Client{Transport: transport}
+}
+
+var _ rdbms_utils.ArrowRows = (*arrowStreamRows)(nil)
+
+type arrowStreamRows struct {
+	body      io.ReadCloser
+	reader    *ipc.Reader // nil if the result is empty
+	ydbTypes  []*Ydb.Type
+	allocator memory.Allocator
+	record    arrow.Record // the current batch with the columns converted to the YDB-mapped types
+	err       error
+}
+
+func (r *arrowStreamRows) Next() bool {
+	r.releaseRecord()
+
+	if r.reader == nil || r.err != nil || !r.reader.Next() {
+		return false
+	}
+
+	r.record, r.err = castRecord(r.reader.Record(), r.ydbTypes, r.allocator)
+
+	return r.err == nil
+}
+
+func (r *arrowStreamRows) Record() arrow.Record { return r.record }
+
+func (r *arrowStreamRows) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if r.reader != nil {
+		return r.reader.Err()
+	}
+
+	return nil
+}
+
+func (r *arrowStreamRows) Close() error {
+	r.releaseRecord()
+
+	if r.reader != nil {
+		r.reader.Release()
+	}
+
+	return r.body.Close()
+}
+
+func (r *arrowStreamRows) releaseRecord() {
+	if r.record != nil {
+		r.record.Release()
+		r.record = nil
+	}
+}
+
+// arrowStreamType returns the Arrow type of the column in the pages sent to the client.
+// Returns false if the values of this type need a conversion that can be done only row by row
+// (e. g. dates and timestamps depend on the requested date time format).
+func arrowStreamType(ydbType *Ydb.Type) (arrow.DataType, bool) {
+	if optional := ydbType.GetOptionalType(); optional != nil {
+		ydbType = optional.GetItem()
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_BOOL:
+		return arrow.PrimitiveTypes.Uint8, true
+	case Ydb.Type_INT8:
+		return arrow.PrimitiveTypes.Int8, true
+	case Ydb.Type_UINT8:
+		return arrow.PrimitiveTypes.Uint8, true
+	case Ydb.Type_INT16:
+		return arrow.PrimitiveTypes.Int16, true
+	case Ydb.Type_UINT16:
+		return arrow.PrimitiveTypes.Uint16, true
+	case Ydb.Type_INT32:
+		return arrow.PrimitiveTypes.Int32, true
+	case Ydb.Type_UINT32:
+		return arrow.PrimitiveTypes.Uint32, true
+	case Ydb.Type_INT64:
+		return arrow.PrimitiveTypes.Int64, true
+	case Ydb.Type_UINT64:
+		return arrow.PrimitiveTypes.Uint64, true
+	case Ydb.Type_FLOAT:
+		return arrow.PrimitiveTypes.Float32, true
+	case Ydb.Type_DOUBLE:
+		return arrow.PrimitiveTypes.Float64, true
+	case Ydb.Type_STRING:
+		return arrow.BinaryTypes.Binary, true
+	default:
+		return nil, false
+	}
+}
+
+// castRecord converts the columns returned by ClickHouse to the YDB-mapped Arrow types.
+// The columns of the same physical layout share the memory with the original ones.
+func castRecord(record arrow.Record, ydbTypes []*Ydb.Type, allocator memory.Allocator) (arrow.Record, error) {
+	fields := make([]arrow.Field, 0, len(ydbTypes))
+	columns := make([]arrow.Array, 0, len(ydbTypes))
+
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+
+	for i, ydbType := range ydbTypes {
+		target, ok := arrowStreamType(ydbType)
+		if !ok {
+			return nil, fmt.Errorf("column #%d of type %v: %w", i, ydbType, common.ErrDataTypeNotSupported)
+		}
+
+		column, err := castColumn(record.Column(i), target, allocator)
+		if err != nil {
+			return nil, fmt.Errorf("cast column #%d: %w", i, err)
+		}
+
+		field := record.Schema().Field(i)
+		fields = append(fields, arrow.Field{Name: field.Name, Type: target, Nullable: field.Nullable})
+		columns = append(columns, column)
+	}
+
+	return array.NewRecord(arrow.NewSchema(fields, nil), columns, record.NumRows()), nil
+}
+
+//nolint:gocyclo
+func castColumn(column arrow.Array, target arrow.DataType, allocator memory.Allocator) (arrow.Array, error) {
+	source := column.DataType()
+
+	switch {
+	case arrow.TypeEqual(source, target):
+		column.Retain()
+
+		return column, nil
+	case source.ID() == arrow.BOOL && target.ID() == arrow.UINT8:
+		values := column.(*array.Boolean)
+
+		builder := array.NewUint8Builder(allocator)
+		defer builder.Release()
+
+		builder.Reserve(values.Len())
+
+		for i := 0; i < values.Len(); i++ {
+			switch {
+			case values.IsNull(i):
+				builder.AppendNull()
+			case values.Value(i):
+				builder.Append(1)
+			default:
+				builder.Append(0)
+			}
+		}
+
+		return builder.NewArray(), nil
+	case (source.ID() == arrow.STRING || source.ID() == arrow.BINARY) && target.ID() == arrow.BINARY:
+		// the layouts are the same, so the buffers are reused
+		data := column.Data()
+		cast := array.NewData(target, data.Len(), data.Buffers(), nil, data.NullN(), data.Offset())
+
+		defer cast.Release()
+
+		return array.MakeFromData(cast), nil
+	case source.ID() == arrow.FIXED_SIZE_BINARY && target.ID() == arrow.BINARY:
+		values := column.(*array.FixedSizeBinary)
+
+		builder := array.NewBinaryBuilder(allocator, arrow.BinaryTypes.Binary)
+		defer builder.Release()
+
+		builder.Reserve(values.Len())
+
+		for i := 0; i < values.Len(); i++ {
+			if values.IsNull(i) {
+				builder.AppendNull()
+			} else {
+				builder.Append(values.Value(i))
+			}
+		}
+
+		return builder.NewArray(), nil
+	default:
+		return nil, fmt.Errorf("cast %v to %v: %w", source, target, common.ErrDataTypeNotSupported)
+	}
+}
+
+// bindQueryArgs substitutes the placeholders with the literals, because ClickHouse HTTP interface
+// doesn't support positional parameters. Returns an error if the argument can't be represented as a literal.
+func bindQueryArgs(queryText string, args []any) (string, error) {
+	var (
+		result strings.Builder
+		quote  rune // the quote of the literal or identifier being scanned, zero outside of them
+		escape bool
+		argIx  int
+	)
+
+	for _, c := range queryText {
+		switch {
+		case quote != 0:
+			switch {
+			case escape:
+				escape = false
+			case c == '\\':
+				escape = true
+			case c == quote:
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '?':
+			if argIx == len(args) {
+				return "", fmt.Errorf("not enough arguments for %d placeholder(s): %w", argIx+1, common.ErrInvariantViolation)
+			}
+
+			literal, err := formatLiteral(args[argIx])
+			if err != nil {
+				return "", fmt.Errorf("format argument #%d: %w", argIx, err)
+			}
+
+			result.WriteString(literal)
+
+			argIx++
+
+			continue
+		}
+
+		result.WriteRune(c)
+	}
+
+	if argIx != len(args) {
+		return "", fmt.Errorf("expected %d arguments, got %d: %w", argIx, len(args), common.ErrInvariantViolation)
+	}
+
+	return result.String(), nil
+}
+
+func formatLiteral(arg any) (string, error) {
+	switch v := arg.(type) {
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return formatFloat(float64(v), 32)
+	case float64:
+		return formatFloat(v, 64)
+	case string:
+		return formatStringLiteral([]byte(v)), nil
+	case []byte:
+		return formatStringLiteral(v), nil
+	default:
+		return "", fmt.Errorf("argument of type %T: %w", arg, common.ErrDataTypeNotSupported)
+	}
+}
+
+func formatFloat(v float64, bitSize int) (string, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", fmt.Errorf("value %v: %w", v, common.ErrDataTypeNotSupported)
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, bitSize), nil
+}
+
+func formatStringLiteral(value []byte) string {
+	const hexDigits = "0123456789abcdef"
+
+	var result strings.Builder
+
+	result.Grow(len(value) + 2)
+	result.WriteByte('\'')
+
+	for _, b := range value {
+		switch {
+		case b == '\'' || b == '\\':
+			result.WriteByte('\\')
+			result.WriteByte(b)
+		case b < 0x20 || b >= 0x7f:
+			// the bytes are passed as is, no matter if they form the valid UTF-8
+			result.WriteString(`\x`)
+			result.WriteByte(hexDigits[b>>4])
+			result.WriteByte(hexDigits[b&0x0f])
+		default:
+			result.WriteByte(b)
+		}
+	}
+
+	result.WriteByte('\'')
+
+	return result.String()
+}
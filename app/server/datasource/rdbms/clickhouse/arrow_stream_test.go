@@ -0,0 +1,202 @@
+package clickhouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestBindQueryArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		args     []any
+		expected string
+		err      error
+	}{
+		{
+			name:     "no args",
+			query:    "SELECT `col` FROM `tab`",
+			expected: "SELECT `col` FROM `tab`",
+		},
+		{
+			name:     "numbers",
+			query:    "SELECT `col` FROM `tab` WHERE (`a` = ?) AND (`b` > ?) AND (`c` < ?)",
+			args:     []any{int32(-1), uint64(2), 0.5},
+			expected: "SELECT `col` FROM `tab` WHERE (`a` = -1) AND (`b` > 2) AND (`c` < 0.5)",
+		},
+		{
+			name:     "strings",
+			query:    "SELECT `col?` FROM `tab` WHERE (`a` = ?) OR (`b` = ?)",
+			args:     []any{"it's \\", []byte{'a', 0x00, 0xff}},
+			expected: "SELECT `col?` FROM `tab` WHERE (`a` = 'it\\'s \\\\') OR (`b` = 'a\\x00\\xff')",
+		},
+		{
+			name:  "unsupported type",
+			query: "SELECT `col` FROM `tab` WHERE `a` = ?",
+			args:  []any{struct{}{}},
+			err:   common.ErrDataTypeNotSupported,
+		},
+		{
+			name:  "too many args",
+			query: "SELECT `col` FROM `tab` WHERE `a` = ?",
+			args:  []any{int32(1), int32(2)},
+			err:   common.ErrInvariantViolation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := bindQueryArgs(tc.query, tc.args)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+// makeArrowStream builds the record with the types that ClickHouse uses in ArrowStream output
+func makeArrowStream(t *testing.T, allocator memory.Allocator) []byte {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "flag", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "code", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}},
+	}, nil)
+
+	builder := array.NewRecordBuilder(allocator, schema)
+	defer builder.Release()
+
+	builder.Field(0).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+	builder.Field(1).(*array.Int32Builder).AppendValues([]int32{1, 0}, []bool{true, false})
+	builder.Field(2).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	builder.Field(3).(*array.FixedSizeBinaryBuilder).AppendValues([][]byte{[]byte("xx"), []byte("yy")}, nil)
+
+	record := builder.NewRecord()
+	defer record.Release()
+
+	var buf strings.Builder
+
+	writer := ipc.NewWriter(&buf, ipc.WithSchema(schema), ipc.WithAllocator(allocator))
+	require.NoError(t, writer.Write(record))
+	require.NoError(t, writer.Close())
+
+	return []byte(buf.String())
+}
+
+func TestArrowStreamClient(t *testing.T) {
+	allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer allocator.AssertSize(t, 0)
+
+	stream := makeArrowStream(t, allocator)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		switch {
+		case r.Header.Get("X-ClickHouse-Key") != "password":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Code: 516. Authentication failed"))
+		case strings.Contains(string(body), "empty"):
+		default:
+			require.Equal(t, "SELECT * FROM `tab` FORMAT ArrowStream", string(body))
+			require.Equal(t, "db", r.URL.Query().Get("database"))
+			require.Equal(t, "0", r.URL.Query().Get("output_format_arrow_string_as_string"))
+			_, _ = w.Write(stream)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	port, err := strconv.ParseUint(serverURL.Port(), 10, 32)
+	require.NoError(t, err)
+
+	client := newArrowStreamClient(server.Client(), &api_common.TGenericDataSourceInstance{
+		Endpoint: &api_common.TGenericEndpoint{Host: serverURL.Hostname(), Port: uint32(port)},
+		Database: "db",
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Basic{
+				Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: "password"},
+			},
+		},
+	}, allocator)
+
+	ydbTypes := []*Ydb.Type{
+		common.MakePrimitiveType(Ydb.Type_BOOL),
+		common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+		common.MakePrimitiveType(Ydb.Type_STRING),
+		common.MakePrimitiveType(Ydb.Type_STRING),
+	}
+
+	t.Run("records", func(t *testing.T) {
+		rows, err := client.query(context.Background(), "SELECT * FROM `tab`", ydbTypes)
+		require.NoError(t, err)
+
+		require.True(t, rows.Next())
+
+		record := rows.Record()
+		require.Equal(t, int64(2), record.NumRows())
+		require.Equal(t, []uint8{1, 0}, record.Column(0).(*array.Uint8).Uint8Values())
+		require.True(t, record.Column(1).IsNull(1))
+		require.Equal(t, []byte("a"), record.Column(2).(*array.Binary).Value(0))
+		require.Equal(t, []byte("yy"), record.Column(3).(*array.Binary).Value(1))
+
+		require.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		rows, err := client.query(context.Background(), "SELECT * FROM `empty`", ydbTypes)
+		require.NoError(t, err)
+		require.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := *client
+		client.password = "wrong"
+
+		_, err := client.query(context.Background(), "SELECT * FROM `tab`", ydbTypes)
+		require.ErrorContains(t, err, "Authentication failed")
+	})
+
+	t.Run("unexpected columns", func(t *testing.T) {
+		_, err := client.query(context.Background(), "SELECT * FROM `tab`", ydbTypes[:1])
+		require.ErrorIs(t, err, common.ErrInvariantViolation)
+	})
+}
+
+func TestArrowStreamType(t *testing.T) {
+	_, ok := arrowStreamType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UINT64)))
+	require.True(t, ok)
+
+	// dates depend on the date time format requested by the client
+	_, ok = arrowStreamType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATE)))
+	require.False(t, ok)
+}
@@ -46,12 +46,15 @@ func (r *rows) MakeTransformer(ydbTypes []*Ydb.Type, cc conversion.Collection) (
 }
 
 var _ rdbms_utils.Connection = (*connectionHTTP)(nil)
+var _ rdbms_utils.QueryExplainer = (*connectionHTTP)(nil)
+var _ rdbms_utils.ArrowQuerier = (*connectionHTTP)(nil)
 
 type connectionHTTP struct {
-	*sql.DB
+	*rdbms_utils.PooledSQLDB
 	queryLogger        common.QueryLogger
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
+	arrowStream        *arrowStreamClient // nil if ArrowStream format is disabled
 }
 
 func (c *connectionHTTP) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, error) {
@@ -75,6 +78,54 @@ func (c *connectionHTTP) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Row
 	return &rows{Rows: out}, nil
 }
 
+func (c *connectionHTTP) CanQueryArrow(params *rdbms_utils.QueryParams, ydbTypes []*Ydb.Type) bool {
+	// the queries without columns (e. g. COUNT(*)) are not worth it
+	if c.arrowStream == nil || len(ydbTypes) == 0 {
+		return false
+	}
+
+	for _, ydbType := range ydbTypes {
+		if _, ok := arrowStreamType(ydbType); !ok {
+			return false
+		}
+	}
+
+	_, err := bindQueryArgs(params.QueryText, params.QueryArgs.Values())
+
+	return err == nil
+}
+
+func (c *connectionHTTP) QueryArrow(
+	params *rdbms_utils.QueryParams,
+	ydbTypes []*Ydb.Type,
+	_ conversion.Collection, // the values are cast column-wise
+) (rdbms_utils.ArrowRows, error) {
+	queryText, err := bindQueryArgs(params.QueryText, params.QueryArgs.Values())
+	if err != nil {
+		return nil, fmt.Errorf("bind query args: %w", err)
+	}
+
+	c.queryLogger.Dump(queryText)
+
+	out, err := c.arrowStream.query(params.Ctx, queryText, ydbTypes)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *connectionHTTP) Explain(params *rdbms_utils.QueryParams) (string, error) {
+	out, err := c.DB.QueryContext(params.Ctx, "EXPLAIN "+params.QueryText, params.QueryArgs.Values()...)
+	if err != nil {
+		return "", fmt.Errorf("query context: %w", err)
+	}
+
+	defer common.LogCloserError(c.queryLogger.Logger, out, "close rows")
+
+	return collectExplainOutput(out)
+}
+
 func (c *connectionHTTP) DataSourceInstance() *api_common.TGenericDataSourceInstance {
 	return c.dataSourceInstance
 }
@@ -87,14 +138,12 @@ func (c *connectionHTTP) Logger() *zap.Logger {
 	return c.queryLogger.Logger
 }
 
-func makeConnectionHTTP(
+func dialConnectionHTTP(
 	ctx context.Context,
 	logger *zap.Logger,
 	cfg *config.TClickHouseConfig,
 	dsi *api_common.TGenericDataSourceInstance,
-	tableName string,
-	queryLogger common.QueryLogger,
-) (rdbms_utils.Connection, error) {
+) (rdbms_utils.PooledConnection, error) {
 	opts := &clickhouse.Options{
 		Addr: []string{common.EndpointToString(dsi.GetEndpoint())},
 		Auth: clickhouse.Auth{
@@ -127,6 +176,7 @@ func makeConnectionHTTP(
 	defer pingCtxCancel()
 
 	if err := conn.PingContext(pingCtx); err != nil {
+		common.LogCloserError(logger, conn, "close connection")
 		return nil, fmt.Errorf("conn ping: %w", err)
 	}
 
@@ -140,10 +190,5 @@ func makeConnectionHTTP(
 	conn.SetMaxOpenConns(maxOpenConns)
 	conn.SetConnMaxLifetime(connMaxLifetime)
 
-	return &connectionHTTP{
-		DB:                 conn,
-		queryLogger:        queryLogger,
-		dataSourceInstance: dsi,
-		tableName:          tableName,
-	}, nil
+	return &rdbms_utils.PooledSQLDB{DB: conn}, nil
 }
@@ -3,7 +3,9 @@ package clickhouse
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"go.uber.org/zap"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
@@ -16,7 +18,8 @@ var _ rdbms_utils.ConnectionManager = (*connectionManager)(nil)
 
 type connectionManager struct {
 	rdbms_utils.ConnectionManagerBase
-	cfg *config.TClickHouseConfig
+	cfg        *config.TClickHouseConfig
+	httpClient *http.Client // used to obtain the data in ArrowStream format, nil if it's disabled
 }
 
 func (c *connectionManager) Make(
@@ -26,32 +29,69 @@ func (c *connectionManager) Make(
 		return nil, fmt.Errorf("currently only basic auth is supported")
 	}
 
-	var (
-		conn rdbms_utils.Connection
-		err  error
-	)
+	var dial rdbms_utils.DialFunc
 
 	switch params.DataSourceInstance.Protocol {
 	case api_common.EGenericProtocol_NATIVE:
-		conn, err = makeConnectionNative(
-			params.Ctx, params.Logger, c.cfg, params.DataSourceInstance, params.TableName, c.QueryLoggerFactory.Make(params.Logger))
+		dial = func(ctx context.Context) (rdbms_utils.PooledConnection, error) {
+			return dialConnectionNative(ctx, params.Logger, c.cfg, params.DataSourceInstance)
+		}
 	case api_common.EGenericProtocol_HTTP:
-		conn, err = makeConnectionHTTP(
-			params.Ctx, params.Logger, c.cfg, params.DataSourceInstance, params.TableName, c.QueryLoggerFactory.Make(params.Logger))
+		dial = func(ctx context.Context) (rdbms_utils.PooledConnection, error) {
+			return dialConnectionHTTP(ctx, params.Logger, c.cfg, params.DataSourceInstance)
+		}
 	default:
 		return nil, fmt.Errorf("can not run ClickHouse connection with protocol '%v'", params.DataSourceInstance.Protocol)
 	}
 
+	pooled, err := c.AcquirePooledConnection(params.Ctx, params.Logger, params.DataSourceInstance, dial)
 	if err != nil {
 		return nil, fmt.Errorf("make connection: %w", err)
 	}
 
+	var (
+		conn        rdbms_utils.Connection
+		queryLogger = c.QueryLoggerFactory.Make(params.Logger)
+	)
+
+	switch t := pooled.(type) {
+	case driver.Conn:
+		conn = &connectionNative{
+			Conn:               t,
+			queryLogger:        queryLogger,
+			dataSourceInstance: params.DataSourceInstance,
+			tableName:          params.TableName,
+		}
+	case *rdbms_utils.PooledSQLDB:
+		httpConn := &connectionHTTP{
+			PooledSQLDB:        t,
+			queryLogger:        queryLogger,
+			dataSourceInstance: params.DataSourceInstance,
+			tableName:          params.TableName,
+		}
+
+		if c.httpClient != nil {
+			httpConn.arrowStream = newArrowStreamClient(c.httpClient, params.DataSourceInstance, c.ArrowAllocator)
+		}
+
+		conn = httpConn
+	default:
+		return nil, fmt.Errorf("unexpected pooled connection type %T", pooled)
+	}
+
 	return []rdbms_utils.Connection{conn}, nil
 }
 
-func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
+func (c *connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
 	for _, conn := range cs {
-		common.LogCloserError(logger, conn, "close clickhouse connection")
+		switch t := conn.(type) {
+		case *connectionNative:
+			c.ReleasePooledConnection(logger, t.Conn)
+		case *connectionHTTP:
+			c.ReleasePooledConnection(logger, t.PooledSQLDB)
+		default:
+			common.LogCloserError(logger, conn, "close clickhouse connection")
+		}
 	}
 }
 
@@ -59,5 +99,11 @@ func NewConnectionManager(
 	cfg *config.TClickHouseConfig,
 	base rdbms_utils.ConnectionManagerBase,
 ) rdbms_utils.ConnectionManager {
-	return &connectionManager{ConnectionManagerBase: base, cfg: cfg}
+	cm := &connectionManager{ConnectionManagerBase: base, cfg: cfg}
+
+	if cfg.GetUseArrowStream() {
+		cm.httpClient = newArrowStreamHTTPClient(cfg)
+	}
+
+	return cm
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -46,6 +47,7 @@ func (r *rowsNative) MakeTransformer(ydbTypes []*Ydb.Type, cc conversion.Collect
 }
 
 var _ rdbms_utils.Connection = (*connectionNative)(nil)
+var _ rdbms_utils.QueryExplainer = (*connectionNative)(nil)
 
 type connectionNative struct {
 	driver.Conn
@@ -57,7 +59,14 @@ type connectionNative struct {
 func (c *connectionNative) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, error) {
 	c.queryLogger.Dump(params.QueryText, params.QueryArgs.Values()...)
 
-	out, err := c.Conn.Query(params.Ctx, params.QueryText, params.QueryArgs.Values()...)
+	ctx := params.Ctx
+
+	// ClickHouse attaches its own spans to the trace of the incoming request
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		ctx = clickhouse.Context(ctx, clickhouse.WithSpan(spanContext))
+	}
+
+	out, err := c.Conn.Query(ctx, params.QueryText, params.QueryArgs.Values()...)
 	if err != nil {
 		return nil, fmt.Errorf("query context: %w", err)
 	}
@@ -75,6 +84,17 @@ func (c *connectionNative) Query(params *rdbms_utils.QueryParams) (rdbms_utils.R
 	return &rowsNative{Rows: out}, nil
 }
 
+func (c *connectionNative) Explain(params *rdbms_utils.QueryParams) (string, error) {
+	out, err := c.Conn.Query(params.Ctx, "EXPLAIN "+params.QueryText, params.QueryArgs.Values()...)
+	if err != nil {
+		return "", fmt.Errorf("query context: %w", err)
+	}
+
+	defer common.LogCloserError(c.queryLogger.Logger, out, "close rows")
+
+	return collectExplainOutput(out)
+}
+
 func (c *connectionNative) DataSourceInstance() *api_common.TGenericDataSourceInstance {
 	return c.dataSourceInstance
 }
@@ -87,14 +107,12 @@ func (c *connectionNative) Logger() *zap.Logger {
 	return c.queryLogger.Logger
 }
 
-func makeConnectionNative(
+func dialConnectionNative(
 	ctx context.Context,
 	logger *zap.Logger,
 	cfg *config.TClickHouseConfig,
 	dsi *api_common.TGenericDataSourceInstance,
-	tableName string,
-	queryLogger common.QueryLogger,
-) (rdbms_utils.Connection, error) {
+) (rdbms_utils.PooledConnection, error) {
 	opts := &clickhouse.Options{
 		Addr: []string{common.EndpointToString(dsi.GetEndpoint())},
 		Auth: clickhouse.Auth{
@@ -130,8 +148,9 @@ func makeConnectionNative(
 	defer pingCtxCancel()
 
 	if err := conn.Ping(pingCtx); err != nil {
+		common.LogCloserError(logger, conn, "close connection")
 		return nil, fmt.Errorf("conn ping: %w", err)
 	}
 
-	return &connectionNative{Conn: conn, queryLogger: queryLogger, dataSourceInstance: dsi, tableName: tableName}, nil
+	return conn, nil
 }
@@ -0,0 +1,34 @@
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// explainRows is implemented by the rows of both native and HTTP drivers
+type explainRows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}
+
+// collectExplainOutput joins the lines returned by EXPLAIN statement
+func collectExplainOutput(rows explainRows) (string, error) {
+	var lines []string
+
+	for rows.Next() {
+		var line string
+
+		if err := rows.Scan(&line); err != nil {
+			return "", fmt.Errorf("rows scan: %w", err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("rows err: %w", err)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -3,7 +3,10 @@ package rdbms
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
@@ -13,6 +16,8 @@ import (
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/policy"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -24,6 +29,10 @@ type Preset struct {
 	SchemaProvider    rdbms_utils.SchemaProvider
 	SplitProvider     rdbms_utils.SplitProvider
 	RetrierSet        *retry.RetrierSet
+	// Query texts are attached to traces only if SQL query logging is enabled
+	EnableQueryLogging bool
+	// Data policy prevents the restricted columns from being used for table splitting, may be nil
+	PolicyEnforcer policy.Enforcer
 }
 
 var _ datasource.DataSource[any] = (*dataSourceImpl)(nil)
@@ -37,6 +46,9 @@ type dataSourceImpl struct {
 	retrierSet          *retry.RetrierSet
 	converterCollection conversion.Collection
 	observationStorage  observation.Storage
+	queryRegistry       *observation.QueryRegistry
+	enableQueryLogging  bool
+	policyEnforcer      policy.Enforcer
 	logger              *zap.Logger
 }
 
@@ -45,29 +57,13 @@ func (ds *dataSourceImpl) DescribeTable(
 	logger *zap.Logger,
 	request *api_service_protos.TDescribeTableRequest,
 ) (*api_service_protos.TDescribeTableResponse, error) {
-	var cs []rdbms_utils.Connection
-
-	err := ds.retrierSet.MakeConnection.Run(ctx, logger,
-		func() error {
-			var makeConnErr error
-
-			params := &rdbms_utils.ConnectionParams{
-				Ctx:                ctx,
-				Logger:             logger,
-				DataSourceInstance: request.DataSourceInstance,
-				TableName:          request.Table,
-				QueryPhase:         rdbms_utils.QueryPhaseDescribeTable,
-			}
-
-			cs, makeConnErr = ds.connectionManager.Make(params)
-			if makeConnErr != nil {
-				return fmt.Errorf("make connection: %w", makeConnErr)
-			}
-
-			return nil
-		},
-	)
-
+	cs, err := ds.makeConnections(ctx, logger, &rdbms_utils.ConnectionParams{
+		Ctx:                ctx,
+		Logger:             logger,
+		DataSourceInstance: request.DataSourceInstance,
+		TableName:          request.Table,
+		QueryPhase:         rdbms_utils.QueryPhaseDescribeTable,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("retry: %w", err)
 	}
@@ -85,6 +81,42 @@ func (ds *dataSourceImpl) DescribeTable(
 	return &api_service_protos.TDescribeTableResponse{Schema: schema}, nil
 }
 
+// makeConnections establishes connection(s) to the data source with retries
+func (ds *dataSourceImpl) makeConnections(
+	ctx context.Context,
+	logger *zap.Logger,
+	params *rdbms_utils.ConnectionParams,
+) ([]rdbms_utils.Connection, error) {
+	ctx, span := utils.StartSpan(
+		ctx,
+		"ConnectionManager.Make",
+		trace.WithAttributes(attribute.String("data_source_kind", params.DataSourceInstance.GetKind().String())),
+	)
+
+	params.Ctx = ctx
+
+	var cs []rdbms_utils.Connection
+
+	err := ds.retrierSet.MakeConnection.Run(
+		ctx,
+		logger,
+		func() error {
+			var makeConnErr error
+
+			cs, makeConnErr = ds.connectionManager.Make(params)
+			if makeConnErr != nil {
+				return fmt.Errorf("make connection: %w", makeConnErr)
+			}
+
+			return nil
+		},
+	)
+
+	utils.EndSpan(span, err)
+
+	return cs, err
+}
+
 func (ds *dataSourceImpl) ListSplits(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -101,6 +133,12 @@ func (ds *dataSourceImpl) ListSplits(
 		ResultChan:            resultChan,
 	}
 
+	if ds.policyEnforcer != nil {
+		params.IsColumnRestricted = func(column string) bool {
+			return ds.policyEnforcer.RestrictsColumn(slct.GetDataSourceInstance(), slct.GetFrom().GetTable(), column)
+		}
+	}
+
 	if err := ds.splitProvider.ListSplits(params); err != nil {
 		return fmt.Errorf("list splits: %w", err)
 	}
@@ -117,32 +155,14 @@ func (ds *dataSourceImpl) ReadSplit(
 	sinkFactory paging.SinkFactory[any],
 ) error {
 	// Make connection(s) to the data source.
-	var cs []rdbms_utils.Connection
-
-	err := ds.retrierSet.MakeConnection.Run(
-		ctx,
-		logger,
-		func() error {
-			var makeConnErr error
-
-			params := &rdbms_utils.ConnectionParams{
-				Ctx:                ctx,
-				Logger:             logger,
-				DataSourceInstance: split.Select.DataSourceInstance,
-				TableName:          split.Select.From.Table,
-				Split:              split,
-				QueryPhase:         rdbms_utils.QueryPhaseReadSplits,
-			}
-
-			cs, makeConnErr = ds.connectionManager.Make(params)
-			if makeConnErr != nil {
-				return fmt.Errorf("make connection: %w", makeConnErr)
-			}
-
-			return nil
-		},
-	)
-
+	cs, err := ds.makeConnections(ctx, logger, &rdbms_utils.ConnectionParams{
+		Ctx:                ctx,
+		Logger:             logger,
+		DataSourceInstance: split.Select.DataSourceInstance,
+		TableName:          split.Select.From.Table,
+		Split:              split,
+		QueryPhase:         rdbms_utils.QueryPhaseReadSplits,
+	})
 	if err != nil {
 		return fmt.Errorf("make connection: %w", err)
 	}
@@ -166,6 +186,7 @@ func (ds *dataSourceImpl) ReadSplit(
 	group := errgroup.Group{}
 
 	for i, conn := range cs {
+		i := i
 		conn := conn
 		sink := sinks[i]
 
@@ -190,8 +211,13 @@ func (ds *dataSourceImpl) ReadSplit(
 				return fmt.Errorf("create outgoing query: %w", err)
 			}
 
+			// make it possible to capture the plan of the query while it's running
+			unregisterExplainer := ds.queryRegistry.RegisterExplainer(
+				outgoingQueryID, ds.makePlanExplainer(logger, split, i, query))
+			defer unregisterExplainer()
+
 			// execute query
-			rowsRead, err := ds.doReadSplitSingleConn(ctx, logger, query, sink, conn)
+			stats, err := ds.doReadSplitSingleConn(ctx, logger, query, sink, conn)
 			if err != nil {
 				// register error
 				if cancelErr := ds.observationStorage.CancelOutgoingQuery(outgoingQueryID, err.Error()); cancelErr != nil {
@@ -202,7 +228,7 @@ func (ds *dataSourceImpl) ReadSplit(
 			}
 
 			// register success
-			if err := ds.observationStorage.FinishOutgoingQuery(outgoingQueryID, rowsRead); err != nil {
+			if err := ds.observationStorage.FinishOutgoingQuery(outgoingQueryID, stats); err != nil {
 				logger.Error("finish outgoing query: %w", zap.Error(err))
 			}
 
@@ -223,16 +249,68 @@ func (ds *dataSourceImpl) doReadSplitSingleConn(
 	query *rdbms_utils.SelectQuery,
 	sink paging.Sink[any],
 	conn rdbms_utils.Connection,
-) (int64, error) {
-	var rows rdbms_utils.Rows
+) (*observation.OutgoingQueryStats, error) {
+	queryStart := time.Now()
+
+	var (
+		rs  *rowsStats
+		err error
+	)
+
+	// some data sources are able to return the data in Arrow format, so the rows don't need to be transformed
+	if querier, ok := conn.(rdbms_utils.ArrowQuerier); ok && querier.CanQueryArrow(&query.QueryParams, query.YdbTypes) {
+		rs, err = ds.queryArrowRecords(ctx, logger, query, sink, querier)
+	} else {
+		rs, err = ds.queryRows(ctx, logger, query, sink, conn)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Notify sink that there will be no more data from this connection.
+	// Hours lost in attempts to move this call into defer: 2
+	sink.Finish()
+
+	sinkStats := sink.Stats()
+
+	stats := &observation.OutgoingQueryStats{
+		RowsRead:     rs.rowsRead,
+		BytesRead:    int64(sinkStats.Bytes),
+		PagesEmitted: int64(sinkStats.Pages),
+	}
+
+	if !rs.firstRowAt.IsZero() {
+		timeToFirstRow := rs.firstRowAt.Sub(queryStart)
+		stats.TimeToFirstRow = &timeToFirstRow
+	}
+
+	return stats, nil
+}
+
+// runQuery starts the query with retries, the query context carries the span,
+// so that it could be passed to the data source
+func (ds *dataSourceImpl) runQuery(
+	ctx context.Context,
+	logger *zap.Logger,
+	query *rdbms_utils.SelectQuery,
+	run func(params *rdbms_utils.QueryParams) error,
+) error {
+	var spanOpts []trace.SpanStartOption
+	if ds.enableQueryLogging {
+		spanOpts = append(spanOpts, trace.WithAttributes(attribute.String("db.statement", query.QueryText)))
+	}
+
+	queryCtx, querySpan := utils.StartSpan(ctx, "Connection.Query", spanOpts...)
+
+	queryParams := query.QueryParams
+	queryParams.Ctx = queryCtx
 
 	err := ds.retrierSet.Query.Run(
-		ctx,
+		queryCtx,
 		logger,
 		func() error {
-			var queryErr error
-
-			if rows, queryErr = conn.Query(&query.QueryParams); queryErr != nil {
+			if queryErr := run(&queryParams); queryErr != nil {
 				return fmt.Errorf("query '%s' error: %w", query.QueryText, queryErr)
 			}
 
@@ -240,42 +318,195 @@ func (ds *dataSourceImpl) doReadSplitSingleConn(
 		},
 	)
 
+	utils.EndSpan(querySpan, err)
+
 	if err != nil {
-		return 0, fmt.Errorf("query: %w", err)
+		return fmt.Errorf("query: %w", err)
+	}
+
+	return nil
+}
+
+// rowsStats describes the stream of rows obtained from the data source
+type rowsStats struct {
+	rowsRead   int64
+	firstRowAt time.Time // zero if there were no rows
+}
+
+func (ds *dataSourceImpl) queryRows(
+	ctx context.Context,
+	logger *zap.Logger,
+	query *rdbms_utils.SelectQuery,
+	sink paging.Sink[any],
+	conn rdbms_utils.Connection,
+) (*rowsStats, error) {
+	var rows rdbms_utils.Rows
+
+	err := ds.runQuery(ctx, logger, query, func(params *rdbms_utils.QueryParams) error {
+		var queryErr error
+
+		rows, queryErr = conn.Query(params)
+
+		return queryErr
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	defer common.LogCloserError(logger, rows, "close rows")
 
 	transformer, err := rows.MakeTransformer(query.YdbTypes, ds.converterCollection)
 	if err != nil {
-		return 0, fmt.Errorf("make transformer: %w", err)
+		return nil, fmt.Errorf("make transformer: %w", err)
 	}
 
-	rowsRead := int64(0)
+	spanTracker := newRowsSpanTracker(ctx)
+
+	rs, err := readRows(rows, transformer, sink, spanTracker)
+
+	spanTracker.end(err)
+
+	return rs, err
+}
+
+func readRows(
+	rows rdbms_utils.Rows,
+	transformer paging.RowTransformer[any],
+	sink paging.Sink[any],
+	spanTracker *rowsSpanTracker,
+) (*rowsStats, error) {
+	rs := &rowsStats{}
 
 	for cont := true; cont; cont = rows.NextResultSet() {
 		for rows.Next() {
-			rowsRead++
+			if rs.rowsRead == 0 {
+				rs.firstRowAt = time.Now()
+			}
+
+			rs.rowsRead++
 
 			if err := rows.Scan(transformer.GetAcceptors()...); err != nil {
-				return 0, fmt.Errorf("rows scan: %w", err)
+				return nil, fmt.Errorf("rows scan: %w", err)
 			}
 
 			if err := sink.AddRow(transformer); err != nil {
-				return 0, fmt.Errorf("add row to paging writer: %w", err)
+				return nil, fmt.Errorf("add row to paging writer: %w", err)
 			}
+
+			spanTracker.rowRead()
 		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return 0, fmt.Errorf("rows error: %w", err)
+		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
-	// Notify sink that there will be no more data from this connection.
-	// Hours lost in attempts to move this call into defer: 2
-	sink.Finish()
+	return rs, nil
+}
 
-	return rowsRead, nil
+func (ds *dataSourceImpl) queryArrowRecords(
+	ctx context.Context,
+	logger *zap.Logger,
+	query *rdbms_utils.SelectQuery,
+	sink paging.Sink[any],
+	querier rdbms_utils.ArrowQuerier,
+) (*rowsStats, error) {
+	var records rdbms_utils.ArrowRows
+
+	err := ds.runQuery(ctx, logger, query, func(params *rdbms_utils.QueryParams) error {
+		var queryErr error
+
+		records, queryErr = querier.QueryArrow(params, query.YdbTypes, ds.converterCollection)
+
+		return queryErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer common.LogCloserError(logger, records, "close records")
+
+	spanTracker := newRowsSpanTracker(ctx)
+
+	rs, err := readArrowRecords(records, sink, spanTracker)
+
+	spanTracker.end(err)
+
+	return rs, err
+}
+
+func readArrowRecords(
+	records rdbms_utils.ArrowRows,
+	sink paging.Sink[any],
+	spanTracker *rowsSpanTracker,
+) (*rowsStats, error) {
+	rs := &rowsStats{}
+
+	for records.Next() {
+		record := records.Record()
+
+		if rs.rowsRead == 0 && record.NumRows() > 0 {
+			rs.firstRowAt = time.Now()
+		}
+
+		rs.rowsRead += record.NumRows()
+
+		if err := sink.AddArrowRecord(record); err != nil {
+			return nil, fmt.Errorf("add record to paging writer: %w", err)
+		}
+
+		spanTracker.rowsRead(record.NumRows())
+	}
+
+	if err := records.Err(); err != nil {
+		return nil, fmt.Errorf("records error: %w", err)
+	}
+
+	return rs, nil
+}
+
+// makePlanExplainer returns the function capturing the execution plan of the query.
+// A distinct connection is used, because the original one is busy with reading the data.
+func (ds *dataSourceImpl) makePlanExplainer(
+	logger *zap.Logger,
+	split *api_service_protos.TSplit,
+	connIndex int,
+	query *rdbms_utils.SelectQuery,
+) observation.PlanExplainer {
+	return func(ctx context.Context) (string, error) {
+		cs, err := ds.makeConnections(ctx, logger, &rdbms_utils.ConnectionParams{
+			Ctx:                ctx,
+			Logger:             logger,
+			DataSourceInstance: split.Select.DataSourceInstance,
+			TableName:          split.Select.From.Table,
+			Split:              split,
+			QueryPhase:         rdbms_utils.QueryPhaseReadSplits,
+		})
+		if err != nil {
+			return "", fmt.Errorf("make connection: %w", err)
+		}
+
+		defer ds.connectionManager.Release(ctx, logger, cs)
+
+		if connIndex >= len(cs) {
+			return "", fmt.Errorf("connection %d is missing: %w", connIndex, common.ErrInvariantViolation)
+		}
+
+		explainer, ok := cs[connIndex].(rdbms_utils.QueryExplainer)
+		if !ok {
+			return "", fmt.Errorf("capture plan: %w", common.ErrMethodNotSupported)
+		}
+
+		params := query.QueryParams
+		params.Ctx = ctx
+
+		plan, err := explainer.Explain(&params)
+		if err != nil {
+			return "", fmt.Errorf("explain: %w", err)
+		}
+
+		return plan, nil
+	}
 }
 
 func NewDataSource(
@@ -283,6 +514,7 @@ func NewDataSource(
 	preset *Preset,
 	converterCollection conversion.Collection,
 	observationStorage observation.Storage,
+	queryRegistry *observation.QueryRegistry,
 ) datasource.DataSource[any] {
 	return &dataSourceImpl{
 		logger:              logger,
@@ -294,5 +526,8 @@ func NewDataSource(
 		retrierSet:          preset.RetrierSet,
 		converterCollection: converterCollection,
 		observationStorage:  observationStorage,
+		queryRegistry:       queryRegistry,
+		enableQueryLogging:  preset.EnableQueryLogging,
+		policyEnforcer:      preset.PolicyEnforcer,
 	}
 }
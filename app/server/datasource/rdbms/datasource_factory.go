@@ -1,8 +1,10 @@
 package rdbms
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
@@ -19,8 +21,11 @@ import (
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/ydb"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
+	"github.com/ydb-platform/fq-connector-go/app/server/policy"
+	"github.com/ydb-platform/fq-connector-go/app/server/secrets"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
 )
 
 var _ datasource.Factory[any] = (*dataSourceFactory)(nil)
@@ -36,8 +41,10 @@ type dataSourceFactory struct {
 	logging     Preset
 
 	observationStorage  observation.Storage
+	queryRegistry       *observation.QueryRegistry
 	loggingResolver     logging.Resolver
 	converterCollection conversion.Collection
+	connectionPools     []rdbms_utils.ConnectionPool
 }
 
 func (dsf *dataSourceFactory) Make(
@@ -46,42 +53,89 @@ func (dsf *dataSourceFactory) Make(
 ) (datasource.DataSource[any], error) {
 	switch dataSourceType {
 	case api_common.EGenericDataSourceKind_CLICKHOUSE:
-		return NewDataSource(logger, &dsf.clickhouse, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.clickhouse, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	case api_common.EGenericDataSourceKind_POSTGRESQL:
-		return NewDataSource(logger, &dsf.postgresql, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.postgresql, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	case api_common.EGenericDataSourceKind_YDB:
-		return NewDataSource(logger, &dsf.ydb, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.ydb, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	case api_common.EGenericDataSourceKind_MS_SQL_SERVER:
-		return NewDataSource(logger, &dsf.msSQLServer, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.msSQLServer, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	case api_common.EGenericDataSourceKind_MYSQL:
-		return NewDataSource(logger, &dsf.mysql, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.mysql, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	case api_common.EGenericDataSourceKind_GREENPLUM:
-		return NewDataSource(logger, &dsf.greenplum, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.greenplum, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	case api_common.EGenericDataSourceKind_ORACLE:
-		return NewDataSource(logger, &dsf.oracle, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.oracle, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	case api_common.EGenericDataSourceKind_LOGGING:
-		return NewDataSource(logger, &dsf.logging, dsf.converterCollection, dsf.observationStorage), nil
+		return NewDataSource(logger, &dsf.logging, dsf.converterCollection, dsf.observationStorage, dsf.queryRegistry), nil
 	default:
 		return nil, fmt.Errorf("pick handler for data source type '%v': %w", dataSourceType, common.ErrDataSourceNotSupported)
 	}
 }
 
+func (dsf *dataSourceFactory) CheckReadiness(ctx context.Context) map[api_common.EGenericDataSourceKind]error {
+	// Only the dependencies owned by the connector itself are checked,
+	// the availability of the user databases is not the connector concern.
+	return map[api_common.EGenericDataSourceKind]error{
+		api_common.EGenericDataSourceKind_CLICKHOUSE:    nil,
+		api_common.EGenericDataSourceKind_POSTGRESQL:    nil,
+		api_common.EGenericDataSourceKind_YDB:           nil,
+		api_common.EGenericDataSourceKind_MS_SQL_SERVER: nil,
+		api_common.EGenericDataSourceKind_MYSQL:         nil,
+		api_common.EGenericDataSourceKind_GREENPLUM:     nil,
+		api_common.EGenericDataSourceKind_ORACLE:        nil,
+		api_common.EGenericDataSourceKind_LOGGING:       dsf.loggingResolver.CheckReadiness(ctx),
+	}
+}
+
 func (dsf *dataSourceFactory) Close() error {
 	if err := dsf.loggingResolver.Close(); err != nil {
 		return fmt.Errorf("close logging resolver: %w", err)
 	}
 
+	for _, pool := range dsf.connectionPools {
+		if err := pool.Close(); err != nil {
+			return fmt.Errorf("close connection pool: %w", err)
+		}
+	}
+
 	return nil
 }
 
+//nolint:funlen
 func NewDataSourceFactory(
+	logger *zap.Logger,
 	cfg *config.TDatasourcesConfig,
 	qlf common.QueryLoggerFactory,
 	converterCollection conversion.Collection,
 	observationStorage observation.Storage,
+	queryRegistry *observation.QueryRegistry,
+	secretResolver secrets.Resolver,
+	policyEnforcer policy.Enforcer,
+	arrowAllocator memory.Allocator,
+	registry metrics.Registry,
 ) (datasource.Factory[any], error) {
-	connManagerBase := rdbms_utils.ConnectionManagerBase{
-		QueryLoggerFactory: qlf,
+	var connectionPools []rdbms_utils.ConnectionPool
+
+	// Every data source has its own connection pool, because connection managers
+	// of different data sources may open different connections for the same data source instance.
+	makeConnManagerBase := func(kind api_common.EGenericDataSourceKind) rdbms_utils.ConnectionManagerBase {
+		base := rdbms_utils.ConnectionManagerBase{
+			QueryLoggerFactory: qlf,
+			ArrowAllocator:     arrowAllocator,
+		}
+
+		if cfg.ConnectionPool.GetEnabled() {
+			base.ConnectionPool = rdbms_utils.NewConnectionPool(
+				logger.With(zap.String("data_source_kind", kind.String())),
+				cfg.ConnectionPool,
+				registry.WithPrefix("connection_pool").WithTags(map[string]string{"data_source_kind": kind.String()}),
+			)
+
+			connectionPools = append(connectionPools, base.ConnectionPool)
+		}
+
+		return base
 	}
 
 	postgresqlTypeMapper := postgresql.NewTypeMapper()
@@ -104,7 +158,7 @@ func NewDataSourceFactory(
 	dsf := &dataSourceFactory{
 		clickhouse: Preset{
 			SQLFormatter:      clickhouse.NewSQLFormatter(cfg.Clickhouse.Pushdown),
-			ConnectionManager: clickhouse.NewConnectionManager(cfg.Clickhouse, connManagerBase),
+			ConnectionManager: clickhouse.NewConnectionManager(cfg.Clickhouse, makeConnManagerBase(api_common.EGenericDataSourceKind_CLICKHOUSE)),
 			TypeMapper:        clickhouseTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(clickhouseTypeMapper, clickhouse.TableMetadataQuery),
 			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
@@ -116,7 +170,10 @@ func NewDataSourceFactory(
 		postgresql: Preset{
 			SQLFormatter: postgresql.NewSQLFormatter(cfg.Postgresql.Pushdown),
 			ConnectionManager: postgresql.NewConnectionManager(
-				cfg.Postgresql, connManagerBase, schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL]),
+				cfg.Postgresql,
+				makeConnManagerBase(api_common.EGenericDataSourceKind_POSTGRESQL),
+				schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL],
+			),
 			TypeMapper: postgresqlTypeMapper,
 			SchemaProvider: rdbms_utils.NewDefaultSchemaProvider(
 				postgresqlTypeMapper,
@@ -133,7 +190,7 @@ func NewDataSourceFactory(
 		},
 		ydb: Preset{
 			SQLFormatter:      ydb.NewSQLFormatter(cfg.Ydb.Mode, cfg.Ydb.Pushdown),
-			ConnectionManager: ydb.NewConnectionManager(cfg.Ydb, connManagerBase),
+			ConnectionManager: ydb.NewConnectionManager(cfg.Ydb, makeConnManagerBase(api_common.EGenericDataSourceKind_YDB)),
 			TypeMapper:        ydbTypeMapper,
 			SchemaProvider:    ydb.NewSchemaProvider(ydbTypeMapper),
 			SplitProvider:     ydb.NewSplitProvider(cfg.Ydb.Splitting),
@@ -144,10 +201,10 @@ func NewDataSourceFactory(
 		},
 		msSQLServer: Preset{
 			SQLFormatter:      ms_sql_server.NewSQLFormatter(cfg.MsSqlServer.Pushdown),
-			ConnectionManager: ms_sql_server.NewConnectionManager(cfg.MsSqlServer, connManagerBase),
+			ConnectionManager: ms_sql_server.NewConnectionManager(cfg.MsSqlServer, makeConnManagerBase(api_common.EGenericDataSourceKind_MS_SQL_SERVER)),
 			TypeMapper:        msSQLServerTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(msSQLServerTypeMapper, ms_sql_server.TableMetadataQuery),
-			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
+			SplitProvider:     ms_sql_server.NewSplitProvider(cfg.MsSqlServer.Splitting),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.MsSqlServer.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.MsSqlServer.ExponentialBackoff, retry.ErrorCheckerNoop),
@@ -155,10 +212,10 @@ func NewDataSourceFactory(
 		},
 		mysql: Preset{
 			SQLFormatter:      mysql.NewSQLFormatter(cfg.Mysql.Pushdown),
-			ConnectionManager: mysql.NewConnectionManager(cfg.Mysql, connManagerBase),
+			ConnectionManager: mysql.NewConnectionManager(cfg.Mysql, makeConnManagerBase(api_common.EGenericDataSourceKind_MYSQL)),
 			TypeMapper:        mysqlTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(mysqlTypeMapper, mysql.TableMetadataQuery),
-			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
+			SplitProvider:     mysql.NewSplitProvider(cfg.Mysql.Splitting),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Mysql.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.Mysql.ExponentialBackoff, retry.ErrorCheckerNoop),
@@ -167,7 +224,10 @@ func NewDataSourceFactory(
 		greenplum: Preset{
 			SQLFormatter: postgresql.NewSQLFormatter(cfg.Greenplum.Pushdown),
 			ConnectionManager: postgresql.NewConnectionManager(
-				cfg.Greenplum, connManagerBase, schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM]),
+				cfg.Greenplum,
+				makeConnManagerBase(api_common.EGenericDataSourceKind_GREENPLUM),
+				schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM],
+			),
 			TypeMapper: postgresqlTypeMapper,
 			SchemaProvider: rdbms_utils.NewDefaultSchemaProvider(
 				postgresqlTypeMapper,
@@ -184,7 +244,7 @@ func NewDataSourceFactory(
 		},
 		oracle: Preset{
 			SQLFormatter:      oracle.NewSQLFormatter(cfg.Oracle.Pushdown),
-			ConnectionManager: oracle.NewConnectionManager(cfg.Oracle, connManagerBase),
+			ConnectionManager: oracle.NewConnectionManager(cfg.Oracle, makeConnManagerBase(api_common.EGenericDataSourceKind_ORACLE)),
 			TypeMapper:        oracleTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(oracleTypeMapper, oracle.TableMetadataQuery),
 			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
@@ -205,7 +265,7 @@ func NewDataSourceFactory(
 
 	dsf.logging = Preset{
 		SQLFormatter:      logging.NewSQLFormatter(ydb.NewSQLFormatter(cfg.Logging.Ydb.Mode, cfg.Logging.Ydb.Pushdown)),
-		ConnectionManager: logging.NewConnectionManager(cfg.Logging, connManagerBase, dsf.loggingResolver),
+		ConnectionManager: logging.NewConnectionManager(cfg.Logging, makeConnManagerBase(api_common.EGenericDataSourceKind_LOGGING), dsf.loggingResolver),
 		TypeMapper:        ydbTypeMapper,
 		SchemaProvider:    ydb.NewSchemaProvider(ydbTypeMapper),
 		SplitProvider:     logging.NewSplitProvider(dsf.loggingResolver, ydb.NewSplitProvider(cfg.Logging.Ydb.Splitting)),
@@ -215,7 +275,19 @@ func NewDataSourceFactory(
 		},
 	}
 
+	for _, preset := range []*Preset{
+		&dsf.clickhouse, &dsf.postgresql, &dsf.ydb, &dsf.msSQLServer,
+		&dsf.mysql, &dsf.greenplum, &dsf.oracle, &dsf.logging,
+	} {
+		// Secret references are resolved before any connection is made
+		preset.ConnectionManager = rdbms_utils.NewSecretResolvingConnectionManager(preset.ConnectionManager, secretResolver)
+		preset.EnableQueryLogging = qlf.Enabled()
+		preset.PolicyEnforcer = policyEnforcer
+	}
+
 	dsf.observationStorage = observationStorage
+	dsf.queryRegistry = queryRegistry
+	dsf.connectionPools = connectionPools
 
 	return dsf, nil
 }
@@ -105,6 +105,7 @@ func TestReadSplit(t *testing.T) {
 		sink := &paging.SinkMock{}
 		sink.On("AddRow", transformer).Return(nil).Times(2)
 		sink.On("Finish").Return().Once()
+		sink.On("Stats").Return(&paging.SinkStats{Rows: 2, Bytes: 10, Pages: 1}).Once()
 
 		sinkFactory := &paging.SinkFactoryMock{}
 		sinkFactory.On("MakeSinks", []*paging.SinkParams{{Logger: logger}}).Return([]paging.Sink[any]{sink}, nil).Once()
@@ -113,7 +114,7 @@ func TestReadSplit(t *testing.T) {
 		observationStorage, err := observation.NewStorage(logger, nil)
 		require.NoError(t, err)
 
-		dataSource := NewDataSource(logger, preset, converterCollection, observationStorage)
+		dataSource := NewDataSource(logger, preset, converterCollection, observationStorage, observation.NewQueryRegistry())
 
 		queryID := observation.IncomingQueryID(0)
 		err = dataSource.ReadSplit(ctx, logger, queryID, readSplitsRequest, split, sinkFactory)
@@ -178,7 +179,7 @@ func TestReadSplit(t *testing.T) {
 		observationStorage, err := observation.NewStorage(logger, nil)
 		require.NoError(t, err)
 
-		dataSource := NewDataSource(logger, preset, converterCollection, observationStorage)
+		dataSource := NewDataSource(logger, preset, converterCollection, observationStorage, observation.NewQueryRegistry())
 
 		queryID := observation.IncomingQueryID(0)
 		err = dataSource.ReadSplit(ctx, logger, queryID, readSplitsRequest, split, sinkFactory)
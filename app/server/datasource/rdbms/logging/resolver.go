@@ -12,6 +12,8 @@ import (
 
 type Resolver interface {
 	resolve(request *resolveRequest) (*resolveResponse, error)
+	// CheckReadiness returns error if the resolver is unable to serve requests
+	CheckReadiness(ctx context.Context) error
 	Close() error
 }
 type resolveRequest struct {
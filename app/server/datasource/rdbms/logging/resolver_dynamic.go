@@ -1,11 +1,13 @@
 package logging
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 
@@ -89,6 +91,28 @@ LOOP:
 	}, nil
 }
 
+// CheckReadiness makes sure that the connection to the Logging API is established
+func (r *dynamicResolver) CheckReadiness(ctx context.Context) error {
+	// the connection is lazy, so initiate it explicitly
+	r.conn.Connect()
+
+	for {
+		state := r.conn.GetState()
+
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("Logging API connection state is %s", state)
+		case connectivity.Idle, connectivity.Connecting:
+		}
+
+		if !r.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("wait for Logging API connection: %w", ctx.Err())
+		}
+	}
+}
+
 func (r *dynamicResolver) Close() error {
 	return r.conn.Close()
 }
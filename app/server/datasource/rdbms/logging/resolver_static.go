@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"math/rand"
@@ -70,6 +71,8 @@ func (r *staticResolver) resolve(request *resolveRequest) (*resolveResponse, err
 	}, nil
 }
 
+func (staticResolver) CheckReadiness(context.Context) error { return nil }
+
 func (staticResolver) Close() error { return nil }
 
 func newResolverStatic(cfg *config.TLoggingConfig_TStaticResolving) Resolver {
@@ -1,8 +1,6 @@
 package ms_sql_server
 
 import (
-	"database/sql"
-
 	_ "github.com/denisenkom/go-mssqldb"
 	"go.uber.org/zap"
 
@@ -14,7 +12,7 @@ import (
 var _ rdbms_utils.Connection = (*Connection)(nil)
 
 type Connection struct {
-	db                 *sql.DB
+	db                 *rdbms_utils.PooledSQLDB
 	queryLogger        common.QueryLogger
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
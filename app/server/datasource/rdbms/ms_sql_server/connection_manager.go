@@ -47,28 +47,35 @@ func (c *connectionManager) Make(
 		connectString += "&encrypt=disable"
 	}
 
-	db, err := sql.Open("sqlserver", connectString)
+	db, err := c.AcquirePooledConnection(ctx, logger, dsi, func(ctx context.Context) (rdbms_utils.PooledConnection, error) {
+		db, err := sql.Open("sqlserver", connectString)
+		if err != nil {
+			return nil, fmt.Errorf("sql open: %w", err)
+		}
+
+		pingCtx, pingCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.PingConnectionTimeout))
+		defer pingCtxCancel()
+
+		err = db.PingContext(pingCtx)
+		if err != nil {
+			common.LogCloserError(logger, db, "close connection")
+			return nil, fmt.Errorf("ping: %w", err)
+		}
+
+		return &rdbms_utils.PooledSQLDB{DB: db}, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("sql open: %w", err)
-	}
-
-	pingCtx, pingCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.PingConnectionTimeout))
-	defer pingCtxCancel()
-
-	err = db.PingContext(pingCtx)
-	if err != nil {
-		common.LogCloserError(logger, db, "close connection")
-		return nil, fmt.Errorf("ping: %w", err)
+		return nil, fmt.Errorf("acquire pooled connection: %w", err)
 	}
 
 	queryLogger := c.QueryLoggerFactory.Make(logger)
 
-	return []rdbms_utils.Connection{&Connection{db, queryLogger, params.DataSourceInstance, params.TableName}}, nil
+	return []rdbms_utils.Connection{&Connection{db.(*rdbms_utils.PooledSQLDB), queryLogger, params.DataSourceInstance, params.TableName}}, nil
 }
 
-func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
+func (c *connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
 	for _, conn := range cs {
-		common.LogCloserError(logger, conn, "close connection")
+		c.ReleasePooledConnection(logger, conn.(*Connection).db)
 	}
 }
 
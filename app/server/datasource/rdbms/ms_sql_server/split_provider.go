@@ -0,0 +1,299 @@
+package ms_sql_server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ rdbms_utils.KeyRangeSampler = (*keyRangeSampler)(nil)
+
+// keyRangeSampler splits tables by the leading column of the clustered index
+// (or the primary key, if the table is a heap), using the histogram
+// of the index statistics to make splits of approximately equal size.
+type keyRangeSampler struct{}
+
+type keyColumn struct {
+	name       string
+	typeName   string
+	indexName  string
+	schemaName string
+}
+
+type histogramStep struct {
+	rangeHiKey *rdbms_utils.TKeyRangeSplitDescription_TBound
+	rangeRows  float64
+	eqRows     float64
+}
+
+func (s keyRangeSampler) SampleKeyRanges(
+	params *rdbms_utils.KeyRangeSampleParams,
+) (*rdbms_utils.KeyRangeSample, error) {
+	column, err := s.getKeyColumn(params)
+	if err != nil {
+		return nil, fmt.Errorf("get key column: %w", err)
+	}
+
+	if column == nil {
+		params.Logger.Info("table has neither clustered index nor primary key")
+
+		return nil, nil
+	}
+
+	if !isSplittableType(column.typeName) {
+		params.Logger.Info("key column type is not suitable for splitting",
+			zap.String("column", column.name), zap.String("type", column.typeName))
+
+		return nil, nil
+	}
+
+	totalRows, err := s.getTotalRows(params)
+	if err != nil {
+		return nil, fmt.Errorf("get total rows: %w", err)
+	}
+
+	splitCount := rdbms_utils.EstimateSplitCount(totalRows, params.RowsPerSplit, params.MaxSplits)
+	if splitCount < 2 {
+		return nil, nil
+	}
+
+	histogram, err := s.getHistogram(params, column)
+	if err != nil {
+		return nil, fmt.Errorf("get histogram: %w", err)
+	}
+
+	if len(histogram) == 0 {
+		params.Logger.Warn("index statistics are empty", zap.String("index", column.indexName))
+
+		return nil, nil
+	}
+
+	return &rdbms_utils.KeyRangeSample{
+		ColumnName: column.name,
+		Bounds:     makeBoundsFromHistogram(histogram, totalRows/splitCount, splitCount),
+	}, nil
+}
+
+// objectID resolves the table passed in the first parameter within the default schema,
+// the same way the unqualified table name is resolved by the SELECT queries.
+// Both parts are quoted separately, because the quoted whole name would be taken for a table name.
+const objectID = "OBJECT_ID(QUOTENAME(SCHEMA_NAME()) + N'.' + QUOTENAME(@p1))"
+
+func (keyRangeSampler) getKeyColumn(params *rdbms_utils.KeyRangeSampleParams) (*keyColumn, error) {
+	queryText := `SELECT TOP 1 c.name, t.name, i.name, OBJECT_SCHEMA_NAME(i.object_id)
+		FROM sys.indexes i
+		JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		JOIN sys.types t ON t.user_type_id = c.user_type_id
+		WHERE i.object_id = ` + objectID + ` AND ic.key_ordinal = 1 AND (i.type = 1 OR i.is_primary_key = 1)
+		ORDER BY i.type ASC`
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(params.Conn.TableName())
+
+	rows, err := params.Conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       params.Ctx,
+		Logger:    params.Logger,
+		QueryText: queryText,
+		QueryArgs: &args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer common.LogCloserError(params.Logger, rows, "close rows")
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("rows error: %w", err)
+		}
+
+		return nil, nil
+	}
+
+	var column keyColumn
+
+	if err := rows.Scan(&column.name, &column.typeName, &column.indexName, &column.schemaName); err != nil {
+		return nil, fmt.Errorf("rows scan: %w", err)
+	}
+
+	return &column, nil
+}
+
+func (keyRangeSampler) getTotalRows(params *rdbms_utils.KeyRangeSampleParams) (uint64, error) {
+	// Heaps have index_id = 0, clustered indexes have index_id = 1
+	queryText := `SELECT ISNULL(SUM(row_count), 0) FROM sys.dm_db_partition_stats
+		WHERE object_id = ` + objectID + ` AND index_id IN (0, 1)`
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(params.Conn.TableName())
+
+	rows, err := params.Conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       params.Ctx,
+		Logger:    params.Logger,
+		QueryText: queryText,
+		QueryArgs: &args,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	defer common.LogCloserError(params.Logger, rows, "close rows")
+
+	var totalRows int64
+
+	if rows.Next() {
+		if err := rows.Scan(&totalRows); err != nil {
+			return 0, fmt.Errorf("rows scan: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return uint64(totalRows), nil
+}
+
+func (keyRangeSampler) getHistogram(
+	params *rdbms_utils.KeyRangeSampleParams,
+	column *keyColumn,
+) ([]histogramStep, error) {
+	// DBCC does not accept parameters, so the names are escaped manually
+	queryText := fmt.Sprintf(
+		"DBCC SHOW_STATISTICS (%s, %s) WITH HISTOGRAM",
+		quoteString(quoteName(column.schemaName)+"."+quoteName(params.Conn.TableName())),
+		quoteString(quoteName(column.indexName)),
+	)
+
+	rows, err := params.Conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       params.Ctx,
+		Logger:    params.Logger,
+		QueryText: queryText,
+		QueryArgs: &rdbms_utils.QueryArgs{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer common.LogCloserError(params.Logger, rows, "close rows")
+
+	var (
+		histogram         []histogramStep
+		rangeHiKey        any
+		distinctRangeRows int64
+		avgRangeRows      float64
+	)
+
+	for rows.Next() {
+		var step histogramStep
+
+		if err := rows.Scan(&rangeHiKey, &step.rangeRows, &step.eqRows, &distinctRangeRows, &avgRangeRows); err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+
+		if step.rangeHiKey, err = makeKeyRangeBound(rangeHiKey); err != nil {
+			return nil, fmt.Errorf("make key range bound: %w", err)
+		}
+
+		histogram = append(histogram, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return histogram, nil
+}
+
+// makeBoundsFromHistogram walks through the histogram steps accumulating row counts,
+// and cuts the table every time the accumulated value reaches the desired split size.
+func makeBoundsFromHistogram(
+	histogram []histogramStep,
+	rowsPerSplit uint64,
+	splitCount uint64,
+) []*rdbms_utils.TKeyRangeSplitDescription_TBound {
+	var (
+		bounds      []*rdbms_utils.TKeyRangeSplitDescription_TBound
+		accumulated float64
+		threshold   = float64(rowsPerSplit)
+	)
+
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	for i, step := range histogram {
+		if uint64(len(bounds)) == splitCount-1 {
+			break
+		}
+
+		// The accumulated rows have the keys up to the previous step key, so once there are enough of them,
+		// the split is cut at the previous step key. The first step key is the minimal one, it's never a cut.
+		// EQ_ROWS are the rows with the keys equal to the step key, they belong to the split that starts with it.
+		if i > 1 && accumulated >= threshold {
+			bounds = append(bounds, histogram[i-1].rangeHiKey)
+			accumulated = histogram[i-1].eqRows
+		}
+
+		// RANGE_ROWS are the rows with the keys lying between the previous step key and the current one
+		accumulated += step.rangeRows + step.eqRows
+	}
+
+	return bounds
+}
+
+// makeKeyRangeBound returns nil for the histogram step of NULL keys,
+// such a step can only be the first one, so it never becomes a bound.
+func makeKeyRangeBound(value any) (*rdbms_utils.TKeyRangeSplitDescription_TBound, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		return rdbms_utils.NewInt64KeyRangeBound(v), nil
+	case int32:
+		return rdbms_utils.NewInt64KeyRangeBound(int64(v)), nil
+	case int16:
+		return rdbms_utils.NewInt64KeyRangeBound(int64(v)), nil
+	case uint8:
+		return rdbms_utils.NewInt64KeyRangeBound(int64(v)), nil
+	case time.Time:
+		return rdbms_utils.NewTimestampKeyRangeBound(v), nil
+	default:
+		return nil, fmt.Errorf("unexpected key value type %T: %w", value, common.ErrDataTypeNotSupported)
+	}
+}
+
+func isSplittableType(typeName string) bool {
+	switch typeName {
+	case "tinyint", "smallint", "int", "bigint", "date", "smalldatetime", "datetime", "datetime2":
+		return true
+	default:
+		return false
+	}
+}
+
+// quoteName works like QUOTENAME function
+func quoteName(ident string) string {
+	return "[" + strings.ReplaceAll(ident, "]", "]]") + "]"
+}
+
+func quoteString(value string) string {
+	return "N'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func formatKeyRangeTimestamp(t time.Time) string {
+	return "CAST('" + t.Format("2006-01-02T15:04:05.000000") + "' AS DATETIME2)"
+}
+
+func NewSplitProvider(cfg *config.TKeyRangeSplittingConfig) rdbms_utils.SplitProvider {
+	return rdbms_utils.NewKeyRangeSplitProvider(cfg, keyRangeSampler{})
+}
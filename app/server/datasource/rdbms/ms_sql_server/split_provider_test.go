@@ -0,0 +1,45 @@
+package ms_sql_server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+)
+
+func TestMakeBoundsFromHistogram(t *testing.T) {
+	histogram := []histogramStep{
+		{rangeHiKey: rdbms_utils.NewInt64KeyRangeBound(1), rangeRows: 0, eqRows: 1},
+		{rangeHiKey: rdbms_utils.NewInt64KeyRangeBound(100), rangeRows: 98, eqRows: 1},
+		{rangeHiKey: rdbms_utils.NewInt64KeyRangeBound(200), rangeRows: 99, eqRows: 1},
+		{rangeHiKey: rdbms_utils.NewInt64KeyRangeBound(300), rangeRows: 99, eqRows: 1},
+		{rangeHiKey: rdbms_utils.NewInt64KeyRangeBound(400), rangeRows: 99, eqRows: 1},
+	}
+
+	t.Run("even", func(t *testing.T) {
+		// the splits have 99, 100, 100 and 101 rows
+		bounds := makeBoundsFromHistogram(histogram, 100, 4)
+		require.Len(t, bounds, 3)
+		require.Equal(t, int64(100), bounds[0].GetInt64Value())
+		require.Equal(t, int64(200), bounds[1].GetInt64Value())
+		require.Equal(t, int64(300), bounds[2].GetInt64Value())
+	})
+
+	t.Run("nullable key", func(t *testing.T) {
+		// the step of NULL keys goes first, these rows are read within the first split
+		nullable := append([]histogramStep{{rangeHiKey: nil, rangeRows: 0, eqRows: 50}}, histogram...)
+
+		bounds := makeBoundsFromHistogram(nullable, 100, 4)
+		require.Len(t, bounds, 3)
+		require.Equal(t, int64(100), bounds[0].GetInt64Value())
+		require.Equal(t, int64(200), bounds[1].GetInt64Value())
+		require.Equal(t, int64(300), bounds[2].GetInt64Value())
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		bounds := makeBoundsFromHistogram(histogram, 10, 2)
+		require.Len(t, bounds, 1)
+		require.Equal(t, int64(100), bounds[0].GetInt64Value())
+	})
+}
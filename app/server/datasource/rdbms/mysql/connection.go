@@ -1,7 +1,9 @@
 package mysql
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -15,11 +17,30 @@ import (
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+var _ rdbms_utils.PooledConnection = (*pooledConnection)(nil)
+
+// pooledConnection is a physical connection that can be reused by different requests
+type pooledConnection struct {
+	*client.Conn
+	// broken is set if the result set of the last query was not read till the end
+	broken atomic.Bool
+}
+
+func (c *pooledConnection) Ping(_ context.Context) error {
+	return c.Conn.Ping()
+}
+
+// IsClosed reports that the connection must not be returned to the pool
+func (c *pooledConnection) IsClosed() bool {
+	return c.broken.Load()
+}
+
 var _ rdbms_utils.Connection = (*connection)(nil)
+var _ rdbms_utils.QueryExplainer = (*connection)(nil)
 
 type connection struct {
 	queryLogger        common.QueryLogger
-	conn               *client.Conn
+	conn               *pooledConnection
 	cfg                *config.TMySQLConfig
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
@@ -45,6 +66,11 @@ func transformArgs(src *rdbms_utils.QueryArgs) []any {
 func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, error) {
 	c.queryLogger.Dump(params.QueryText, params.QueryArgs.Values()...)
 
+	stmt, err := c.conn.Prepare(params.QueryText)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: failed to prepare query: %w", err)
+	}
+
 	results := make(chan rowData, c.cfg.ResultChanCapacity)
 	result := &mysql.Result{}
 
@@ -52,6 +78,7 @@ func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, e
 		ctx:                     params.Ctx,
 		cfg:                     c.cfg,
 		logger:                  params.Logger,
+		conn:                    c.conn,
 		rowChan:                 results,
 		errChan:                 make(chan error, 1),
 		lastRow:                 nil,
@@ -60,16 +87,8 @@ func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, e
 		inputFinished:           false,
 	}
 
-	stmt, err := c.conn.Prepare(params.QueryText)
-	if err != nil {
-		return r, fmt.Errorf("mysql: failed to prepare query: %w", err)
-	}
-
-	go func() {
-		defer close(r.rowChan)
-		defer close(r.errChan)
-
-		r.errChan <- stmt.ExecuteSelectStreaming(
+	r.start(func(ctx context.Context) error {
+		err := stmt.ExecuteSelectStreaming(
 			result,
 			// In per-row handler copy entire row. The driver re-uses memory allocated for single row,
 			// so we need either to lock the row until the reader is done its reading and processing
@@ -94,8 +113,8 @@ func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, e
 
 				select {
 				case r.rowChan <- rowData{newRow, result.Fields}:
-				case <-params.Ctx.Done():
-					return params.Ctx.Err()
+				case <-ctx.Done():
+					return ctx.Err()
 				}
 
 				return nil
@@ -103,11 +122,49 @@ func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, e
 			nil,
 			transformArgs(params.QueryArgs)...,
 		)
-	}()
+		if err != nil {
+			return err
+		}
+
+		// The statement can be closed only when the result set is read till the end,
+		// otherwise it's dropped by the server together with the broken connection.
+		if err := stmt.Close(); err != nil {
+			return fmt.Errorf("close statement: %w", err)
+		}
+
+		return nil
+	})
 
 	return r, nil
 }
 
+func (c *connection) Explain(params *rdbms_utils.QueryParams) (string, error) {
+	// tree format describes the plan in a single column
+	result, err := c.conn.Execute("EXPLAIN FORMAT=TREE "+params.QueryText, transformArgs(params.QueryArgs)...)
+	if err != nil {
+		return "", fmt.Errorf("execute: %w", err)
+	}
+
+	defer result.Close()
+
+	if result.Resultset == nil {
+		return "", nil
+	}
+
+	lines := make([]string, 0, result.RowNumber())
+
+	for i := 0; i < result.RowNumber(); i++ {
+		line, err := result.GetString(i, 0)
+		if err != nil {
+			return "", fmt.Errorf("get string: %w", err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
 func (c *connection) Logger() *zap.Logger {
 	return c.queryLogger.Logger
 }
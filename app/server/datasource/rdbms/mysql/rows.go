@@ -30,8 +30,13 @@ type rowData struct {
 
 type rows struct {
 	ctx    context.Context
+	cancel context.CancelFunc
 	logger *zap.Logger
 
+	// conn is owned by the streaming goroutine until it's done
+	conn *pooledConnection
+	done chan struct{}
+
 	errChan       chan error
 	rowChan       chan rowData
 	lastRow       *rowData
@@ -44,7 +49,47 @@ type rows struct {
 	cfg                     *config.TMySQLConfig
 }
 
-func (*rows) Close() error { return nil }
+// start runs the streaming of the result set in a separate goroutine
+func (r *rows) start(stream func(ctx context.Context) error) {
+	r.ctx, r.cancel = context.WithCancel(r.ctx)
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+		defer close(r.rowChan)
+		defer close(r.errChan)
+
+		err := stream(r.ctx)
+		if err != nil {
+			// the rest of the result set is left in the socket, so the connection cannot be reused
+			r.conn.broken.Store(true)
+		}
+
+		r.errChan <- err
+	}()
+}
+
+// Close interrupts the streaming and waits until the connection is released by the streaming goroutine
+func (r *rows) Close() error {
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return nil
+	default:
+	}
+
+	// the goroutine may be blocked on reading from the socket
+	r.conn.broken.Store(true)
+
+	if err := r.conn.SetReadDeadline(time.Now()); err != nil {
+		r.logger.Warn("set read deadline", zap.Error(err))
+	}
+
+	<-r.done
+
+	return nil
+}
 
 func (*rows) Err() error { return nil }
 
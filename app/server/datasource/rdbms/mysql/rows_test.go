@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-mysql-org/go-mysql/client"
+	"github.com/go-mysql-org/go-mysql/packet"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestRowsClose(t *testing.T) {
+	testCases := []struct {
+		name   string
+		stream func(ctx context.Context, r *rows, conn net.Conn) error
+		broken bool
+	}{
+		{
+			name: "result set read till the end",
+			stream: func(context.Context, *rows, net.Conn) error {
+				return nil
+			},
+			broken: false,
+		},
+		{
+			name: "blocked on sending rows",
+			stream: func(ctx context.Context, r *rows, _ net.Conn) error {
+				for {
+					select {
+					case r.rowChan <- rowData{}:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			},
+			broken: true,
+		},
+		{
+			name: "blocked on reading from the socket",
+			stream: func(_ context.Context, _ *rows, conn net.Conn) error {
+				_, err := conn.Read(make([]byte, 1))
+
+				return err
+			},
+			broken: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientConn, serverConn := net.Pipe()
+			defer serverConn.Close()
+
+			r := &rows{
+				ctx:     context.Background(),
+				logger:  common.NewTestLogger(t),
+				conn:    &pooledConnection{Conn: &client.Conn{Conn: packet.NewConn(clientConn)}},
+				rowChan: make(chan rowData),
+				errChan: make(chan error, 1),
+			}
+
+			r.start(func(ctx context.Context) error { return tc.stream(ctx, r, clientConn) })
+
+			if !tc.broken {
+				require.False(t, r.Next())
+			}
+
+			require.NoError(t, r.Close())
+			require.Equal(t, tc.broken, r.conn.IsClosed())
+		})
+	}
+}
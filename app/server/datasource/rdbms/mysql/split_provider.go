@@ -0,0 +1,406 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+)
+
+var _ rdbms_utils.KeyRangeSampler = (*keyRangeSampler)(nil)
+
+// keyRangeSampler splits tables by the first column of the primary key,
+// which is the leading column of the clustered index in InnoDB,
+// using the histogram of the column to make splits of approximately equal size.
+// MySQL doesn't keep histograms for single-column unique keys, so in the most common case
+// of a single-column primary key the range between its minimal and maximal values is divided evenly.
+type keyRangeSampler struct{}
+
+type keyColumn struct {
+	name      string
+	dataType  string
+	unsigned  bool
+	tableRows uint64
+}
+
+func (s keyRangeSampler) SampleKeyRanges(
+	params *rdbms_utils.KeyRangeSampleParams,
+) (*rdbms_utils.KeyRangeSample, error) {
+	conn := params.Conn.(*connection)
+
+	column, err := s.getKeyColumn(conn)
+	if err != nil {
+		return nil, fmt.Errorf("get key column: %w", err)
+	}
+
+	if column == nil {
+		params.Logger.Info("table has no primary key")
+
+		return nil, nil
+	}
+
+	if !isSplittableType(column.dataType) {
+		params.Logger.Info("primary key column type is not suitable for splitting",
+			zap.String("column", column.name), zap.String("type", column.dataType))
+
+		return nil, nil
+	}
+
+	splitCount := rdbms_utils.EstimateSplitCount(column.tableRows, params.RowsPerSplit, params.MaxSplits)
+	if splitCount < 2 {
+		return nil, nil
+	}
+
+	bounds, err := s.getBounds(params.Logger, conn, column, splitCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bounds) == 0 {
+		return nil, nil
+	}
+
+	return &rdbms_utils.KeyRangeSample{
+		ColumnName: column.name,
+		Bounds:     bounds,
+	}, nil
+}
+
+func (s keyRangeSampler) getBounds(
+	logger *zap.Logger,
+	conn *connection,
+	column *keyColumn,
+	splitCount uint64,
+) ([]*rdbms_utils.TKeyRangeSplitDescription_TBound, error) {
+	histogram, err := s.getHistogram(conn, column)
+	if err != nil {
+		return nil, fmt.Errorf("get histogram: %w", err)
+	}
+
+	if histogram != nil {
+		bounds, err := makeBoundsFromHistogram(histogram, column, splitCount)
+		if err != nil {
+			return nil, fmt.Errorf("make bounds from histogram: %w", err)
+		}
+
+		return bounds, nil
+	}
+
+	logger.Info("key column has no histogram statistics, the range of key values will be divided evenly",
+		zap.String("column", column.name))
+
+	lower, upper, err := s.getKeyRange(conn, column)
+	if err != nil {
+		return nil, fmt.Errorf("get key range: %w", err)
+	}
+
+	if lower == nil || upper == nil {
+		// the table is empty
+		return nil, nil
+	}
+
+	bounds, err := rdbms_utils.SplitKeyRangeEvenly(lower, upper, splitCount)
+	if err != nil {
+		return nil, fmt.Errorf("split key range evenly: %w", err)
+	}
+
+	return bounds, nil
+}
+
+func (keyRangeSampler) getKeyColumn(conn *connection) (*keyColumn, error) {
+	// Table statistics are estimated by the storage engine, but the precision is quite enough
+	// to divide the table into the splits of approximately equal size.
+	queryText := `SELECT s.column_name, c.data_type, c.column_type, IFNULL(t.table_rows, 0)
+		FROM information_schema.statistics s
+		JOIN information_schema.columns c
+			ON c.table_schema = s.table_schema AND c.table_name = s.table_name AND c.column_name = s.column_name
+		JOIN information_schema.tables t
+			ON t.table_schema = s.table_schema AND t.table_name = s.table_name
+		WHERE s.table_schema = ? AND s.table_name = ? AND s.index_name = 'PRIMARY' AND s.seq_in_index = 1`
+
+	args := []any{conn.DataSourceInstance().Database, conn.TableName()}
+
+	conn.queryLogger.Dump(queryText, args...)
+
+	result, err := conn.conn.Execute(queryText, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute: %w", err)
+	}
+
+	defer result.Close()
+
+	if result.RowNumber() == 0 {
+		return nil, nil
+	}
+
+	var column keyColumn
+
+	if column.name, err = result.GetString(0, 0); err != nil {
+		return nil, fmt.Errorf("get column name: %w", err)
+	}
+
+	if column.dataType, err = result.GetString(0, 1); err != nil {
+		return nil, fmt.Errorf("get data type: %w", err)
+	}
+
+	columnType, err := result.GetString(0, 2)
+	if err != nil {
+		return nil, fmt.Errorf("get column type: %w", err)
+	}
+
+	column.unsigned = strings.Contains(columnType, "unsigned")
+
+	if column.tableRows, err = result.GetUint(0, 3); err != nil {
+		return nil, fmt.Errorf("get table rows: %w", err)
+	}
+
+	return &column, nil
+}
+
+func (keyRangeSampler) getKeyRange(
+	conn *connection,
+	column *keyColumn,
+) (lower, upper *rdbms_utils.TKeyRangeSplitDescription_TBound, err error) {
+	var (
+		f          sqlFormatter
+		identifier = f.SanitiseIdentifier(column.name)
+		queryText  string
+	)
+
+	// MIN and MAX are resolved with the primary key index, so there is no need to scan the table.
+	if isTimestampType(column.dataType) {
+		// Session time zone is UTC, so the timestamps are not shifted
+		queryText = fmt.Sprintf(
+			"SELECT TIMESTAMPDIFF(MICROSECOND, '1970-01-01 00:00:00', MIN(%s)), "+
+				"TIMESTAMPDIFF(MICROSECOND, '1970-01-01 00:00:00', MAX(%s)) FROM %s",
+			identifier, identifier, f.FormatFrom(conn.TableName()))
+	} else {
+		queryText = fmt.Sprintf("SELECT MIN(%s), MAX(%s) FROM %s", identifier, identifier, f.FormatFrom(conn.TableName()))
+	}
+
+	conn.queryLogger.Dump(queryText)
+
+	result, err := conn.conn.Execute(queryText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("execute: %w", err)
+	}
+
+	defer result.Close()
+
+	if result.RowNumber() == 0 {
+		return nil, nil, nil
+	}
+
+	bounds := make([]*rdbms_utils.TKeyRangeSplitDescription_TBound, 2)
+
+	for i := range bounds {
+		isNull, err := result.IsNull(0, i)
+		if err != nil {
+			return nil, nil, fmt.Errorf("is null: %w", err)
+		}
+
+		if isNull {
+			return nil, nil, nil
+		}
+
+		// Text protocol returns numbers as strings, and the driver fails to parse negative ones by itself
+		value, err := result.GetString(0, i)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get string: %w", err)
+		}
+
+		if bounds[i], err = parseKeyRangeBound(value, column); err != nil {
+			return nil, nil, fmt.Errorf("parse key range bound: %w", err)
+		}
+	}
+
+	return bounds[0], bounds[1], nil
+}
+
+// parseKeyRangeBound parses the value returned by the query of the key range,
+// the timestamps are obtained as the number of microseconds since the epoch.
+func parseKeyRangeBound(value string, column *keyColumn) (*rdbms_utils.TKeyRangeSplitDescription_TBound, error) {
+	if isTimestampType(column.dataType) {
+		micros, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse int: %w", err)
+		}
+
+		return rdbms_utils.NewTimestampKeyRangeBound(time.UnixMicro(micros)), nil
+	}
+
+	return parseIntegerBound(value, column)
+}
+
+// histogram is the distribution statistics of the column collected by ANALYZE TABLE ... UPDATE HISTOGRAM:
+// https://dev.mysql.com/doc/refman/8.0/en/optimizer-statistics.html
+type histogram struct {
+	// Singleton buckets are [value, cumulative frequency],
+	// equi-height buckets are [lower value, upper value, cumulative frequency, number of distinct values].
+	Buckets [][]json.RawMessage `json:"buckets"`
+	Type    string              `json:"histogram-type"`
+}
+
+func (keyRangeSampler) getHistogram(conn *connection, column *keyColumn) (*histogram, error) {
+	queryText := `SELECT histogram FROM information_schema.column_statistics
+		WHERE schema_name = ? AND table_name = ? AND column_name = ?`
+
+	args := []any{conn.DataSourceInstance().Database, conn.TableName(), column.name}
+
+	conn.queryLogger.Dump(queryText, args...)
+
+	result, err := conn.conn.Execute(queryText, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute: %w", err)
+	}
+
+	defer result.Close()
+
+	if result.RowNumber() == 0 {
+		return nil, nil
+	}
+
+	data, err := result.GetString(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get histogram: %w", err)
+	}
+
+	var out histogram
+
+	if err := json.Unmarshal([]byte(data), &out); err != nil {
+		return nil, fmt.Errorf("unmarshal histogram: %w", err)
+	}
+
+	if len(out.Buckets) == 0 {
+		return nil, nil
+	}
+
+	return &out, nil
+}
+
+// makeBoundsFromHistogram cuts the table at the lower values of the buckets following the ones
+// where the cumulative frequency reaches the next multiple of 1 / splitCount,
+// so that the keys less than the bound make up the corresponding fraction of the table.
+func makeBoundsFromHistogram(
+	h *histogram,
+	column *keyColumn,
+	splitCount uint64,
+) ([]*rdbms_utils.TKeyRangeSplitDescription_TBound, error) {
+	var frequencyIndex int
+
+	switch h.Type {
+	case "singleton":
+		frequencyIndex = 1
+	case "equi-height":
+		frequencyIndex = 2
+	default:
+		return nil, fmt.Errorf("unexpected histogram type '%s'", h.Type)
+	}
+
+	var (
+		bounds []*rdbms_utils.TKeyRangeSplitDescription_TBound
+		split  uint64 = 1
+	)
+
+	for i := 0; i+1 < len(h.Buckets) && split < splitCount; i++ {
+		if len(h.Buckets[i]) <= frequencyIndex || len(h.Buckets[i+1]) == 0 {
+			return nil, fmt.Errorf("unexpected histogram bucket size %d", len(h.Buckets[i]))
+		}
+
+		var frequency float64
+
+		if err := json.Unmarshal(h.Buckets[i][frequencyIndex], &frequency); err != nil {
+			return nil, fmt.Errorf("unmarshal cumulative frequency: %w", err)
+		}
+
+		if frequency < float64(split)/float64(splitCount) {
+			continue
+		}
+
+		bound, err := parseHistogramValue(h.Buckets[i+1][0], column)
+		if err != nil {
+			return nil, fmt.Errorf("parse histogram value: %w", err)
+		}
+
+		bounds = append(bounds, bound)
+
+		// the bucket may be large enough to cover several splits
+		for split < splitCount && float64(split)/float64(splitCount) <= frequency {
+			split++
+		}
+	}
+
+	return bounds, nil
+}
+
+func parseHistogramValue(value json.RawMessage, column *keyColumn) (*rdbms_utils.TKeyRangeSplitDescription_TBound, error) {
+	if isTimestampType(column.dataType) {
+		var text string
+
+		if err := json.Unmarshal(value, &text); err != nil {
+			return nil, fmt.Errorf("unmarshal string: %w", err)
+		}
+
+		// the values are in UTC just like the session time zone
+		for _, layout := range []string{"2006-01-02 15:04:05.999999", "2006-01-02"} {
+			if t, err := time.Parse(layout, text); err == nil {
+				return rdbms_utils.NewTimestampKeyRangeBound(t), nil
+			}
+		}
+
+		return nil, fmt.Errorf("unexpected time value '%s'", text)
+	}
+
+	// keep the integers as is, so that the large unsigned ones don't lose precision
+	return parseIntegerBound(string(value), column)
+}
+
+func parseIntegerBound(text string, column *keyColumn) (*rdbms_utils.TKeyRangeSplitDescription_TBound, error) {
+	if column.unsigned {
+		result, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse uint: %w", err)
+		}
+
+		return rdbms_utils.NewUint64KeyRangeBound(result), nil
+	}
+
+	result, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse int: %w", err)
+	}
+
+	return rdbms_utils.NewInt64KeyRangeBound(result), nil
+}
+
+func isSplittableType(dataType string) bool {
+	switch dataType {
+	case typeTinyInt, typeSmallInt, typeMediumInt, typeInt, typeBigInt:
+		return true
+	default:
+		return isTimestampType(dataType)
+	}
+}
+
+func isTimestampType(dataType string) bool {
+	switch dataType {
+	case typeDate, typeDatetime, typeTimestamp:
+		return true
+	default:
+		return false
+	}
+}
+
+func formatKeyRangeTimestamp(t time.Time) string {
+	return "'" + t.Format("2006-01-02 15:04:05.000000") + "'"
+}
+
+func NewSplitProvider(cfg *config.TKeyRangeSplittingConfig) rdbms_utils.SplitProvider {
+	return rdbms_utils.NewKeyRangeSplitProvider(cfg, keyRangeSampler{})
+}
@@ -0,0 +1,67 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeBoundsFromHistogram(t *testing.T) {
+	parse := func(t *testing.T, data string) *histogram {
+		var h histogram
+
+		require.NoError(t, json.Unmarshal([]byte(data), &h))
+
+		return &h
+	}
+
+	t.Run("equi-height", func(t *testing.T) {
+		// the keys are skewed towards the beginning of the range
+		h := parse(t, `{"histogram-type": "equi-height", "buckets": [
+			[1, 10, 0.25, 10], [11, 20, 0.5, 10], [21, 1000, 0.75, 10], [1001, 100000, 1.0, 10]
+		]}`)
+
+		bounds, err := makeBoundsFromHistogram(h, &keyColumn{dataType: typeInt}, 4)
+		require.NoError(t, err)
+		require.Len(t, bounds, 3)
+		require.Equal(t, int64(11), bounds[0].GetInt64Value())
+		require.Equal(t, int64(21), bounds[1].GetInt64Value())
+		require.Equal(t, int64(1001), bounds[2].GetInt64Value())
+	})
+
+	t.Run("bucket covering several splits", func(t *testing.T) {
+		h := parse(t, `{"histogram-type": "singleton", "buckets": [[5, 0.9], [18446744073709551615, 1.0]]}`)
+
+		bounds, err := makeBoundsFromHistogram(h, &keyColumn{dataType: typeBigInt, unsigned: true}, 4)
+		require.NoError(t, err)
+		require.Len(t, bounds, 1)
+		require.Equal(t, uint64(18446744073709551615), bounds[0].GetUint64Value())
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		h := parse(t, `{"histogram-type": "singleton", "buckets": [
+			["2024-01-01 00:00:00.000000", 0.5], ["2024-01-02 12:30:00.500000", 1.0]
+		]}`)
+
+		bounds, err := makeBoundsFromHistogram(h, &keyColumn{dataType: typeDatetime}, 2)
+		require.NoError(t, err)
+		require.Len(t, bounds, 1)
+		require.Equal(t, time.Date(2024, 1, 2, 12, 30, 0, 500000000, time.UTC).UnixMicro(), bounds[0].GetTimestampValue())
+	})
+}
+
+func TestParseKeyRangeBound(t *testing.T) {
+	bound, err := parseKeyRangeBound("-42", &keyColumn{dataType: typeInt})
+	require.NoError(t, err)
+	require.Equal(t, int64(-42), bound.GetInt64Value())
+
+	bound, err = parseKeyRangeBound("18446744073709551615", &keyColumn{dataType: typeBigInt, unsigned: true})
+	require.NoError(t, err)
+	require.Equal(t, uint64(18446744073709551615), bound.GetUint64Value())
+
+	bound, err = parseKeyRangeBound("1704067200000000", &keyColumn{dataType: typeDatetime})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMicro(), bound.GetTimestampValue())
+}
@@ -86,11 +86,11 @@ func (f sqlFormatter) FormatFrom(tableName string) string {
 	return f.SanitiseIdentifier(tableName)
 }
 
-func (sqlFormatter) RenderSelectQueryText(
+func (f sqlFormatter) RenderSelectQueryText(
 	parts *rdbms_utils.SelectQueryParts,
-	_ *api_service_protos.TSplit,
+	split *api_service_protos.TSplit,
 ) (string, error) {
-	return rdbms_utils.DefaultSelectQueryRender(parts)
+	return rdbms_utils.RenderSelectQueryTextForKeyRange(f, formatKeyRangeTimestamp, parts, split)
 }
 
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
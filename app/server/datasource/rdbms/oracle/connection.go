@@ -2,7 +2,10 @@ package oracle
 
 import (
 	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	go_ora "github.com/sijms/go-ora/v2"
 	"go.uber.org/zap"
@@ -13,6 +16,7 @@ import (
 )
 
 var _ rdbms_utils.Connection = (*connection)(nil)
+var _ rdbms_utils.QueryExplainer = (*connection)(nil)
 
 type connection struct {
 	conn               *go_ora.Connection
@@ -28,9 +32,54 @@ func (c *connection) Close() error {
 func (c *connection) Query(queryParams *rdbms_utils.QueryParams) (rdbms_utils.Rows, error) {
 	c.queryLogger.Dump(queryParams.QueryText, queryParams.QueryArgs.Values()...)
 
-	valueArgs := make([]driver.NamedValue, queryParams.QueryArgs.Count())
-	for i := 0; i < len(queryParams.QueryArgs.Values()); i++ {
-		valueArgs[i].Value = queryParams.QueryArgs.Get(i).Value
+	out, err := c.conn.QueryContext(queryParams.Ctx, queryParams.QueryText, makeNamedValues(queryParams.QueryArgs))
+	if err != nil {
+		return nil, fmt.Errorf("query with context: %w", err)
+	}
+
+	rows := newRows(out)
+
+	return rows, nil
+}
+
+// Explain saves the plan into the plan table and then renders it with DBMS_XPLAN package
+func (c *connection) Explain(queryParams *rdbms_utils.QueryParams) (string, error) {
+	_, err := c.conn.ExecContext(queryParams.Ctx, "EXPLAIN PLAN FOR "+queryParams.QueryText, makeNamedValues(queryParams.QueryArgs))
+	if err != nil {
+		return "", fmt.Errorf("exec with context: %w", err)
+	}
+
+	out, err := c.conn.QueryContext(queryParams.Ctx, "SELECT PLAN_TABLE_OUTPUT FROM TABLE(DBMS_XPLAN.DISPLAY())", nil)
+	if err != nil {
+		return "", fmt.Errorf("query with context: %w", err)
+	}
+
+	defer common.LogCloserError(c.queryLogger.Logger, out, "close rows")
+
+	var (
+		lines []string
+		dest  = make([]driver.Value, 1)
+	)
+
+	for {
+		if err := out.Next(dest); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return "", fmt.Errorf("rows next: %w", err)
+		}
+
+		lines = append(lines, fmt.Sprint(dest[0]))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func makeNamedValues(args *rdbms_utils.QueryArgs) []driver.NamedValue {
+	valueArgs := make([]driver.NamedValue, args.Count())
+	for i := 0; i < len(args.Values()); i++ {
+		valueArgs[i].Value = args.Get(i).Value
 		// TODO YQ-3455: research
 		// 	for some reason query works with all Ordinal = 0
 		// 	Golang docs states: Ordinal position of the parameter starting from one and is always set.
@@ -39,14 +88,7 @@ func (c *connection) Query(queryParams *rdbms_utils.QueryParams) (rdbms_utils.Ro
 		valueArgs[i].Ordinal = i + 1
 	}
 
-	out, err := c.conn.QueryContext(queryParams.Ctx, queryParams.QueryText, valueArgs)
-	if err != nil {
-		return nil, fmt.Errorf("query with context: %w", err)
-	}
-
-	rows := newRows(out)
-
-	return rows, nil
+	return valueArgs
 }
 
 func (c *connection) DataSourceInstance() *api_common.TGenericDataSourceInstance {
@@ -28,8 +28,6 @@ func (c *connectionManager) Make(
 		return nil, fmt.Errorf("can not create Oracle connection with protocol '%v'", dsi.Protocol)
 	}
 
-	var err error
-
 	urlOptions := make(map[string]string)
 	if dsi.UseTls {
 		// more information in YQ-3456
@@ -49,36 +47,43 @@ func (c *connectionManager) Make(
 		urlOptions,
 	)
 
-	conn, err := go_ora.NewConnection(connStr, nil)
-	if err != nil {
-		return nil, fmt.Errorf("new go-ora connection: %w", err)
-	}
+	conn, err := c.AcquirePooledConnection(ctx, logger, dsi, func(ctx context.Context) (rdbms_utils.PooledConnection, error) {
+		conn, err := go_ora.NewConnection(connStr, nil)
+		if err != nil {
+			return nil, fmt.Errorf("new go-ora connection: %w", err)
+		}
 
-	openCtx, openCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.OpenConnectionTimeout))
-	defer openCtxCancel()
+		openCtx, openCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.OpenConnectionTimeout))
+		defer openCtxCancel()
 
-	err = conn.OpenWithContext(openCtx)
-	if err != nil {
-		return nil, fmt.Errorf("open connection: %w", err)
-	}
+		err = conn.OpenWithContext(openCtx)
+		if err != nil {
+			return nil, fmt.Errorf("open connection: %w", err)
+		}
+
+		pingCtx, pingCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.PingConnectionTimeout))
+		defer pingCtxCancel()
 
-	pingCtx, pingCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.PingConnectionTimeout))
-	defer pingCtxCancel()
+		err = conn.Ping(pingCtx)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ping database: %w", err)
+		}
 
-	err = conn.Ping(pingCtx)
+		return conn, nil
+	})
 	if err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("ping database: %w", err)
+		return nil, fmt.Errorf("acquire pooled connection: %w", err)
 	}
 
 	queryLogger := c.QueryLoggerFactory.Make(logger)
 
-	return []rdbms_utils.Connection{&connection{conn, queryLogger, params.DataSourceInstance, params.TableName}}, nil
+	return []rdbms_utils.Connection{&connection{conn.(*go_ora.Connection), queryLogger, params.DataSourceInstance, params.TableName}}, nil
 }
 
-func (*connectionManager) Release(_ context.Context, logger *zap.Logger, conn []rdbms_utils.Connection) {
-	for _, c := range conn {
-		common.LogCloserError(logger, c, "close Oracle connection")
+func (c *connectionManager) Release(_ context.Context, logger *zap.Logger, conn []rdbms_utils.Connection) {
+	for _, cs := range conn {
+		c.ReleasePooledConnection(logger, cs.(*connection).conn)
 	}
 }
 
@@ -3,7 +3,10 @@ package postgresql
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -19,6 +22,8 @@ import (
 )
 
 var _ rdbms_utils.Connection = (*connection)(nil)
+var _ rdbms_utils.QueryExplainer = (*connection)(nil)
+var _ rdbms_utils.ArrowQuerier = (*connection)(nil)
 
 type rows struct {
 	pgx.Rows
@@ -45,17 +50,25 @@ func (r rows) MakeTransformer(ydbTypes []*Ydb.Type, cc conversion.Collection) (p
 	return transformerFromOIDs(oids, ydbTypes, cc)
 }
 
-type connection struct {
+var _ rdbms_utils.PooledConnection = (*pooledConnection)(nil)
+
+// pooledConnection is a physical connection that can be reused by different requests
+type pooledConnection struct {
 	*pgx.Conn
-	queryLogger        common.QueryLogger
-	dataSourceInstance *api_common.TGenericDataSourceInstance
-	tableName          string
 }
 
-func (c *connection) Close() error {
+func (c *pooledConnection) Close() error {
 	return c.Conn.Close(context.TODO())
 }
 
+type connection struct {
+	*pooledConnection
+	queryLogger        common.QueryLogger
+	dataSourceInstance *api_common.TGenericDataSourceInstance
+	tableName          string
+	arrowAllocator     memory.Allocator
+}
+
 func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, error) {
 	c.queryLogger.Dump(params.QueryText, params.QueryArgs.Values()...)
 
@@ -67,6 +80,74 @@ func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, e
 	return rows{Rows: out}, nil
 }
 
+func (c *connection) CanQueryArrow(params *rdbms_utils.QueryParams, ydbTypes []*Ydb.Type) bool {
+	// the queries without columns (e. g. COUNT(*)) are not worth it
+	if len(ydbTypes) == 0 {
+		return false
+	}
+
+	_, err := bindQueryArgs(params.QueryText, params.QueryArgs.Values())
+
+	return err == nil
+}
+
+// QueryArrow reads the query results via `COPY ... TO STDOUT (FORMAT binary)`
+func (c *connection) QueryArrow(
+	params *rdbms_utils.QueryParams,
+	ydbTypes []*Ydb.Type,
+	cc conversion.Collection,
+) (rdbms_utils.ArrowRows, error) {
+	queryText, err := bindQueryArgs(params.QueryText, params.QueryArgs.Values())
+	if err != nil {
+		return nil, fmt.Errorf("bind query args: %w", err)
+	}
+
+	c.queryLogger.Dump(queryText)
+
+	// COPY output carries no column descriptions, so they are obtained from the unnamed prepared statement
+	description, err := c.Conn.PgConn().Prepare(params.Ctx, "", queryText, nil)
+	if err != nil {
+		return nil, fmt.Errorf("prepare: %w", err)
+	}
+
+	fieldNames := make([]string, 0, len(description.Fields))
+	oids := make([]uint32, 0, len(description.Fields))
+
+	for _, field := range description.Fields {
+		fieldNames = append(fieldNames, field.Name)
+		oids = append(oids, field.DataTypeOID)
+	}
+
+	out, err := newCopyRows(fieldNames, oids, ydbTypes, cc, c.arrowAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("new copy rows: %w", err)
+	}
+
+	copyText := fmt.Sprintf("COPY (%s) TO STDOUT WITH (FORMAT binary)", queryText)
+
+	out.start(params.Ctx, func(ctx context.Context, w io.Writer) error {
+		_, err := c.Conn.PgConn().CopyTo(ctx, w, copyText)
+
+		return err
+	})
+
+	return out, nil
+}
+
+func (c *connection) Explain(params *rdbms_utils.QueryParams) (string, error) {
+	out, err := c.Conn.Query(params.Ctx, "EXPLAIN "+params.QueryText, params.QueryArgs.Values()...)
+	if err != nil {
+		return "", fmt.Errorf("query error: %w", err)
+	}
+
+	lines, err := pgx.CollectRows(out, pgx.RowTo[string])
+	if err != nil {
+		return "", fmt.Errorf("collect rows: %w", err)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
 func (c *connection) DataSourceInstance() *api_common.TGenericDataSourceInstance {
 	return c.dataSourceInstance
 }
@@ -125,6 +206,24 @@ func (c *connectionManager) Make(
 		connCfg.TLSConfig.ServerName = dsi.GetEndpoint().GetHost()
 	}
 
+	conn, err := c.AcquirePooledConnection(ctx, logger, dsi, func(ctx context.Context) (rdbms_utils.PooledConnection, error) {
+		return c.dial(ctx, logger, connCfg, dsi)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("acquire pooled connection: %w", err)
+	}
+
+	queryLogger := c.QueryLoggerFactory.Make(logger)
+
+	return []rdbms_utils.Connection{&connection{conn.(*pooledConnection), queryLogger, dsi, params.TableName, c.ArrowAllocator}}, nil
+}
+
+func (c *connectionManager) dial(
+	ctx context.Context,
+	logger *zap.Logger,
+	connCfg *pgx.ConnConfig,
+	dsi *api_common.TGenericDataSourceInstance,
+) (rdbms_utils.PooledConnection, error) {
 	openCtx, openCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.GetOpenConnectionTimeout()))
 	defer openCtxCancel()
 
@@ -138,21 +237,33 @@ func (c *connectionManager) Make(
 	searchPath := fmt.Sprintf("set search_path=%s", c.schemaGetter(dsi))
 
 	if _, err = conn.Exec(openCtx, searchPath); err != nil {
+		common.LogCloserError(logger, &pooledConnection{conn}, "close connection")
+
 		return nil, fmt.Errorf("exec: %w", err)
 	}
 
-	queryLogger := c.QueryLoggerFactory.Make(logger)
-
-	return []rdbms_utils.Connection{&connection{conn, queryLogger, dsi, params.TableName}}, nil
+	return &pooledConnection{conn}, nil
 }
 
-func (*connectionManager) Release(ctx context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
+func (c *connectionManager) Release(ctx context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
 	for _, conn := range cs {
-		if err := conn.(*connection).Conn.DeallocateAll(ctx); err != nil {
+		pgConn := conn.(*connection)
+
+		// prepared statements must not leak into the next request using the same pooled connection,
+		// so the connection is terminated if it's impossible to clean them up;
+		// the connection may be already closed because of the interrupted COPY command
+		if pgConn.Conn.IsClosed() {
+			c.ReleasePooledConnection(logger, pgConn.pooledConnection)
+
+			continue
+		}
+
+		if err := pgConn.Conn.DeallocateAll(ctx); err != nil {
 			logger.Error("deallocate prepared statements", zap.Error(err))
+			common.LogCloserError(logger, pgConn.pooledConnection, "close connection")
 		}
 
-		common.LogCloserError(logger, conn, "close connection")
+		c.ReleasePooledConnection(logger, pgConn.pooledConnection)
 	}
 }
 
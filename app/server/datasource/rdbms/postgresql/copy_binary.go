@@ -0,0 +1,547 @@
+package postgresql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// Binary COPY format: https://www.postgresql.org/docs/current/sql-copy.html#id-1.9.3.55.9.4
+var copyBinarySignature = []byte("PGCOPY\n\377\r\n\x00")
+
+const (
+	// a record is made when either of the limits is reached, the sink splits or merges the records into pages anyway
+	copyRecordMaxRows  = 10000
+	copyRecordMaxBytes = 1 << 20
+)
+
+// errCopyAborted is passed to the writing side of the stream when the reading is stopped before the end of data
+var errCopyAborted = fmt.Errorf("copy aborted")
+
+// columnDecoder converts the value in binary format to the YDB-mapped Arrow type and appends it to the builder
+type columnDecoder func(data []byte, builder array.Builder) error
+
+var _ rdbms_utils.ArrowRows = (*copyRows)(nil)
+
+// copyRows decodes the output of `COPY ... TO STDOUT (FORMAT binary)` straight into Arrow builders,
+// so that no intermediate Go values are made for every row.
+type copyRows struct {
+	reader   *bufio.Reader
+	pipe     *io.PipeReader
+	cancel   context.CancelFunc
+	copyDone chan error // the result of the COPY command
+	copyErr  error
+	waited   bool
+
+	decoders []columnDecoder
+	builders []array.Builder
+	schema   *arrow.Schema
+	record   arrow.Record // the current batch
+
+	headerRead bool
+	finished   bool // the trailer has been read
+	fieldBuf   []byte
+	err        error
+}
+
+func (r *copyRows) Next() bool {
+	r.releaseRecord()
+
+	if r.finished || r.err != nil {
+		return false
+	}
+
+	if !r.headerRead {
+		if r.err = r.readHeader(); r.err != nil {
+			return false
+		}
+
+		r.headerRead = true
+	}
+
+	rows, err := r.readTuples()
+	if err != nil {
+		r.err = err
+
+		return false
+	}
+
+	if r.finished {
+		// the COPY command reports the errors that happened after the last tuple
+		if err := r.wait(); err != nil {
+			r.err = fmt.Errorf("copy: %w", err)
+
+			return false
+		}
+	}
+
+	if rows == 0 {
+		return false
+	}
+
+	columns := make([]arrow.Array, 0, len(r.builders))
+	for _, builder := range r.builders {
+		columns = append(columns, builder.NewArray())
+	}
+
+	r.record = array.NewRecord(r.schema, columns, int64(rows))
+
+	for _, column := range columns {
+		column.Release()
+	}
+
+	return true
+}
+
+func (r *copyRows) readHeader() error {
+	header := make([]byte, len(copyBinarySignature)+8)
+	if _, err := io.ReadFull(r.reader, header); err != nil {
+		return r.wrapReadError("read header", err)
+	}
+
+	if !bytes.Equal(header[:len(copyBinarySignature)], copyBinarySignature) {
+		return fmt.Errorf("invalid binary copy signature: %w", common.ErrInvariantViolation)
+	}
+
+	// flags are followed by the length of the header extension, that must be skipped
+	extensionLength := binary.BigEndian.Uint32(header[len(copyBinarySignature)+4:])
+	if _, err := r.reader.Discard(int(extensionLength)); err != nil {
+		return r.wrapReadError("skip header extension", err)
+	}
+
+	return nil
+}
+
+// readTuples decodes the rows into builders until the record is full or the data is over
+func (r *copyRows) readTuples() (int, error) {
+	var (
+		rows   int
+		header [4]byte
+		size   int
+	)
+
+	for rows < copyRecordMaxRows && size < copyRecordMaxBytes {
+		if _, err := io.ReadFull(r.reader, header[:2]); err != nil {
+			return 0, r.wrapReadError("read field count", err)
+		}
+
+		fieldCount := int16(binary.BigEndian.Uint16(header[:2]))
+		if fieldCount == -1 {
+			r.finished = true
+
+			break
+		}
+
+		if int(fieldCount) != len(r.decoders) {
+			return 0, fmt.Errorf("expected %d fields, got %d: %w", len(r.decoders), fieldCount, common.ErrInvariantViolation)
+		}
+
+		for i, decoder := range r.decoders {
+			if _, err := io.ReadFull(r.reader, header[:4]); err != nil {
+				return 0, r.wrapReadError("read field length", err)
+			}
+
+			length := int32(binary.BigEndian.Uint32(header[:4]))
+			if length == -1 {
+				r.builders[i].AppendNull()
+
+				continue
+			}
+
+			if length < 0 {
+				return 0, fmt.Errorf("invalid length %d of field #%d: %w", length, i, common.ErrInvariantViolation)
+			}
+
+			if cap(r.fieldBuf) < int(length) {
+				r.fieldBuf = make([]byte, length)
+			}
+
+			data := r.fieldBuf[:length]
+			if _, err := io.ReadFull(r.reader, data); err != nil {
+				return 0, r.wrapReadError("read field", err)
+			}
+
+			if err := decoder(data, r.builders[i]); err != nil {
+				return 0, fmt.Errorf("decode field #%d: %w", i, err)
+			}
+
+			size += int(length)
+		}
+
+		rows++
+	}
+
+	return rows, nil
+}
+
+// wrapReadError prefers the error of the COPY command, because the stream is broken as a consequence of it
+func (r *copyRows) wrapReadError(msg string, err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		if copyErr := r.wait(); copyErr != nil {
+			return fmt.Errorf("copy: %w", copyErr)
+		}
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func (r *copyRows) Record() arrow.Record { return r.record }
+
+func (r *copyRows) Err() error { return r.err }
+
+func (r *copyRows) Close() error {
+	r.releaseRecord()
+
+	for _, builder := range r.builders {
+		builder.Release()
+	}
+
+	if r.copyDone == nil {
+		return nil
+	}
+
+	if !r.waited {
+		// interrupt the COPY command, the connection is closed as a consequence
+		r.cancel()
+		r.pipe.CloseWithError(errCopyAborted)
+		_ = r.wait()
+	}
+
+	r.cancel()
+
+	return nil
+}
+
+// wait returns the result of the COPY command once it's over
+func (r *copyRows) wait() error {
+	if !r.waited {
+		r.copyErr = <-r.copyDone
+		r.waited = true
+	}
+
+	return r.copyErr
+}
+
+func (r *copyRows) releaseRecord() {
+	if r.record != nil {
+		r.record.Release()
+		r.record = nil
+	}
+}
+
+func newCopyRows(
+	fieldNames []string,
+	oids []uint32,
+	ydbTypes []*Ydb.Type,
+	cc conversion.Collection,
+	allocator memory.Allocator,
+) (*copyRows, error) {
+	if len(oids) != len(ydbTypes) {
+		return nil, fmt.Errorf("expected %d columns, got %d: %w", len(ydbTypes), len(oids), common.ErrInvariantViolation)
+	}
+
+	builders, err := common.YdbTypesToArrowBuilders(ydbTypes, allocator)
+	if err != nil {
+		return nil, fmt.Errorf("ydb types to arrow builders: %w", err)
+	}
+
+	r := &copyRows{
+		decoders: make([]columnDecoder, 0, len(oids)),
+		builders: builders,
+	}
+
+	fields := make([]arrow.Field, 0, len(oids))
+
+	for i, oid := range oids {
+		decoder, err := makeColumnDecoder(oid, ydbTypes[i], cc)
+		if err != nil {
+			for _, builder := range builders {
+				builder.Release()
+			}
+
+			return nil, fmt.Errorf("make decoder for column #%d: %w", i, err)
+		}
+
+		r.decoders = append(r.decoders, decoder)
+		fields = append(fields, arrow.Field{Name: fieldNames[i], Type: builders[i].Type(), Nullable: true})
+	}
+
+	r.schema = arrow.NewSchema(fields, nil)
+
+	return r, nil
+}
+
+// start makes the rows read the output of the COPY command running in the background
+func (r *copyRows) start(ctx context.Context, copyTo func(ctx context.Context, w io.Writer) error) {
+	pipeReader, pipeWriter := io.Pipe()
+
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.pipe = pipeReader
+	r.reader = bufio.NewReader(pipeReader)
+	r.copyDone = make(chan error, 1)
+
+	go func() {
+		err := copyTo(ctx, pipeWriter)
+		// the reading side gets EOF when the command is over
+		pipeWriter.CloseWithError(err)
+		r.copyDone <- err
+	}()
+}
+
+//nolint:gocyclo,funlen
+func makeColumnDecoder(oid uint32, ydbType *Ydb.Type, cc conversion.Collection) (columnDecoder, error) {
+	switch oid {
+	case pgtype.BoolOID:
+		return fixedSizeDecoder(1, func(data []byte, builder array.Builder) error {
+			return appendDecoded[bool, uint8, *array.Uint8Builder](data[0] != 0, builder, cc.Bool())
+		}), nil
+	case pgtype.Int2OID:
+		return fixedSizeDecoder(2, func(data []byte, builder array.Builder) error {
+			return appendDecoded[int16, int16, *array.Int16Builder](int16(binary.BigEndian.Uint16(data)), builder, cc.Int16())
+		}), nil
+	case pgtype.Int4OID:
+		return fixedSizeDecoder(4, func(data []byte, builder array.Builder) error {
+			return appendDecoded[int32, int32, *array.Int32Builder](int32(binary.BigEndian.Uint32(data)), builder, cc.Int32())
+		}), nil
+	case pgtype.Int8OID:
+		return fixedSizeDecoder(8, func(data []byte, builder array.Builder) error {
+			return appendDecoded[int64, int64, *array.Int64Builder](int64(binary.BigEndian.Uint64(data)), builder, cc.Int64())
+		}), nil
+	case pgtype.Float4OID:
+		return fixedSizeDecoder(4, func(data []byte, builder array.Builder) error {
+			value := math.Float32frombits(binary.BigEndian.Uint32(data))
+
+			return appendDecoded[float32, float32, *array.Float32Builder](value, builder, cc.Float32())
+		}), nil
+	case pgtype.Float8OID:
+		return fixedSizeDecoder(8, func(data []byte, builder array.Builder) error {
+			value := math.Float64frombits(binary.BigEndian.Uint64(data))
+
+			return appendDecoded[float64, float64, *array.Float64Builder](value, builder, cc.Float64())
+		}), nil
+	case pgtype.TextOID, pgtype.BPCharOID, pgtype.VarcharOID, pgtype.JSONOID:
+		// the binary representation of these types is the same as the text one
+		return func(data []byte, builder array.Builder) error {
+			return appendDecoded[string, string, *array.StringBuilder](string(data), builder, cc.String())
+		}, nil
+	case pgtype.ByteaOID:
+		return func(data []byte, builder array.Builder) error {
+			builder.(*array.BinaryBuilder).Append(data)
+
+			return nil
+		}, nil
+	case pgtype.UUIDOID:
+		return fixedSizeDecoder(16, func(data []byte, builder array.Builder) error {
+			builder.(*array.BinaryBuilder).Append([]byte(uuid.UUID(data).String()))
+
+			return nil
+		}), nil
+	case pgtype.DateOID:
+		return makeDateDecoder(oid, ydbType, cc)
+	case pgtype.TimestampOID:
+		return makeTimestampDecoder(oid, ydbType, cc)
+	default:
+		return nil, fmt.Errorf("convert type OID %d: %w", oid, common.ErrDataTypeNotSupported)
+	}
+}
+
+func makeDateDecoder(oid uint32, ydbType *Ydb.Type, cc conversion.Collection) (columnDecoder, error) {
+	ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+	if err != nil {
+		return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+	}
+
+	switch ydbTypeID {
+	case Ydb.Type_UTF8:
+		return fixedSizeDecoder(4, func(data []byte, builder array.Builder) error {
+			return appendDecoded[time.Time, string, *array.StringBuilder](decodeDate(data), builder, cc.DateToString())
+		}), nil
+	case Ydb.Type_DATE:
+		return fixedSizeDecoder(4, func(data []byte, builder array.Builder) error {
+			return appendDecoded[time.Time, uint16, *array.Uint16Builder](decodeDate(data), builder, cc.Date())
+		}), nil
+	default:
+		return nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbType, oid, common.ErrDataTypeNotSupported)
+	}
+}
+
+func makeTimestampDecoder(oid uint32, ydbType *Ydb.Type, cc conversion.Collection) (columnDecoder, error) {
+	ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+	if err != nil {
+		return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+	}
+
+	switch ydbTypeID {
+	case Ydb.Type_UTF8:
+		return fixedSizeDecoder(8, func(data []byte, builder array.Builder) error {
+			return appendDecoded[time.Time, string, *array.StringBuilder](decodeTimestamp(data), builder, cc.TimestampToString(true))
+		}), nil
+	case Ydb.Type_TIMESTAMP:
+		return fixedSizeDecoder(8, func(data []byte, builder array.Builder) error {
+			return appendDecoded[time.Time, uint64, *array.Uint64Builder](decodeTimestamp(data), builder, cc.Timestamp())
+		}), nil
+	default:
+		return nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbType, oid, common.ErrDataTypeNotSupported)
+	}
+}
+
+func fixedSizeDecoder(size int, decoder columnDecoder) columnDecoder {
+	return func(data []byte, builder array.Builder) error {
+		if len(data) != size {
+			return fmt.Errorf("expected %d bytes, got %d: %w", size, len(data), common.ErrInvariantViolation)
+		}
+
+		return decoder(data, builder)
+	}
+}
+
+func appendDecoded[IN common.ValueType, OUT common.ValueType, AB common.ArrowBuilder[OUT]](
+	value IN,
+	builder array.Builder,
+	conv conversion.ValuePtrConverter[IN, OUT],
+) error {
+	return utils.AppendValueToArrowBuilder[IN, OUT, AB](&value, builder, conv)
+}
+
+// PostgreSQL dates and timestamps are counted from 2000-01-01
+var postgresEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// decodeDate follows pgx, so that the values are the same as the ones read row by row:
+// infinite dates are represented with zero time
+func decodeDate(data []byte) time.Time {
+	days := int32(binary.BigEndian.Uint32(data))
+	if days == math.MaxInt32 || days == math.MinInt32 {
+		return time.Time{}
+	}
+
+	return time.Date(2000, time.January, int(1+days), 0, 0, 0, 0, time.UTC)
+}
+
+func decodeTimestamp(data []byte) time.Time {
+	microseconds := int64(binary.BigEndian.Uint64(data))
+	if microseconds == math.MaxInt64 || microseconds == math.MinInt64 {
+		return time.Time{}
+	}
+
+	return time.Unix(
+		postgresEpoch.Unix()+microseconds/1000000,
+		(microseconds%1000000)*1000,
+	).UTC()
+}
+
+// bindQueryArgs substitutes the placeholders with the literals, because COPY command takes no parameters.
+// The literals are quoted, so that PostgreSQL infers their types from the context just like the types of parameters.
+// Returns an error if the argument can't be represented as a literal.
+func bindQueryArgs(queryText string, args []any) (string, error) {
+	var (
+		result strings.Builder
+		quote  byte // the quote of the literal or identifier being scanned, zero outside of them
+	)
+
+	for i := 0; i < len(queryText); i++ {
+		c := queryText[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '$':
+			j := i + 1
+			for j < len(queryText) && queryText[j] >= '0' && queryText[j] <= '9' {
+				j++
+			}
+
+			if j == i+1 {
+				break
+			}
+
+			n, err := strconv.Atoi(queryText[i+1 : j])
+			if err != nil || n < 1 || n > len(args) {
+				return "", fmt.Errorf("invalid placeholder '%s': %w", queryText[i:j], common.ErrInvariantViolation)
+			}
+
+			literal, err := formatLiteral(args[n-1])
+			if err != nil {
+				return "", fmt.Errorf("format argument #%d: %w", n-1, err)
+			}
+
+			result.WriteString(literal)
+
+			i = j - 1
+
+			continue
+		}
+
+		result.WriteByte(c)
+	}
+
+	return result.String(), nil
+}
+
+func formatLiteral(arg any) (string, error) {
+	var value string
+
+	switch v := arg.(type) {
+	case bool:
+		value = strconv.FormatBool(v)
+	case int32:
+		value = strconv.FormatInt(int64(v), 10)
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case uint32:
+		value = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		value = strconv.FormatUint(v, 10)
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return "", fmt.Errorf("value %v: %w", v, common.ErrDataTypeNotSupported)
+		}
+
+		value = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", fmt.Errorf("value %v: %w", v, common.ErrDataTypeNotSupported)
+		}
+
+		value = strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		if strings.ContainsRune(v, 0) {
+			return "", fmt.Errorf("string with zero byte: %w", common.ErrDataTypeNotSupported)
+		}
+
+		value = v
+	case []byte:
+		// hex format of bytea
+		value = `\x` + hex.EncodeToString(v)
+	default:
+		return "", fmt.Errorf("argument of type %T: %w", arg, common.ErrDataTypeNotSupported)
+	}
+
+	// the escape string syntax doesn't depend on standard_conforming_strings setting
+	return "E'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'", nil
+}
@@ -0,0 +1,279 @@
+package postgresql
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestBindQueryArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		args     []any
+		expected string
+		err      error
+	}{
+		{
+			name:     "no args",
+			query:    `SELECT "col" FROM "tab"`,
+			expected: `SELECT "col" FROM "tab"`,
+		},
+		{
+			name:     "numbers",
+			query:    `SELECT "col" FROM "tab" WHERE ("a" = $1) AND ("b" > $2) AND ("c" < $3)`,
+			args:     []any{int32(-1), uint64(2), 0.5},
+			expected: `SELECT "col" FROM "tab" WHERE ("a" = E'-1') AND ("b" > E'2') AND ("c" < E'0.5')`,
+		},
+		{
+			name:     "strings",
+			query:    `SELECT "col$1" FROM "tab" WHERE ("a" = $1) OR ("b" = $2) OR ("c" = '$1')`,
+			args:     []any{"it's \\", []byte{'a', 0xff}},
+			expected: `SELECT "col$1" FROM "tab" WHERE ("a" = E'it\'s \\') OR ("b" = E'\\x61ff') OR ("c" = '$1')`,
+		},
+		{
+			name:  "unsupported type",
+			query: `SELECT "col" FROM "tab" WHERE "a" = $1`,
+			args:  []any{struct{}{}},
+			err:   common.ErrDataTypeNotSupported,
+		},
+		{
+			name:  "not a number",
+			query: `SELECT "col" FROM "tab" WHERE "a" = $1`,
+			args:  []any{math.NaN()},
+			err:   common.ErrDataTypeNotSupported,
+		},
+		{
+			name:  "missing arg",
+			query: `SELECT "col" FROM "tab" WHERE "a" = $2`,
+			args:  []any{int32(1)},
+			err:   common.ErrInvariantViolation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := bindQueryArgs(tc.query, tc.args)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+// copyStream builds the output of the COPY command in binary format
+type copyStream struct {
+	bytes.Buffer
+}
+
+func newCopyStream() *copyStream {
+	s := &copyStream{}
+	s.Write(copyBinarySignature)
+	s.Write(make([]byte, 8)) // flags and header extension length
+
+	return s
+}
+
+// tuple writes the row, nil values are written as NULL
+func (s *copyStream) tuple(fields ...[]byte) {
+	s.Write(binary.BigEndian.AppendUint16(nil, uint16(len(fields))))
+
+	for _, field := range fields {
+		if field == nil {
+			s.Write(binary.BigEndian.AppendUint32(nil, math.MaxUint32))
+
+			continue
+		}
+
+		s.Write(binary.BigEndian.AppendUint32(nil, uint32(len(field))))
+		s.Write(field)
+	}
+}
+
+func (s *copyStream) trailer() {
+	s.Write([]byte{0xff, 0xff})
+}
+
+func startCopyRows(t *testing.T, allocator memory.Allocator, ydbTypes []*Ydb.Type, oids []uint32, data []byte, copyErr error) *copyRows {
+	names := make([]string, len(oids))
+	for i := range names {
+		names[i] = fmt.Sprintf("col%d", i)
+	}
+
+	rows, err := newCopyRows(names, oids, ydbTypes, conversion.NewCollection(&config.TConversionConfig{}), allocator)
+	require.NoError(t, err)
+
+	rows.start(context.Background(), func(_ context.Context, w io.Writer) error {
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+
+		return copyErr
+	})
+
+	return rows
+}
+
+func TestCopyRows(t *testing.T) {
+	ydbTypes := []*Ydb.Type{
+		common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+		common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+		common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_BOOL)),
+		common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATE)),
+		common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_STRING)),
+	}
+	oids := []uint32{pgtype.Int4OID, pgtype.TextOID, pgtype.BoolOID, pgtype.DateOID, pgtype.UUIDOID}
+
+	uuidValue := []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}
+
+	stream := newCopyStream()
+	date := binary.BigEndian.AppendUint32(nil, 1) // 2000-01-02
+	stream.tuple(binary.BigEndian.AppendUint32(nil, 1), []byte("a"), []byte{1}, date, uuidValue)
+	stream.tuple(nil, nil, nil, nil, nil)
+	stream.trailer()
+
+	t.Run("records", func(t *testing.T) {
+		allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer allocator.AssertSize(t, 0)
+
+		rows := startCopyRows(t, allocator, ydbTypes, oids, stream.Bytes(), nil)
+
+		require.True(t, rows.Next())
+
+		record := rows.Record()
+		require.Equal(t, int64(2), record.NumRows())
+		require.Equal(t, "col1", record.ColumnName(1))
+		require.Equal(t, int32(1), record.Column(0).(*array.Int32).Value(0))
+		require.Equal(t, "a", record.Column(1).(*array.String).Value(0))
+		require.Equal(t, uint8(1), record.Column(2).(*array.Uint8).Value(0))
+		require.Equal(t, uint16(10958), record.Column(3).(*array.Uint16).Value(0))
+		require.Equal(t, "12345678-9abc-def0-1234-56789abcdef0", string(record.Column(4).(*array.Binary).Value(0)))
+
+		for i := 0; i < len(oids); i++ {
+			require.True(t, record.Column(i).IsNull(1))
+		}
+
+		require.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("copy error", func(t *testing.T) {
+		allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer allocator.AssertSize(t, 0)
+
+		// the stream is cut in the middle of the tuple
+		rows := startCopyRows(t, allocator, ydbTypes, oids, stream.Bytes()[:30], fmt.Errorf("canceling statement"))
+
+		require.False(t, rows.Next())
+		require.ErrorContains(t, rows.Err(), "canceling statement")
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("close before the end", func(t *testing.T) {
+		allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer allocator.AssertSize(t, 0)
+
+		names := make([]string, len(oids))
+		rows, err := newCopyRows(names, oids, ydbTypes, conversion.NewCollection(&config.TConversionConfig{}), allocator)
+		require.NoError(t, err)
+
+		// the command is blocked on writing until the reader is closed
+		rows.start(context.Background(), func(_ context.Context, w io.Writer) error {
+			for {
+				if _, err := w.Write(stream.Bytes()[:len(stream.Bytes())-2]); err != nil {
+					return err
+				}
+			}
+		})
+
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("invalid field length", func(t *testing.T) {
+		allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer allocator.AssertSize(t, 0)
+
+		// the only negative length allowed is -1 marking NULL values
+		data := newCopyStream()
+		data.Write(binary.BigEndian.AppendUint16(nil, uint16(len(oids))))
+		data.Write(binary.BigEndian.AppendUint32(nil, math.MaxUint32-1))
+
+		rows := startCopyRows(t, allocator, ydbTypes, oids, data.Bytes(), nil)
+
+		require.False(t, rows.Next())
+		require.ErrorIs(t, rows.Err(), common.ErrInvariantViolation)
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		data := []byte("PGCOPY\n\377\r\n\x01\x00\x00\x00\x00\x00\x00\x00\x00")
+		rows := startCopyRows(t, memory.DefaultAllocator, ydbTypes, oids, data, nil)
+
+		require.False(t, rows.Next())
+		require.ErrorIs(t, rows.Err(), common.ErrInvariantViolation)
+		require.NoError(t, rows.Close())
+	})
+}
+
+func TestColumnDecoders(t *testing.T) {
+	cc := conversion.NewCollection(&config.TConversionConfig{})
+
+	t.Run("infinite timestamp", func(t *testing.T) {
+		decoder, err := makeColumnDecoder(pgtype.TimestampOID, common.MakePrimitiveType(Ydb.Type_UTF8), cc)
+		require.NoError(t, err)
+
+		builder := array.NewStringBuilder(memory.DefaultAllocator)
+		defer builder.Release()
+
+		// the same value as the one obtained via pgx
+		require.NoError(t, decoder(binary.BigEndian.AppendUint64(nil, math.MaxInt64), builder))
+		require.NoError(t, decoder(binary.BigEndian.AppendUint64(nil, 1500000), builder))
+
+		values := builder.NewStringArray()
+		defer values.Release()
+
+		require.Equal(t, "0001-01-01T00:00:00Z", values.Value(0))
+		require.Equal(t, "2000-01-01T00:00:01.5Z", values.Value(1))
+	})
+
+	t.Run("unexpected size", func(t *testing.T) {
+		decoder, err := makeColumnDecoder(pgtype.Int8OID, common.MakePrimitiveType(Ydb.Type_INT64), cc)
+		require.NoError(t, err)
+
+		builder := array.NewInt64Builder(memory.DefaultAllocator)
+		defer builder.Release()
+
+		require.ErrorIs(t, decoder([]byte{1, 2, 3, 4}, builder), common.ErrInvariantViolation)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := makeColumnDecoder(pgtype.NumericOID, common.MakePrimitiveType(Ydb.Type_UTF8), cc)
+		require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+	})
+
+	t.Run("date to ydb type", func(t *testing.T) {
+		_, err := makeColumnDecoder(pgtype.DateOID, common.MakePrimitiveType(Ydb.Type_INT64), cc)
+		require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+	})
+}
@@ -0,0 +1,50 @@
+package rdbms
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+)
+
+// rowsPerSpan is the number of rows covered by a single span:
+// a span per row would be too expensive, while a single span for the whole split
+// hides the dynamics of the long reads.
+const rowsPerSpan = 10000
+
+// rowsSpanTracker represents the rows fetched from the data source as a sequence of spans
+type rowsSpanTracker struct {
+	ctx  context.Context
+	span trace.Span
+	rows int64
+}
+
+func (t *rowsSpanTracker) start() {
+	_, t.span = utils.StartSpan(t.ctx, "Rows.Next")
+	t.rows = 0
+}
+
+func (t *rowsSpanTracker) rowRead() { t.rowsRead(1) }
+
+func (t *rowsSpanTracker) rowsRead(count int64) {
+	t.rows += count
+
+	if t.rows >= rowsPerSpan {
+		t.end(nil)
+		t.start()
+	}
+}
+
+func (t *rowsSpanTracker) end(err error) {
+	t.span.SetAttributes(attribute.Int64("rows", t.rows))
+	utils.EndSpan(t.span, err)
+}
+
+func newRowsSpanTracker(ctx context.Context) *rowsSpanTracker {
+	t := &rowsSpanTracker{ctx: ctx}
+	t.start()
+
+	return t
+}
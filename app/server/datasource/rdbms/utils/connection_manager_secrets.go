@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/fq-connector-go/app/server/secrets"
+)
+
+var _ ConnectionManager = (*secretResolvingConnectionManager)(nil)
+
+// secretResolvingConnectionManager resolves secret references in credentials right before dialing,
+// so that the secrets never leave the connection manager (e. g. within the split descriptions)
+type secretResolvingConnectionManager struct {
+	ConnectionManager
+	resolver secrets.Resolver
+}
+
+func (cm *secretResolvingConnectionManager) Make(params *ConnectionParams) ([]Connection, error) {
+	dsi, err := cm.resolver.ResolveCredentials(params.Ctx, params.DataSourceInstance)
+	if err != nil {
+		return nil, fmt.Errorf("resolve credentials: %w", err)
+	}
+
+	resolvedParams := *params
+	resolvedParams.DataSourceInstance = dsi
+
+	return cm.ConnectionManager.Make(&resolvedParams)
+}
+
+func NewSecretResolvingConnectionManager(cm ConnectionManager, resolver secrets.Resolver) ConnectionManager {
+	return &secretResolvingConnectionManager{
+		ConnectionManager: cm,
+		resolver:          resolver,
+	}
+}
@@ -0,0 +1,470 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	app_utils "github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// PooledConnection is a physical connection to the data source
+// that can be sequentially reused by multiple requests.
+type PooledConnection interface {
+	// Ping checks if the connection is still alive.
+	Ping(ctx context.Context) error
+	// Close terminates network connection.
+	Close() error
+}
+
+// DialFunc opens a brand-new physical connection.
+type DialFunc func(ctx context.Context) (PooledConnection, error)
+
+// ConnectionPool keeps physical connections between the requests.
+// Connections are shared only within the same data source instance,
+// so the requests with different endpoints, databases or credentials never meet each other.
+type ConnectionPool interface {
+	// Acquire returns either an idle connection opened to the data source instance earlier,
+	// or a brand-new connection made with dial function. The call blocks if the limit
+	// of the open connections is reached.
+	Acquire(
+		ctx context.Context,
+		logger *zap.Logger,
+		dsi *api_common.TGenericDataSourceInstance,
+		dial DialFunc,
+	) (PooledConnection, error)
+	// Release returns the connection obtained with Acquire back to the pool.
+	Release(logger *zap.Logger, conn PooledConnection)
+	// Close terminates all idle connections. Connections that are in use
+	// will be terminated during the release.
+	Close() error
+}
+
+// AcquirePooledConnection takes physical connection from the pool if it is configured,
+// or dials a new connection otherwise.
+func (b *ConnectionManagerBase) AcquirePooledConnection(
+	ctx context.Context,
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	dial DialFunc,
+) (PooledConnection, error) {
+	if b.ConnectionPool == nil {
+		return dial(ctx)
+	}
+
+	return b.ConnectionPool.Acquire(ctx, logger, dsi, dial)
+}
+
+// ReleasePooledConnection returns physical connection to the pool if it is configured,
+// or closes the connection otherwise.
+func (b *ConnectionManagerBase) ReleasePooledConnection(logger *zap.Logger, conn PooledConnection) {
+	if b.ConnectionPool == nil {
+		common.LogCloserError(logger, conn, "close connection")
+
+		return
+	}
+
+	b.ConnectionPool.Release(logger, conn)
+}
+
+var _ PooledConnection = (*PooledSQLDB)(nil)
+
+// PooledSQLDB adapts database/sql handle to the PooledConnection interface.
+type PooledSQLDB struct {
+	*sql.DB
+}
+
+func (db *PooledSQLDB) Ping(ctx context.Context) error {
+	return db.DB.PingContext(ctx)
+}
+
+// closedConnectionChecker may be implemented by the connections
+// that are able to report that they were broken during the request.
+type closedConnectionChecker interface {
+	IsClosed() bool
+}
+
+type idleConnection struct {
+	conn       PooledConnection
+	releasedAt time.Time
+}
+
+type connectionPoolBucket struct {
+	// idle connections ordered by release time
+	idle []*idleConnection
+	// total number of connections (both idle and in use, including the ones being dialed)
+	open uint32
+	// closed every time when the connection is returned to the bucket or terminated
+	changed chan struct{}
+}
+
+func (b *connectionPoolBucket) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+type connectionPoolMetrics struct {
+	idleConnections     metrics.IntGauge
+	inUseConnections    metrics.IntGauge
+	createdConnections  metrics.Counter
+	reusedConnections   metrics.Counter
+	closedConnections   metrics.Counter
+	healthCheckFailures metrics.Counter
+	acquireWaits        metrics.Counter
+}
+
+func newConnectionPoolMetrics(registry metrics.Registry) *connectionPoolMetrics {
+	m := &connectionPoolMetrics{
+		idleConnections:     registry.IntGauge("idle_connections"),
+		inUseConnections:    registry.IntGauge("in_use_connections"),
+		createdConnections:  registry.Counter("created_connections"),
+		reusedConnections:   registry.Counter("reused_connections"),
+		closedConnections:   registry.Counter("closed_connections"),
+		healthCheckFailures: registry.Counter("health_check_failures"),
+		acquireWaits:        registry.Counter("acquire_waits"),
+	}
+
+	app_utils.Rated(m.createdConnections)
+	app_utils.Rated(m.reusedConnections)
+	app_utils.Rated(m.closedConnections)
+	app_utils.Rated(m.healthCheckFailures)
+	app_utils.Rated(m.acquireWaits)
+
+	return m
+}
+
+var _ ConnectionPool = (*connectionPool)(nil)
+
+type connectionPool struct {
+	logger              *zap.Logger
+	maxOpen             uint32
+	maxIdle             uint32
+	idleTimeout         time.Duration
+	healthCheckInterval time.Duration
+	healthCheckTimeout  time.Duration
+	metrics             *connectionPoolMetrics
+
+	mutex   sync.Mutex
+	buckets map[string]*connectionPoolBucket
+	inUse   map[PooledConnection]string
+	closed  bool
+
+	exitChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+func (p *connectionPool) Acquire(
+	ctx context.Context,
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	dial DialFunc,
+) (PooledConnection, error) {
+	key, err := makeConnectionPoolKey(dsi)
+	if err != nil {
+		return nil, fmt.Errorf("make connection pool key: %w", err)
+	}
+
+	for {
+		p.mutex.Lock()
+
+		if p.closed {
+			p.mutex.Unlock()
+
+			return nil, errors.New("connection pool is closed")
+		}
+
+		bucket := p.getBucket(key)
+
+		// the most recently used connections are taken first
+		if n := len(bucket.idle); n > 0 {
+			ic := bucket.idle[n-1]
+			bucket.idle = bucket.idle[:n-1]
+			p.inUse[ic.conn] = key
+			p.metrics.idleConnections.Add(-1)
+			p.metrics.inUseConnections.Add(1)
+			p.mutex.Unlock()
+
+			if err := p.checkHealth(ctx, logger, ic); err != nil {
+				logger.Warn("pooled connection health check failed", zap.Error(err))
+				p.metrics.healthCheckFailures.Inc()
+				p.discard(logger, ic.conn)
+
+				continue
+			}
+
+			p.metrics.reusedConnections.Inc()
+
+			return ic.conn, nil
+		}
+
+		if p.maxOpen == 0 || bucket.open < p.maxOpen {
+			bucket.open++
+			p.mutex.Unlock()
+
+			return p.dial(ctx, key, dial)
+		}
+
+		changed := bucket.changed
+		p.mutex.Unlock()
+
+		p.metrics.acquireWaits.Inc()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for pooled connection: %w", ctx.Err())
+		}
+	}
+}
+
+func (p *connectionPool) dial(ctx context.Context, key string, dial DialFunc) (PooledConnection, error) {
+	conn, err := dial(ctx)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	bucket := p.buckets[key]
+
+	if err != nil {
+		bucket.open--
+		bucket.notify()
+
+		return nil, err
+	}
+
+	p.inUse[conn] = key
+	p.metrics.inUseConnections.Add(1)
+	p.metrics.createdConnections.Inc()
+
+	return conn, nil
+}
+
+func (p *connectionPool) checkHealth(ctx context.Context, logger *zap.Logger, ic *idleConnection) error {
+	if time.Since(ic.releasedAt) < p.healthCheckInterval {
+		return nil
+	}
+
+	logger.Debug("checking pooled connection health")
+
+	pingCtx, pingCtxCancel := context.WithTimeout(ctx, p.healthCheckTimeout)
+	defer pingCtxCancel()
+
+	if err := ic.conn.Ping(pingCtx); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+
+	return nil
+}
+
+func (p *connectionPool) Release(logger *zap.Logger, conn PooledConnection) {
+	p.mutex.Lock()
+
+	key, exists := p.inUse[conn]
+	if !exists {
+		p.mutex.Unlock()
+
+		logger.Error("attempt to release connection that does not belong to the pool")
+		p.closeConnection(logger, conn)
+
+		return
+	}
+
+	delete(p.inUse, conn)
+	p.metrics.inUseConnections.Add(-1)
+
+	bucket := p.buckets[key]
+
+	checker, ok := conn.(closedConnectionChecker)
+	broken := ok && checker.IsClosed()
+
+	if p.closed || broken || uint32(len(bucket.idle)) >= p.maxIdle {
+		bucket.open--
+		bucket.notify()
+		p.mutex.Unlock()
+
+		p.closeConnection(logger, conn)
+
+		return
+	}
+
+	bucket.idle = append(bucket.idle, &idleConnection{conn: conn, releasedAt: time.Now()})
+	bucket.notify()
+	p.metrics.idleConnections.Add(1)
+	p.mutex.Unlock()
+}
+
+// discard terminates the acquired connection without returning it to the pool.
+func (p *connectionPool) discard(logger *zap.Logger, conn PooledConnection) {
+	p.mutex.Lock()
+
+	key := p.inUse[conn]
+	delete(p.inUse, conn)
+	p.metrics.inUseConnections.Add(-1)
+
+	bucket := p.buckets[key]
+	bucket.open--
+	bucket.notify()
+
+	p.mutex.Unlock()
+
+	p.closeConnection(logger, conn)
+}
+
+func (p *connectionPool) closeConnection(logger *zap.Logger, conn PooledConnection) {
+	common.LogCloserError(logger, conn, "close pooled connection")
+	p.metrics.closedConnections.Inc()
+}
+
+// getBucket must be called under the mutex
+func (p *connectionPool) getBucket(key string) *connectionPoolBucket {
+	bucket, exists := p.buckets[key]
+	if !exists {
+		bucket = &connectionPoolBucket{changed: make(chan struct{})}
+		p.buckets[key] = bucket
+	}
+
+	return bucket
+}
+
+func (p *connectionPool) evictIdleConnections() {
+	var (
+		expired []*idleConnection
+		now     = time.Now()
+	)
+
+	p.mutex.Lock()
+
+	for key, bucket := range p.buckets {
+		// idle connections are sorted by release time, so the expired ones are at the beginning
+		n := 0
+		for n < len(bucket.idle) && now.Sub(bucket.idle[n].releasedAt) >= p.idleTimeout {
+			n++
+		}
+
+		if n > 0 {
+			expired = append(expired, bucket.idle[:n]...)
+			bucket.idle = append([]*idleConnection(nil), bucket.idle[n:]...)
+			bucket.open -= uint32(n)
+			bucket.notify()
+			p.metrics.idleConnections.Add(-int64(n))
+		}
+
+		if bucket.open == 0 {
+			delete(p.buckets, key)
+		}
+	}
+
+	p.mutex.Unlock()
+
+	if len(expired) > 0 {
+		p.logger.Debug("closing expired idle connections", zap.Int("total", len(expired)))
+	}
+
+	for _, ic := range expired {
+		p.closeConnection(p.logger, ic.conn)
+	}
+}
+
+func (p *connectionPool) runEvictionLoop() {
+	defer p.wg.Done()
+
+	period := p.idleTimeout / 2
+	if period < time.Second {
+		period = time.Second
+	}
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.evictIdleConnections()
+		case <-p.exitChan:
+			return
+		}
+	}
+}
+
+func (p *connectionPool) Close() error {
+	p.mutex.Lock()
+
+	if p.closed {
+		p.mutex.Unlock()
+
+		return nil
+	}
+
+	p.closed = true
+
+	var idle []*idleConnection
+
+	for _, bucket := range p.buckets {
+		idle = append(idle, bucket.idle...)
+		bucket.open -= uint32(len(bucket.idle))
+		bucket.idle = nil
+		bucket.notify()
+	}
+
+	p.metrics.idleConnections.Set(0)
+	p.mutex.Unlock()
+
+	close(p.exitChan)
+	p.wg.Wait()
+
+	for _, ic := range idle {
+		p.closeConnection(p.logger, ic.conn)
+	}
+
+	return nil
+}
+
+// makeConnectionPoolKey computes the hash of all the data source instance parameters
+// (including credentials) affecting the physical connection.
+func makeConnectionPoolKey(dsi *api_common.TGenericDataSourceInstance) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(dsi)
+	if err != nil {
+		return "", fmt.Errorf("marshal data source instance: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func NewConnectionPool(
+	logger *zap.Logger,
+	cfg *config.TConnectionPoolConfig,
+	registry metrics.Registry,
+) ConnectionPool {
+	p := &connectionPool{
+		logger:              logger,
+		maxOpen:             cfg.MaxOpenConnectionsPerKey,
+		maxIdle:             cfg.MaxIdleConnectionsPerKey,
+		idleTimeout:         common.MustDurationFromString(cfg.IdleTimeout),
+		healthCheckInterval: common.MustDurationFromString(cfg.HealthCheckInterval),
+		healthCheckTimeout:  common.MustDurationFromString(cfg.HealthCheckTimeout),
+		metrics:             newConnectionPoolMetrics(registry),
+		buckets:             make(map[string]*connectionPoolBucket),
+		inUse:               make(map[PooledConnection]string),
+		exitChan:            make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+
+	go p.runEvictionLoop()
+
+	return p
+}
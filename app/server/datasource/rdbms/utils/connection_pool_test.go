@@ -0,0 +1,270 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+type pooledConnectionMock struct {
+	pingErr error
+	closed  atomic.Bool
+	broken  bool
+}
+
+func (c *pooledConnectionMock) Ping(_ context.Context) error { return c.pingErr }
+
+func (c *pooledConnectionMock) Close() error {
+	c.closed.Store(true)
+
+	return nil
+}
+
+func (c *pooledConnectionMock) IsClosed() bool { return c.broken }
+
+type dialerMock struct {
+	dialed atomic.Int32
+}
+
+func (d *dialerMock) dial(_ context.Context) (PooledConnection, error) {
+	d.dialed.Add(1)
+
+	return &pooledConnectionMock{}, nil
+}
+
+var testDataSourceInstance = &api_common.TGenericDataSourceInstance{
+	Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+	Endpoint: &api_common.TGenericEndpoint{Host: "localhost", Port: 5432},
+	Database: "db",
+	Credentials: &api_common.TGenericCredentials{
+		Payload: &api_common.TGenericCredentials_Basic{
+			Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: "password"},
+		},
+	},
+}
+
+func TestConnectionPool(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+
+	t.Run("reuse connection", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1h",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		conn1, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn1)
+
+		conn2, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn2)
+
+		require.Same(t, conn1, conn2)
+		require.Equal(t, int32(1), dialer.dialed.Load())
+		require.False(t, conn1.(*pooledConnectionMock).closed.Load())
+	})
+
+	t.Run("different data source instances", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1h",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		other := proto.Clone(testDataSourceInstance).(*api_common.TGenericDataSourceInstance)
+		other.Database = "other"
+
+		conn1, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn1)
+
+		conn2, err := pool.Acquire(ctx, logger, other, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn2)
+
+		require.NotSame(t, conn1, conn2)
+		require.Equal(t, int32(2), dialer.dialed.Load())
+	})
+
+	t.Run("max idle connections", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1h",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		conn1, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+
+		conn2, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+
+		pool.Release(logger, conn1)
+		pool.Release(logger, conn2)
+
+		require.False(t, conn1.(*pooledConnectionMock).closed.Load())
+		require.True(t, conn2.(*pooledConnectionMock).closed.Load())
+	})
+
+	t.Run("health check failure", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1h",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		conn1, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+
+		conn1.(*pooledConnectionMock).pingErr = errors.New("connection reset by peer")
+		pool.Release(logger, conn1)
+
+		conn2, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn2)
+
+		require.NotSame(t, conn1, conn2)
+		require.True(t, conn1.(*pooledConnectionMock).closed.Load())
+		require.Equal(t, int32(2), dialer.dialed.Load())
+	})
+
+	t.Run("broken connection is not returned", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1h",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		conn, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+
+		conn.(*pooledConnectionMock).broken = true
+		pool.Release(logger, conn)
+
+		require.True(t, conn.(*pooledConnectionMock).closed.Load())
+	})
+
+	t.Run("max open connections", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxOpenConnectionsPerKey: 1,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1h",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		conn1, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+
+		// the limit is reached, so the request waits until its context expires
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err = pool.Acquire(waitCtx, logger, testDataSourceInstance, dialer.dial)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// the request gets the released connection
+		acquired := make(chan PooledConnection)
+
+		go func() {
+			conn, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+			require.NoError(t, err)
+			acquired <- conn
+		}()
+
+		pool.Release(logger, conn1)
+
+		conn2 := <-acquired
+		require.Same(t, conn1, conn2)
+		require.Equal(t, int32(1), dialer.dialed.Load())
+
+		pool.Release(logger, conn2)
+	})
+
+	t.Run("idle timeout", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1ms",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		conn, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn)
+
+		time.Sleep(10 * time.Millisecond)
+		pool.(*connectionPool).evictIdleConnections()
+
+		require.True(t, conn.(*pooledConnectionMock).closed.Load())
+	})
+
+	t.Run("close", func(t *testing.T) {
+		pool := NewConnectionPool(logger, &config.TConnectionPoolConfig{
+			Enabled:                  true,
+			MaxIdleConnectionsPerKey: 1,
+			IdleTimeout:              "1h",
+			HealthCheckInterval:      "0s",
+			HealthCheckTimeout:       "1s",
+		}, nop.Registry{})
+		defer func() { require.NoError(t, pool.Close()) }()
+		dialer := &dialerMock{}
+
+		idle, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, idle)
+
+		inUse, err := pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.NoError(t, err)
+		require.Same(t, idle, inUse)
+
+		require.NoError(t, pool.Close())
+
+		// connections that are in use are closed during the release
+		require.False(t, inUse.(*pooledConnectionMock).closed.Load())
+		pool.Release(logger, inUse)
+		require.True(t, inUse.(*pooledConnectionMock).closed.Load())
+
+		_, err = pool.Acquire(ctx, logger, testDataSourceInstance, dialer.dial)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// KeyRangeTimestampFormatter renders a timestamp key range bound as a literal of a particular SQL dialect.
+type KeyRangeTimestampFormatter func(t time.Time) string
+
+// RenderSelectQueryTextForKeyRange composes the query text reading the key range
+// from the split description. Splits without description are rendered as is.
+func RenderSelectQueryTextForKeyRange(
+	formatter SQLFormatter,
+	formatTimestamp KeyRangeTimestampFormatter,
+	parts *SelectQueryParts,
+	split *api_service_protos.TSplit,
+) (string, error) {
+	if len(split.GetDescription()) == 0 {
+		return DefaultSelectQueryRender(parts)
+	}
+
+	var description TKeyRangeSplitDescription
+
+	if err := protojson.Unmarshal(split.GetDescription(), &description); err != nil {
+		return "", fmt.Errorf("unmarshal split description: %w", err)
+	}
+
+	rangeClause, err := formatKeyRangeClause(formatter, formatTimestamp, &description)
+	if err != nil {
+		return "", fmt.Errorf("format key range clause: %w", err)
+	}
+
+	modifiedParts := *parts
+
+	switch {
+	case rangeClause == "":
+	case parts.WhereClause == "":
+		modifiedParts.WhereClause = rangeClause
+	default:
+		modifiedParts.WhereClause = rangeClause + " AND (" + parts.WhereClause + ")"
+	}
+
+	return DefaultSelectQueryRender(&modifiedParts)
+}
+
+func formatKeyRangeClause(
+	formatter SQLFormatter,
+	formatTimestamp KeyRangeTimestampFormatter,
+	description *TKeyRangeSplitDescription,
+) (string, error) {
+	if description.ColumnName == "" {
+		return "", fmt.Errorf("empty key column name")
+	}
+
+	column := formatter.SanitiseIdentifier(description.ColumnName)
+
+	var conditions []string
+
+	if description.LowerBound != nil {
+		value, err := formatKeyRangeBound(description.LowerBound, formatTimestamp)
+		if err != nil {
+			return "", fmt.Errorf("format lower bound: %w", err)
+		}
+
+		conditions = append(conditions, fmt.Sprintf("(%s >= %s)", column, value))
+	}
+
+	if description.UpperBound != nil {
+		value, err := formatKeyRangeBound(description.UpperBound, formatTimestamp)
+		if err != nil {
+			return "", fmt.Errorf("format upper bound: %w", err)
+		}
+
+		// The key column may be nullable, and NULL values do not satisfy any range condition,
+		// so they are read within the first split.
+		if description.LowerBound == nil {
+			conditions = append(conditions, fmt.Sprintf("((%s < %s) OR (%s IS NULL))", column, value, column))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%s < %s)", column, value))
+		}
+	}
+
+	return strings.Join(conditions, " AND "), nil
+}
+
+func formatKeyRangeBound(
+	bound *TKeyRangeSplitDescription_TBound,
+	formatTimestamp KeyRangeTimestampFormatter,
+) (string, error) {
+	switch v := bound.GetPayload().(type) {
+	case *TKeyRangeSplitDescription_TBound_Int64Value:
+		return strconv.FormatInt(v.Int64Value, 10), nil
+	case *TKeyRangeSplitDescription_TBound_Uint64Value:
+		return strconv.FormatUint(v.Uint64Value, 10), nil
+	case *TKeyRangeSplitDescription_TBound_TimestampValue:
+		return formatTimestamp(time.UnixMicro(v.TimestampValue).UTC()), nil
+	default:
+		return "", fmt.Errorf("unexpected bound type: %T", v)
+	}
+}
+
+// SplitKeyRangeEvenly returns the bounds dividing the [lower, upper] range
+// into (at most) n subranges of equal width. Both ends must be of the same type.
+func SplitKeyRangeEvenly(
+	lower, upper *TKeyRangeSplitDescription_TBound,
+	n uint64,
+) ([]*TKeyRangeSplitDescription_TBound, error) {
+	if fmt.Sprintf("%T", lower.GetPayload()) != fmt.Sprintf("%T", upper.GetPayload()) {
+		return nil, fmt.Errorf("bound types mismatch: %T and %T", lower.GetPayload(), upper.GetPayload())
+	}
+
+	lo, makeBound, err := keyRangeBoundToBigInt(lower)
+	if err != nil {
+		return nil, fmt.Errorf("lower bound: %w", err)
+	}
+
+	hi, _, err := keyRangeBoundToBigInt(upper)
+	if err != nil {
+		return nil, fmt.Errorf("upper bound: %w", err)
+	}
+
+	if n < 2 || hi.Cmp(lo) <= 0 {
+		return nil, nil
+	}
+
+	var (
+		width  = new(big.Int).Sub(hi, lo)
+		prev   = lo
+		bounds []*TKeyRangeSplitDescription_TBound
+	)
+
+	for i := uint64(1); i < n; i++ {
+		// lo + width * i / n
+		value := new(big.Int).Mul(width, new(big.Int).SetUint64(i))
+		value.Quo(value, new(big.Int).SetUint64(n))
+		value.Add(value, lo)
+
+		// small ranges cannot be divided into n parts
+		if value.Cmp(prev) <= 0 {
+			continue
+		}
+
+		bounds = append(bounds, makeBound(value))
+		prev = value
+	}
+
+	return bounds, nil
+}
+
+func keyRangeBoundToBigInt(
+	bound *TKeyRangeSplitDescription_TBound,
+) (*big.Int, func(*big.Int) *TKeyRangeSplitDescription_TBound, error) {
+	switch v := bound.GetPayload().(type) {
+	case *TKeyRangeSplitDescription_TBound_Int64Value:
+		return big.NewInt(v.Int64Value), func(x *big.Int) *TKeyRangeSplitDescription_TBound {
+			return NewInt64KeyRangeBound(x.Int64())
+		}, nil
+	case *TKeyRangeSplitDescription_TBound_Uint64Value:
+		return new(big.Int).SetUint64(v.Uint64Value), func(x *big.Int) *TKeyRangeSplitDescription_TBound {
+			return NewUint64KeyRangeBound(x.Uint64())
+		}, nil
+	case *TKeyRangeSplitDescription_TBound_TimestampValue:
+		return big.NewInt(v.TimestampValue), func(x *big.Int) *TKeyRangeSplitDescription_TBound {
+			return NewTimestampKeyRangeBound(time.UnixMicro(x.Int64()))
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected bound type: %T", v)
+	}
+}
+
+func NewInt64KeyRangeBound(value int64) *TKeyRangeSplitDescription_TBound {
+	return &TKeyRangeSplitDescription_TBound{
+		Payload: &TKeyRangeSplitDescription_TBound_Int64Value{Int64Value: value},
+	}
+}
+
+func NewUint64KeyRangeBound(value uint64) *TKeyRangeSplitDescription_TBound {
+	return &TKeyRangeSplitDescription_TBound{
+		Payload: &TKeyRangeSplitDescription_TBound_Uint64Value{Uint64Value: value},
+	}
+}
+
+func NewTimestampKeyRangeBound(value time.Time) *TKeyRangeSplitDescription_TBound {
+	return &TKeyRangeSplitDescription_TBound{
+		Payload: &TKeyRangeSplitDescription_TBound_TimestampValue{TimestampValue: value.UnixMicro()},
+	}
+}
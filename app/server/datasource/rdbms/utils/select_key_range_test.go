@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+type keyRangeTestFormatter struct {
+	SQLFormatter
+}
+
+func (keyRangeTestFormatter) SanitiseIdentifier(ident string) string {
+	return fmt.Sprintf(`"%s"`, ident)
+}
+
+func formatTestTimestamp(t time.Time) string {
+	return "'" + t.Format(time.RFC3339) + "'"
+}
+
+func TestRenderSelectQueryTextForKeyRange(t *testing.T) {
+	type testCase struct {
+		testName    string
+		description *TKeyRangeSplitDescription
+		whereClause string
+		outputQuery string
+	}
+
+	tcs := []testCase{
+		{
+			testName:    "no_description",
+			description: nil,
+			whereClause: `("col" = ?)`,
+			outputQuery: `SELECT "col" FROM "tab" WHERE ("col" = ?)`,
+		},
+		{
+			testName: "first_range",
+			description: &TKeyRangeSplitDescription{
+				ColumnName: "id",
+				UpperBound: NewInt64KeyRangeBound(-10),
+			},
+			outputQuery: `SELECT "col" FROM "tab" WHERE (("id" < -10) OR ("id" IS NULL))`,
+		},
+		{
+			testName: "middle_range_with_where",
+			description: &TKeyRangeSplitDescription{
+				ColumnName: "id",
+				LowerBound: NewUint64KeyRangeBound(10),
+				UpperBound: NewUint64KeyRangeBound(20),
+			},
+			whereClause: `("col" = ?)`,
+			outputQuery: `SELECT "col" FROM "tab" WHERE ("id" >= 10) AND ("id" < 20) AND (("col" = ?))`,
+		},
+		{
+			testName: "last_range_timestamp",
+			description: &TKeyRangeSplitDescription{
+				ColumnName: "ts",
+				LowerBound: NewTimestampKeyRangeBound(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			},
+			outputQuery: `SELECT "col" FROM "tab" WHERE ("ts" >= '2024-01-02T03:04:05Z')`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{}
+
+			if tc.description != nil {
+				data, err := protojson.Marshal(tc.description)
+				require.NoError(t, err)
+
+				split.Payload = &api_service_protos.TSplit_Description{Description: data}
+			}
+
+			parts := &SelectQueryParts{
+				SelectClause: `"col"`,
+				FromClause:   `"tab"`,
+				WhereClause:  tc.whereClause,
+			}
+
+			queryText, err := RenderSelectQueryTextForKeyRange(keyRangeTestFormatter{}, formatTestTimestamp, parts, split)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, queryText)
+		})
+	}
+}
+
+func TestSplitKeyRangeEvenly(t *testing.T) {
+	type testCase struct {
+		testName string
+		lower    *TKeyRangeSplitDescription_TBound
+		upper    *TKeyRangeSplitDescription_TBound
+		n        uint64
+		bounds   []*TKeyRangeSplitDescription_TBound
+	}
+
+	tcs := []testCase{
+		{
+			testName: "int64",
+			lower:    NewInt64KeyRangeBound(-100),
+			upper:    NewInt64KeyRangeBound(100),
+			n:        4,
+			bounds: []*TKeyRangeSplitDescription_TBound{
+				NewInt64KeyRangeBound(-50),
+				NewInt64KeyRangeBound(0),
+				NewInt64KeyRangeBound(50),
+			},
+		},
+		{
+			testName: "uint64_overflow",
+			lower:    NewUint64KeyRangeBound(0),
+			upper:    NewUint64KeyRangeBound(1<<64 - 1),
+			n:        2,
+			bounds: []*TKeyRangeSplitDescription_TBound{
+				NewUint64KeyRangeBound(1<<63 - 1),
+			},
+		},
+		{
+			testName: "narrow_range",
+			lower:    NewInt64KeyRangeBound(1),
+			upper:    NewInt64KeyRangeBound(3),
+			n:        10,
+			bounds: []*TKeyRangeSplitDescription_TBound{
+				NewInt64KeyRangeBound(2),
+			},
+		},
+		{
+			testName: "single_value",
+			lower:    NewInt64KeyRangeBound(1),
+			upper:    NewInt64KeyRangeBound(1),
+			n:        10,
+			bounds:   nil,
+		},
+		{
+			testName: "timestamp",
+			lower:    NewTimestampKeyRangeBound(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			upper:    NewTimestampKeyRangeBound(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+			n:        2,
+			bounds: []*TKeyRangeSplitDescription_TBound{
+				NewTimestampKeyRangeBound(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			bounds, err := SplitKeyRangeEvenly(tc.lower, tc.upper, tc.n)
+			require.NoError(t, err)
+			require.Len(t, bounds, len(tc.bounds))
+
+			for i := range bounds {
+				require.Equal(t, tc.bounds[i].String(), bounds[i].String())
+			}
+		})
+	}
+
+	t.Run("type_mismatch", func(t *testing.T) {
+		_, err := SplitKeyRangeEvenly(NewInt64KeyRangeBound(0), NewUint64KeyRangeBound(1), 2)
+		require.Error(t, err)
+	})
+}
+
+func TestEstimateSplitCount(t *testing.T) {
+	require.Equal(t, uint64(1), EstimateSplitCount(0, 100, 10))
+	require.Equal(t, uint64(1), EstimateSplitCount(100, 100, 10))
+	require.Equal(t, uint64(2), EstimateSplitCount(101, 100, 10))
+	require.Equal(t, uint64(10), EstimateSplitCount(100500, 100, 10))
+}
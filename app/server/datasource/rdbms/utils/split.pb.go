@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v3.12.4
+// source: app/server/datasource/rdbms/utils/split.proto
+
+package utils
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TKeyRangeSplitDescription describes a part of a relational table
+// containing the rows with the key column values belonging to a certain range.
+// It can be shared by any data source capable of range scans over the table key.
+type TKeyRangeSplitDescription struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the key column
+	ColumnName string `protobuf:"bytes,1,opt,name=column_name,json=columnName,proto3" json:"column_name,omitempty"`
+	// Lower bound of the range (inclusive).
+	// Not set for the first range of a table.
+	LowerBound *TKeyRangeSplitDescription_TBound `protobuf:"bytes,2,opt,name=lower_bound,json=lowerBound,proto3" json:"lower_bound,omitempty"`
+	// Upper bound of the range (exclusive).
+	// Not set for the last range of a table.
+	UpperBound    *TKeyRangeSplitDescription_TBound `protobuf:"bytes,3,opt,name=upper_bound,json=upperBound,proto3" json:"upper_bound,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TKeyRangeSplitDescription) Reset() {
+	*x = TKeyRangeSplitDescription{}
+	mi := &file_app_server_datasource_rdbms_utils_split_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TKeyRangeSplitDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TKeyRangeSplitDescription) ProtoMessage() {}
+
+func (x *TKeyRangeSplitDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_app_server_datasource_rdbms_utils_split_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TKeyRangeSplitDescription.ProtoReflect.Descriptor instead.
+func (*TKeyRangeSplitDescription) Descriptor() ([]byte, []int) {
+	return file_app_server_datasource_rdbms_utils_split_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TKeyRangeSplitDescription) GetColumnName() string {
+	if x != nil {
+		return x.ColumnName
+	}
+	return ""
+}
+
+func (x *TKeyRangeSplitDescription) GetLowerBound() *TKeyRangeSplitDescription_TBound {
+	if x != nil {
+		return x.LowerBound
+	}
+	return nil
+}
+
+func (x *TKeyRangeSplitDescription) GetUpperBound() *TKeyRangeSplitDescription_TBound {
+	if x != nil {
+		return x.UpperBound
+	}
+	return nil
+}
+
+// TBound is a value of the key column
+type TKeyRangeSplitDescription_TBound struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*TKeyRangeSplitDescription_TBound_Int64Value
+	//	*TKeyRangeSplitDescription_TBound_Uint64Value
+	//	*TKeyRangeSplitDescription_TBound_TimestampValue
+	Payload       isTKeyRangeSplitDescription_TBound_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TKeyRangeSplitDescription_TBound) Reset() {
+	*x = TKeyRangeSplitDescription_TBound{}
+	mi := &file_app_server_datasource_rdbms_utils_split_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TKeyRangeSplitDescription_TBound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TKeyRangeSplitDescription_TBound) ProtoMessage() {}
+
+func (x *TKeyRangeSplitDescription_TBound) ProtoReflect() protoreflect.Message {
+	mi := &file_app_server_datasource_rdbms_utils_split_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TKeyRangeSplitDescription_TBound.ProtoReflect.Descriptor instead.
+func (*TKeyRangeSplitDescription_TBound) Descriptor() ([]byte, []int) {
+	return file_app_server_datasource_rdbms_utils_split_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *TKeyRangeSplitDescription_TBound) GetPayload() isTKeyRangeSplitDescription_TBound_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *TKeyRangeSplitDescription_TBound) GetInt64Value() int64 {
+	if x != nil {
+		if x, ok := x.Payload.(*TKeyRangeSplitDescription_TBound_Int64Value); ok {
+			return x.Int64Value
+		}
+	}
+	return 0
+}
+
+func (x *TKeyRangeSplitDescription_TBound) GetUint64Value() uint64 {
+	if x != nil {
+		if x, ok := x.Payload.(*TKeyRangeSplitDescription_TBound_Uint64Value); ok {
+			return x.Uint64Value
+		}
+	}
+	return 0
+}
+
+func (x *TKeyRangeSplitDescription_TBound) GetTimestampValue() int64 {
+	if x != nil {
+		if x, ok := x.Payload.(*TKeyRangeSplitDescription_TBound_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return 0
+}
+
+type isTKeyRangeSplitDescription_TBound_Payload interface {
+	isTKeyRangeSplitDescription_TBound_Payload()
+}
+
+type TKeyRangeSplitDescription_TBound_Int64Value struct {
+	Int64Value int64 `protobuf:"varint,1,opt,name=int64_value,json=int64Value,proto3,oneof"`
+}
+
+type TKeyRangeSplitDescription_TBound_Uint64Value struct {
+	Uint64Value uint64 `protobuf:"varint,2,opt,name=uint64_value,json=uint64Value,proto3,oneof"`
+}
+
+type TKeyRangeSplitDescription_TBound_TimestampValue struct {
+	// Microseconds since Unix epoch (UTC)
+	TimestampValue int64 `protobuf:"varint,3,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+func (*TKeyRangeSplitDescription_TBound_Int64Value) isTKeyRangeSplitDescription_TBound_Payload() {}
+
+func (*TKeyRangeSplitDescription_TBound_Uint64Value) isTKeyRangeSplitDescription_TBound_Payload() {}
+
+func (*TKeyRangeSplitDescription_TBound_TimestampValue) isTKeyRangeSplitDescription_TBound_Payload() {
+}
+
+var File_app_server_datasource_rdbms_utils_split_proto protoreflect.FileDescriptor
+
+var file_app_server_datasource_rdbms_utils_split_proto_rawDesc = string([]byte{
+	0x0a, 0x2d, 0x61, 0x70, 0x70, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2f, 0x72, 0x64, 0x62, 0x6d, 0x73, 0x2f, 0x75, 0x74,
+	0x69, 0x6c, 0x73, 0x2f, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x30, 0x4e, 0x59, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x41, 0x70, 0x70, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x44, 0x42, 0x4d, 0x53, 0x2e, 0x55, 0x74, 0x69, 0x6c,
+	0x73, 0x22, 0xaf, 0x03, 0x0a, 0x19, 0x54, 0x4b, 0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53,
+	0x70, 0x6c, 0x69, 0x74, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x73, 0x0a, 0x0b, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x52, 0x2e, 0x4e, 0x59, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x44, 0x42,
+	0x4d, 0x53, 0x2e, 0x55, 0x74, 0x69, 0x6c, 0x73, 0x2e, 0x54, 0x4b, 0x65, 0x79, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x54, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x0a, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x42, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x73, 0x0a, 0x0b, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x52, 0x2e, 0x4e, 0x59, 0x71,
+	0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x52, 0x44, 0x42, 0x4d, 0x53, 0x2e, 0x55, 0x74, 0x69, 0x6c, 0x73, 0x2e, 0x54, 0x4b,
+	0x65, 0x79, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x0a,
+	0x75, 0x70, 0x70, 0x65, 0x72, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x1a, 0x86, 0x01, 0x0a, 0x06, 0x54,
+	0x42, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e,
+	0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x75, 0x69, 0x6e, 0x74,
+	0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00,
+	0x52, 0x0b, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x29, 0x0a,
+	0x0f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x42, 0x4c, 0x5a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x79, 0x64, 0x62, 0x2d, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x66,
+	0x71, 0x2d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2d, 0x67, 0x6f, 0x2f, 0x61,
+	0x70, 0x70, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x2f, 0x72, 0x64, 0x62, 0x6d, 0x73, 0x2f, 0x75, 0x74, 0x69, 0x6c, 0x73,
+	0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_app_server_datasource_rdbms_utils_split_proto_rawDescOnce sync.Once
+	file_app_server_datasource_rdbms_utils_split_proto_rawDescData []byte
+)
+
+func file_app_server_datasource_rdbms_utils_split_proto_rawDescGZIP() []byte {
+	file_app_server_datasource_rdbms_utils_split_proto_rawDescOnce.Do(func() {
+		file_app_server_datasource_rdbms_utils_split_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_app_server_datasource_rdbms_utils_split_proto_rawDesc), len(file_app_server_datasource_rdbms_utils_split_proto_rawDesc)))
+	})
+	return file_app_server_datasource_rdbms_utils_split_proto_rawDescData
+}
+
+var file_app_server_datasource_rdbms_utils_split_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_app_server_datasource_rdbms_utils_split_proto_goTypes = []any{
+	(*TKeyRangeSplitDescription)(nil),        // 0: NYql.Connector.App.Server.DataSource.RDBMS.Utils.TKeyRangeSplitDescription
+	(*TKeyRangeSplitDescription_TBound)(nil), // 1: NYql.Connector.App.Server.DataSource.RDBMS.Utils.TKeyRangeSplitDescription.TBound
+}
+var file_app_server_datasource_rdbms_utils_split_proto_depIdxs = []int32{
+	1, // 0: NYql.Connector.App.Server.DataSource.RDBMS.Utils.TKeyRangeSplitDescription.lower_bound:type_name -> NYql.Connector.App.Server.DataSource.RDBMS.Utils.TKeyRangeSplitDescription.TBound
+	1, // 1: NYql.Connector.App.Server.DataSource.RDBMS.Utils.TKeyRangeSplitDescription.upper_bound:type_name -> NYql.Connector.App.Server.DataSource.RDBMS.Utils.TKeyRangeSplitDescription.TBound
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_app_server_datasource_rdbms_utils_split_proto_init() }
+func file_app_server_datasource_rdbms_utils_split_proto_init() {
+	if File_app_server_datasource_rdbms_utils_split_proto != nil {
+		return
+	}
+	file_app_server_datasource_rdbms_utils_split_proto_msgTypes[1].OneofWrappers = []any{
+		(*TKeyRangeSplitDescription_TBound_Int64Value)(nil),
+		(*TKeyRangeSplitDescription_TBound_Uint64Value)(nil),
+		(*TKeyRangeSplitDescription_TBound_TimestampValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_app_server_datasource_rdbms_utils_split_proto_rawDesc), len(file_app_server_datasource_rdbms_utils_split_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_app_server_datasource_rdbms_utils_split_proto_goTypes,
+		DependencyIndexes: file_app_server_datasource_rdbms_utils_split_proto_depIdxs,
+		MessageInfos:      file_app_server_datasource_rdbms_utils_split_proto_msgTypes,
+	}.Build()
+	File_app_server_datasource_rdbms_utils_split_proto = out.File
+	file_app_server_datasource_rdbms_utils_split_proto_goTypes = nil
+	file_app_server_datasource_rdbms_utils_split_proto_depIdxs = nil
+}
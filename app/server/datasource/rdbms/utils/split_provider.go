@@ -1,9 +1,5 @@
 package utils
 
-import (
-	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
-)
-
 var _ SplitProvider = (*defaultSplitProvider)(nil)
 
 type defaultSplitProvider struct{}
@@ -12,13 +8,7 @@ func (defaultSplitProvider) ListSplits(
 	params *ListSplitsParams,
 ) error {
 	// By default we deny table splitting
-	select {
-	case params.ResultChan <- &datasource.ListSplitResult{Slct: params.Select, Description: nil}:
-	case <-params.Ctx.Done():
-		return params.Ctx.Err()
-	}
-
-	return nil
+	return listSingleSplit(params.Ctx, params.Select, params.ResultChan)
 }
 
 func NewDefaultSplitProvider() SplitProvider {
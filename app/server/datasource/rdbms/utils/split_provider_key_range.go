@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+)
+
+type KeyRangeSampleParams struct {
+	Ctx    context.Context
+	Logger *zap.Logger
+	Conn   Connection
+	// Desired number of rows in a single split
+	RowsPerSplit uint64
+	// Maximum number of splits the table can be divided into
+	MaxSplits uint64
+}
+
+type KeyRangeSample struct {
+	// Name of the column the table will be split by
+	ColumnName string
+	// Sorted list of the key values dividing the table into the ranges.
+	// N bounds produce N+1 splits.
+	Bounds []*TKeyRangeSplitDescription_TBound
+}
+
+// KeyRangeSampler discovers the key column of a table and
+// estimates the distribution of its values with the help of database statistics.
+type KeyRangeSampler interface {
+	// SampleKeyRanges returns nil if the table cannot be split
+	// (there is no suitable key column or the table is too small).
+	SampleKeyRanges(params *KeyRangeSampleParams) (*KeyRangeSample, error)
+}
+
+var _ SplitProvider = (*keyRangeSplitProvider)(nil)
+
+type keyRangeSplitProvider struct {
+	cfg     *config.TKeyRangeSplittingConfig
+	sampler KeyRangeSampler
+}
+
+func (s *keyRangeSplitProvider) ListSplits(params *ListSplitsParams) error {
+	if !s.cfg.Enabled {
+		return listSingleSplit(params.Ctx, params.Select, params.ResultChan)
+	}
+
+	sample, err := s.sampleKeyRanges(params)
+	if err != nil {
+		// Statistics may be unavailable because of insufficient privileges,
+		// but it's still possible to read the table within a single split.
+		params.Logger.Warn("unable to sample key ranges, fallback to single split per table", zap.Error(err))
+
+		return listSingleSplit(params.Ctx, params.Select, params.ResultChan)
+	}
+
+	if sample == nil || len(sample.Bounds) == 0 {
+		params.Logger.Info("table will not be split")
+
+		return listSingleSplit(params.Ctx, params.Select, params.ResultChan)
+	}
+
+	// the bounds would reveal the values of the hidden or masked column to the client
+	if params.IsColumnRestricted != nil && params.IsColumnRestricted(sample.ColumnName) {
+		params.Logger.Info("table will not be split, because key column is restricted by data policy", zap.String("column", sample.ColumnName))
+
+		return listSingleSplit(params.Ctx, params.Select, params.ResultChan)
+	}
+
+	params.Logger.Info(
+		"table will be split by key ranges",
+		zap.String("column", sample.ColumnName),
+		zap.Int("splits", len(sample.Bounds)+1),
+	)
+
+	for i := 0; i <= len(sample.Bounds); i++ {
+		description := &TKeyRangeSplitDescription{
+			ColumnName: sample.ColumnName,
+		}
+
+		if i > 0 {
+			description.LowerBound = sample.Bounds[i-1]
+		}
+
+		if i < len(sample.Bounds) {
+			description.UpperBound = sample.Bounds[i]
+		}
+
+		select {
+		case params.ResultChan <- &datasource.ListSplitResult{Slct: params.Select, Description: description}:
+		case <-params.Ctx.Done():
+			return params.Ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (s *keyRangeSplitProvider) sampleKeyRanges(params *ListSplitsParams) (*KeyRangeSample, error) {
+	var cs []Connection
+
+	err := params.MakeConnectionRetrier.Run(params.Ctx, params.Logger,
+		func() error {
+			var makeConnErr error
+
+			makeConnectionParams := &ConnectionParams{
+				Ctx:                params.Ctx,
+				Logger:             params.Logger,
+				DataSourceInstance: params.Select.GetDataSourceInstance(),
+				TableName:          params.Select.GetFrom().GetTable(),
+				QueryPhase:         QueryPhaseListSplits,
+			}
+
+			cs, makeConnErr = params.ConnectionManager.Make(makeConnectionParams)
+			if makeConnErr != nil {
+				return fmt.Errorf("make connection: %w", makeConnErr)
+			}
+
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("retry: %w", err)
+	}
+
+	defer params.ConnectionManager.Release(params.Ctx, params.Logger, cs)
+
+	maxSplits := uint64(s.cfg.MaxSplits)
+	if limit := uint64(params.Request.GetMaxSplitCount()); limit != 0 && limit < maxSplits {
+		maxSplits = limit
+	}
+
+	if maxSplits < 2 {
+		return nil, nil
+	}
+
+	sample, err := s.sampler.SampleKeyRanges(&KeyRangeSampleParams{
+		Ctx:          params.Ctx,
+		Logger:       params.Logger,
+		Conn:         cs[0],
+		RowsPerSplit: s.cfg.RowsPerSplit,
+		MaxSplits:    maxSplits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sample key ranges: %w", err)
+	}
+
+	return sample, nil
+}
+
+func listSingleSplit(
+	ctx context.Context,
+	slct *api_service_protos.TSelect,
+	resultChan chan<- *datasource.ListSplitResult,
+) error {
+	select {
+	case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: nil}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// EstimateSplitCount returns the number of splits for a table containing totalRows rows.
+func EstimateSplitCount(totalRows, rowsPerSplit, maxSplits uint64) uint64 {
+	if rowsPerSplit == 0 {
+		return 1
+	}
+
+	splits := (totalRows + rowsPerSplit - 1) / rowsPerSplit
+
+	if splits > maxSplits {
+		splits = maxSplits
+	}
+
+	if splits == 0 {
+		splits = 1
+	}
+
+	return splits
+}
+
+func NewKeyRangeSplitProvider(cfg *config.TKeyRangeSplittingConfig, sampler KeyRangeSampler) SplitProvider {
+	return &keyRangeSplitProvider{
+		cfg:     cfg,
+		sampler: sampler,
+	}
+}
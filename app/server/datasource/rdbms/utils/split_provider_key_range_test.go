@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type keyRangeSamplerStub struct {
+	sample *KeyRangeSample
+}
+
+func (s keyRangeSamplerStub) SampleKeyRanges(*KeyRangeSampleParams) (*KeyRangeSample, error) {
+	return s.sample, nil
+}
+
+func TestKeyRangeSplitProvider(t *testing.T) {
+	provider := NewKeyRangeSplitProvider(
+		&config.TKeyRangeSplittingConfig{Enabled: true, RowsPerSplit: 100, MaxSplits: 10},
+		keyRangeSamplerStub{sample: &KeyRangeSample{
+			ColumnName: "id",
+			Bounds: []*TKeyRangeSplitDescription_TBound{
+				{Payload: &TKeyRangeSplitDescription_TBound_Int64Value{Int64Value: 100}},
+			},
+		}},
+	)
+
+	listSplits := func(isColumnRestricted func(column string) bool) []*datasource.ListSplitResult {
+		connectionManager := &ConnectionManagerMock{}
+		connectionManager.On("Make", mock.Anything).Return([]Connection{&ConnectionMock{}}, nil).Once()
+		connectionManager.On("Release", mock.Anything).Return().Once()
+
+		resultChan := make(chan *datasource.ListSplitResult, 10)
+
+		err := provider.ListSplits(&ListSplitsParams{
+			Ctx:                   context.Background(),
+			Logger:                common.NewTestLogger(t),
+			MakeConnectionRetrier: retry.NewRetrierNoop(),
+			ConnectionManager:     connectionManager,
+			Request:               &api_service_protos.TListSplitsRequest{},
+			Select:                &api_service_protos.TSelect{From: &api_service_protos.TSelect_TFrom{Table: "users"}},
+			ResultChan:            resultChan,
+			IsColumnRestricted:    isColumnRestricted,
+		})
+		require.NoError(t, err)
+		close(resultChan)
+
+		var results []*datasource.ListSplitResult
+		for result := range resultChan {
+			results = append(results, result)
+		}
+
+		return results
+	}
+
+	results := listSplits(nil)
+	require.Len(t, results, 2)
+	require.Equal(t, "id", results[0].Description.(*TKeyRangeSplitDescription).ColumnName)
+
+	// the bounds of the restricted column are not revealed, the table is read within a single split
+	results = listSplits(func(column string) bool { return column == "id" })
+	require.Len(t, results, 1)
+	require.Nil(t, results[0].Description)
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"context"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -37,6 +39,30 @@ type Connection interface {
 	Close() error
 }
 
+// QueryExplainer is implemented by the connections able to capture the execution plan of a query
+type QueryExplainer interface {
+	// Explain returns the execution plan of the query in a human-readable form.
+	Explain(params *QueryParams) (string, error)
+}
+
+// ArrowQuerier is implemented by the connections able to obtain the query results in Arrow format,
+// so that the record batches are passed to the sink without row-by-row transformation.
+type ArrowQuerier interface {
+	// CanQueryArrow tells if the query returning the columns of given types can be run via QueryArrow.
+	CanQueryArrow(params *QueryParams, ydbTypes []*Ydb.Type) bool
+	// QueryArrow runs a query returning the records with the columns already converted to the YDB-mapped Arrow types.
+	QueryArrow(params *QueryParams, ydbTypes []*Ydb.Type, cc conversion.Collection) (ArrowRows, error)
+}
+
+// ArrowRows iterates over the record batches returned by the query
+type ArrowRows interface {
+	Close() error
+	Err() error
+	Next() bool
+	// Record returns the current batch, it's valid until the next call of Next or Close
+	Record() arrow.Record
+}
+
 type Rows interface {
 	Close() error
 	Err() error
@@ -75,6 +101,12 @@ type ConnectionManager interface {
 
 type ConnectionManagerBase struct {
 	QueryLoggerFactory common.QueryLoggerFactory
+	// ConnectionPool is optional: if it's nil, every physical connection
+	// is opened for a single request and closed during the release.
+	ConnectionPool ConnectionPool
+	// ArrowAllocator is used by the connections that read data in Arrow format,
+	// so that the memory of the record batches is taken into account.
+	ArrowAllocator memory.Allocator
 }
 
 type SelectQueryParts struct {
@@ -121,6 +153,9 @@ type ListSplitsParams struct {
 	// Interface implementations should not close this channel, just return from the function
 	// when the data is over.
 	ResultChan chan<- *datasource.ListSplitResult
+	// IsColumnRestricted reports whether the values of the column must not appear in the split descriptions
+	// because of the data policy; nil if there are no restrictions
+	IsColumnRestricted func(column string) bool
 }
 
 // SplitProvider generates stream of splits - the description of the parts of a large external table
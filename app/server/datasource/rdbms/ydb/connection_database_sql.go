@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"time"
 
 	"go.uber.org/zap"
 
@@ -43,10 +42,11 @@ func (r rowsDatabaseSQL) MakeTransformer(ydbTypes []*Ydb.Type, cc conversion.Col
 }
 
 var _ rdbms_utils.Connection = (*connectionDatabaseSQL)(nil)
+var _ rdbms_utils.QueryExplainer = (*connectionDatabaseSQL)(nil)
 
 type connectionDatabaseSQL struct {
 	*sql.DB
-	driver             *ydb_sdk.Driver
+	driver             *pooledDriver
 	queryLogger        common.QueryLogger
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
@@ -76,8 +76,24 @@ func (c *connectionDatabaseSQL) Query(params *rdbms_utils.QueryParams) (rdbms_ut
 	return rowsDatabaseSQL{Rows: out}, nil
 }
 
+func (c *connectionDatabaseSQL) Explain(params *rdbms_utils.QueryParams) (string, error) {
+	var ast, plan string
+
+	// in explain mode the driver returns a single row containing query AST and plan
+	err := c.DB.QueryRowContext(
+		ydb_sdk.WithQueryMode(params.Ctx, ydb_sdk.ExplainQueryMode),
+		params.QueryText,
+		params.QueryArgs.Values()...,
+	).Scan(&ast, &plan)
+	if err != nil {
+		return "", fmt.Errorf("query row context: %w", err)
+	}
+
+	return plan, nil
+}
+
 func (c *connectionDatabaseSQL) Driver() *ydb_sdk.Driver {
-	return c.driver
+	return c.driver.Driver
 }
 
 func (c *connectionDatabaseSQL) DataSourceInstance() *api_common.TGenericDataSourceInstance {
@@ -90,11 +106,7 @@ func (c *connectionDatabaseSQL) TableName() string {
 
 func (c *connectionDatabaseSQL) Close() error {
 	err1 := c.DB.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
-
-	err2 := c.driver.Close(ctx)
+	err2 := c.driver.Close()
 
 	if err1 != nil || err2 != nil {
 		return fmt.Errorf("connection close err: %w; driver close err: %w", err1, err2)
@@ -114,10 +126,10 @@ func newConnectionDatabaseSQL(
 	cfg *config.TYdbConfig,
 	dsi *api_common.TGenericDataSourceInstance,
 	tableName string,
-	ydbDriver *ydb_sdk.Driver,
+	ydbDriver *pooledDriver,
 ) (Connection, error) {
 	ydbConn, err := ydb_sdk.Connector(
-		ydbDriver,
+		ydbDriver.Driver,
 		ydb_sdk.WithAutoDeclare(),
 		ydb_sdk.WithPositionalArgs(),
 		ydb_sdk.WithTablePathPrefix(dsi.Database),
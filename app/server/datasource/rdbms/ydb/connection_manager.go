@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -24,12 +25,37 @@ type Connection interface {
 	Driver() *ydb_sdk.Driver
 }
 
+var _ rdbms_utils.PooledConnection = (*pooledDriver)(nil)
+
+// pooledDriver is a YDB SDK driver that can be reused by different requests
+type pooledDriver struct {
+	*ydb_sdk.Driver
+}
+
+func (d *pooledDriver) Ping(ctx context.Context) error {
+	if _, err := d.Driver.Discovery().WhoAmI(ctx); err != nil {
+		return fmt.Errorf("who am i: %w", err)
+	}
+
+	return nil
+}
+
+func (d *pooledDriver) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	if err := d.Driver.Close(ctx); err != nil {
+		return fmt.Errorf("driver close: %w", err)
+	}
+
+	return nil
+}
+
 var _ rdbms_utils.ConnectionManager = (*connectionManager)(nil)
 
 type connectionManager struct {
 	rdbms_utils.ConnectionManagerBase
 	cfg *config.TYdbConfig
-	// TODO: cache of connections, remove unused connections with TTL
 }
 
 func (c *connectionManager) Make(
@@ -88,11 +114,20 @@ func (c *connectionManager) Make(
 		))
 	}
 
-	ydbDriver, err := ydb_sdk.Open(openCtx, dsn, ydbOptions...)
+	pooled, err := c.AcquirePooledConnection(ctx, logger, dsi, func(_ context.Context) (rdbms_utils.PooledConnection, error) {
+		ydbDriver, err := ydb_sdk.Open(openCtx, dsn, ydbOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("open driver error: %w", err)
+		}
+
+		return &pooledDriver{ydbDriver}, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("open driver error: %w", err)
+		return nil, fmt.Errorf("acquire pooled connection: %w", err)
 	}
 
+	ydbDriver := pooled.(*pooledDriver)
+
 	var ydbConn Connection
 
 	switch c.cfg.Mode {
@@ -111,6 +146,8 @@ func (c *connectionManager) Make(
 	}
 
 	if err != nil {
+		c.ReleasePooledConnection(logger, ydbDriver)
+
 		return nil, fmt.Errorf("new connection: %w", err)
 	}
 
@@ -119,9 +156,18 @@ func (c *connectionManager) Make(
 	return []rdbms_utils.Connection{ydbConn}, nil
 }
 
-func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
+func (c *connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
 	for _, conn := range cs {
-		common.LogCloserError(logger, conn, "close YDB connection")
+		switch t := conn.(type) {
+		case *connectionNative:
+			c.ReleasePooledConnection(logger, t.driver)
+		case *connectionDatabaseSQL:
+			// database/sql handle is made for every request, only the driver is shared
+			common.LogCloserError(logger, t.DB, "close YDB database/sql connection")
+			c.ReleasePooledConnection(logger, t.driver)
+		default:
+			common.LogCloserError(logger, conn, "close YDB connection")
+		}
 	}
 }
 
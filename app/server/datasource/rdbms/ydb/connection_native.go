@@ -108,12 +108,13 @@ func (r *rowsNative) Close() error {
 }
 
 var _ rdbms_utils.Connection = (*connectionNative)(nil)
+var _ rdbms_utils.QueryExplainer = (*connectionNative)(nil)
 
 type connectionNative struct {
 	dsi         *api_common.TGenericDataSourceInstance
 	queryLogger common.QueryLogger
 	ctx         context.Context
-	driver      *ydb_sdk.Driver
+	driver      *pooledDriver
 	tableName   string
 	formatter   rdbms_utils.SQLFormatter
 }
@@ -132,90 +133,15 @@ func (c *connectionNative) Query(params *rdbms_utils.QueryParams) (rdbms_utils.R
 			}
 
 			// prepare parameter list
-			paramsBuilder := ydb_sdk.ParamsBuilder()
-
-			for i, arg := range params.QueryArgs.Values() {
-				placeholder := c.formatter.GetPlaceholder(i)
-
-				switch t := arg.(type) {
-				case bool:
-					paramsBuilder = paramsBuilder.Param(placeholder).Bool(t)
-				case *bool:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Bool(t).EndOptional()
-				case int8:
-					paramsBuilder = paramsBuilder.Param(placeholder).Int8(t)
-				case *int8:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int8(t).EndOptional()
-				case int16:
-					paramsBuilder = paramsBuilder.Param(placeholder).Int16(t)
-				case *int16:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int16(t).EndOptional()
-				case int32:
-					paramsBuilder = paramsBuilder.Param(placeholder).Int32(t)
-				case *int32:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int32(t).EndOptional()
-				case int64:
-					paramsBuilder = paramsBuilder.Param(placeholder).Int64(t)
-				case *int64:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int64(t).EndOptional()
-				case uint8:
-					paramsBuilder = paramsBuilder.Param(placeholder).Uint8(t)
-				case *uint8:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint8(t).EndOptional()
-				case uint16:
-					paramsBuilder = paramsBuilder.Param(placeholder).Uint16(t)
-				case *uint16:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint16(t).EndOptional()
-				case uint32:
-					paramsBuilder = paramsBuilder.Param(placeholder).Uint32(t)
-				case *uint32:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint32(t).EndOptional()
-				case uint64:
-					paramsBuilder = paramsBuilder.Param(placeholder).Uint64(t)
-				case *uint64:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint64(t).EndOptional()
-				case float32:
-					paramsBuilder = paramsBuilder.Param(placeholder).Float(t)
-				case *float32:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Float(t).EndOptional()
-				case float64:
-					paramsBuilder = paramsBuilder.Param(placeholder).Double(t)
-				case *float64:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Double(t).EndOptional()
-				case string:
-					paramsBuilder = paramsBuilder.Param(placeholder).Text(t)
-				case *string:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Text(t).EndOptional()
-				case []byte:
-					paramsBuilder = paramsBuilder.Param(placeholder).Bytes(t)
-				case *[]byte:
-					paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Bytes(t).EndOptional()
-				case time.Time:
-					switch params.QueryArgs.Get(i).YdbType.GetTypeId() {
-					case Ydb.Type_TIMESTAMP:
-						paramsBuilder = paramsBuilder.Param(placeholder).Timestamp(t)
-					default:
-						return fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
-					}
-				case *time.Time:
-					switch params.QueryArgs.Get(i).YdbType.GetOptionalType().GetItem().GetTypeId() {
-					case Ydb.Type_TIMESTAMP:
-						paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Timestamp(t).EndOptional()
-					default:
-						return fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
-					}
-				default:
-					return fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
-				}
+			parametersOption, err := c.makeParametersOption(params)
+			if err != nil {
+				return fmt.Errorf("make parameters option: %w", err)
 			}
 
 			c.queryLogger.Dump(queryRewritten, params.QueryArgs.Values()...)
 
 			// execute query
-			streamResult, err := session.Query(
-				ctx,
-				queryRewritten,
-				ydb_sdk_query.WithParameters(paramsBuilder.Build()))
+			streamResult, err := session.Query(ctx, queryRewritten, parametersOption)
 			if err != nil {
 				return fmt.Errorf("session query: %w", err)
 			}
@@ -263,8 +189,123 @@ func (c *connectionNative) Query(params *rdbms_utils.QueryParams) (rdbms_utils.R
 	}
 }
 
+// makeParametersOption converts query arguments into YDB query parameters
+//
+//nolint:gocyclo
+func (c *connectionNative) makeParametersOption(params *rdbms_utils.QueryParams) (ydb_sdk_query.ExecuteOption, error) {
+	paramsBuilder := ydb_sdk.ParamsBuilder()
+
+	for i, arg := range params.QueryArgs.Values() {
+		placeholder := c.formatter.GetPlaceholder(i)
+
+		switch t := arg.(type) {
+		case bool:
+			paramsBuilder = paramsBuilder.Param(placeholder).Bool(t)
+		case *bool:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Bool(t).EndOptional()
+		case int8:
+			paramsBuilder = paramsBuilder.Param(placeholder).Int8(t)
+		case *int8:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int8(t).EndOptional()
+		case int16:
+			paramsBuilder = paramsBuilder.Param(placeholder).Int16(t)
+		case *int16:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int16(t).EndOptional()
+		case int32:
+			paramsBuilder = paramsBuilder.Param(placeholder).Int32(t)
+		case *int32:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int32(t).EndOptional()
+		case int64:
+			paramsBuilder = paramsBuilder.Param(placeholder).Int64(t)
+		case *int64:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Int64(t).EndOptional()
+		case uint8:
+			paramsBuilder = paramsBuilder.Param(placeholder).Uint8(t)
+		case *uint8:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint8(t).EndOptional()
+		case uint16:
+			paramsBuilder = paramsBuilder.Param(placeholder).Uint16(t)
+		case *uint16:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint16(t).EndOptional()
+		case uint32:
+			paramsBuilder = paramsBuilder.Param(placeholder).Uint32(t)
+		case *uint32:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint32(t).EndOptional()
+		case uint64:
+			paramsBuilder = paramsBuilder.Param(placeholder).Uint64(t)
+		case *uint64:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Uint64(t).EndOptional()
+		case float32:
+			paramsBuilder = paramsBuilder.Param(placeholder).Float(t)
+		case *float32:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Float(t).EndOptional()
+		case float64:
+			paramsBuilder = paramsBuilder.Param(placeholder).Double(t)
+		case *float64:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Double(t).EndOptional()
+		case string:
+			paramsBuilder = paramsBuilder.Param(placeholder).Text(t)
+		case *string:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Text(t).EndOptional()
+		case []byte:
+			paramsBuilder = paramsBuilder.Param(placeholder).Bytes(t)
+		case *[]byte:
+			paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Bytes(t).EndOptional()
+		case time.Time:
+			switch params.QueryArgs.Get(i).YdbType.GetTypeId() {
+			case Ydb.Type_TIMESTAMP:
+				paramsBuilder = paramsBuilder.Param(placeholder).Timestamp(t)
+			default:
+				return nil, fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
+			}
+		case *time.Time:
+			switch params.QueryArgs.Get(i).YdbType.GetOptionalType().GetItem().GetTypeId() {
+			case Ydb.Type_TIMESTAMP:
+				paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Timestamp(t).EndOptional()
+			default:
+				return nil, fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
+		}
+	}
+
+	return ydb_sdk_query.WithParameters(paramsBuilder.Build()), nil
+}
+
+// Explain captures the plan of the query with the help of the query service explain mode
+func (c *connectionNative) Explain(params *rdbms_utils.QueryParams) (string, error) {
+	queryRewritten, err := c.rewriteQuery(params)
+	if err != nil {
+		return "", fmt.Errorf("rewrite query: %w", err)
+	}
+
+	parametersOption, err := c.makeParametersOption(params)
+	if err != nil {
+		return "", fmt.Errorf("make parameters option: %w", err)
+	}
+
+	var plan string
+
+	err = c.driver.Query().Exec(
+		params.Ctx,
+		queryRewritten,
+		parametersOption,
+		ydb_sdk_query.WithExecMode(ydb_sdk_query.ExecModeExplain),
+		ydb_sdk_query.WithStatsMode(ydb_sdk_query.StatsModeNone, func(stats ydb_sdk_query.Stats) {
+			plan = stats.QueryPlan()
+		}),
+		ydb_sdk_query.WithIdempotent(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("query exec: %w", err)
+	}
+
+	return plan, nil
+}
+
 func (c *connectionNative) Driver() *ydb_sdk.Driver {
-	return c.driver
+	return c.driver.Driver
 }
 
 func (c *connectionNative) DataSourceInstance() *api_common.TGenericDataSourceInstance {
@@ -276,7 +317,7 @@ func (c *connectionNative) TableName() string {
 }
 
 func (c *connectionNative) Close() error {
-	if err := c.driver.Close(c.ctx); err != nil {
+	if err := c.driver.Driver.Close(c.ctx); err != nil {
 		return fmt.Errorf("driver close: %w", err)
 	}
 
@@ -330,7 +371,7 @@ func newConnectionNative(
 	queryLogger common.QueryLogger,
 	dsi *api_common.TGenericDataSourceInstance,
 	tableName string,
-	driver *ydb_sdk.Driver,
+	driver *pooledDriver,
 	formatter rdbms_utils.SQLFormatter,
 ) Connection {
 	return &connectionNative{
@@ -95,6 +95,18 @@ func WithConnectionTimeouts(open, ping string) EmbeddedOption {
 	}
 }
 
+type withClickHouseArrowStream struct {
+	enabled bool
+}
+
+func (o *withClickHouseArrowStream) apply(cfg *config.TServerConfig) {
+	cfg.Datasources.Clickhouse.UseArrowStream = o.enabled
+}
+
+func WithClickHouseArrowStream(enabled bool) EmbeddedOption {
+	return &withClickHouseArrowStream{enabled: enabled}
+}
+
 type withYdbConnectorMode struct {
 	mode config.TYdbConfig_Mode
 }
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+)
+
+// clientAuthenticator checks client identity according to the `auth` section of the connector server config.
+type clientAuthenticator struct {
+	// If true, client must present a valid certificate
+	requireClientCertificate bool
+	// If not empty, client certificate must contain one of these names
+	allowedNames map[string]struct{}
+	// If not nil, client must pass valid token
+	tokenVerifier tokenVerifier
+}
+
+func (a *clientAuthenticator) authenticate(ctx context.Context) error {
+	if a.requireClientCertificate {
+		if err := a.checkClientCertificate(ctx); err != nil {
+			return status.Errorf(codes.PermissionDenied, "check client certificate: %v", err)
+		}
+	}
+
+	if a.tokenVerifier != nil {
+		token, err := extractBearerToken(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "extract token: %v", err)
+		}
+
+		if err := a.tokenVerifier.verify(token); err != nil {
+			return status.Errorf(codes.Unauthenticated, "verify token: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *clientAuthenticator) checkClientCertificate(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return errors.New("no peer info")
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return errors.New("connection is not secured with TLS")
+	}
+
+	// the chains have been already verified during TLS handshake
+	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return errors.New("no verified client certificate")
+	}
+
+	if len(a.allowedNames) == 0 {
+		return nil
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+
+	for _, name := range certificateNames(cert) {
+		if _, exists := a.allowedNames[name]; exists {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("certificate subject '%s' is not allowed", cert.Subject.String())
+}
+
+func certificateNames(cert *x509.Certificate) []string {
+	names := make([]string, 0, 1+len(cert.DNSNames)+len(cert.EmailAddresses)+len(cert.URIs)+len(cert.IPAddresses))
+
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+
+	names = append(names, cert.DNSNames...)
+	names = append(names, cert.EmailAddresses...)
+
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+
+	return names
+}
+
+const authorizationHeader = "authorization"
+
+func extractBearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errors.New("no metadata")
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", fmt.Errorf("missing `%s` header", authorizationHeader)
+	}
+
+	const prefix = "bearer "
+
+	if len(values[0]) <= len(prefix) || !strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return "", errors.New("bearer token expected")
+	}
+
+	return strings.TrimSpace(values[0][len(prefix):]), nil
+}
+
+func newClientAuthenticator(cfg *config.TClientAuthConfig) (*clientAuthenticator, error) {
+	a := &clientAuthenticator{}
+
+	if mtls := cfg.GetMtls(); mtls != nil {
+		a.requireClientCertificate = true
+		a.allowedNames = make(map[string]struct{}, len(mtls.AllowedNames))
+		for _, name := range mtls.AllowedNames {
+			a.allowedNames[name] = struct{}{}
+		}
+	}
+
+	var err error
+
+	switch t := cfg.GetToken().GetPayload().(type) {
+	case nil:
+	case *config.TClientAuthConfig_TTokenAuth_Static:
+		if a.tokenVerifier, err = newStaticTokenVerifier(t.Static); err != nil {
+			return nil, fmt.Errorf("new static token verifier: %w", err)
+		}
+	case *config.TClientAuthConfig_TTokenAuth_Jwt:
+		if a.tokenVerifier, err = newJWTVerifier(t.Jwt); err != nil {
+			return nil, fmt.Errorf("new JWT verifier: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected token auth type: %T", t)
+	}
+
+	return a, nil
+}
+
+// loadClientCAPool reads the bundle of CA certificates used to verify clients
+func loadClientCAPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no valid certificates found in '%s'", path)
+	}
+
+	return pool, nil
+}
+
+func UnaryServerAuth(a *clientAuthenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		// probes and load balancers have no credentials
+		if isHealthMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if err := a.authenticate(ctx); err != nil {
+			utils.LoggerMustFromContext(ctx).Warn("client authentication failed", zap.Error(err))
+
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamServerAuth(a *clientAuthenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isHealthMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		if err := a.authenticate(stream.Context()); err != nil {
+			utils.LoggerMustFromContext(stream.Context()).Warn("client authentication failed", zap.Error(err))
+
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
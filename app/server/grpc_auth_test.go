@@ -0,0 +1,246 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+)
+
+func makeContextWithToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestStaticTokenAuth(t *testing.T) {
+	tokensFile := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(tokensFile, []byte("token2\n\n  token3  \n"), 0o600))
+
+	a, err := newClientAuthenticator(&config.TClientAuthConfig{
+		Token: &config.TClientAuthConfig_TTokenAuth{
+			Payload: &config.TClientAuthConfig_TTokenAuth_Static{
+				Static: &config.TClientAuthConfig_TTokenAuth_TStaticTokens{
+					Tokens:     []string{"token1"},
+					TokensFile: tokensFile,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, token := range []string{"token1", "token2", "token3"} {
+		require.NoError(t, a.authenticate(makeContextWithToken(token)), token)
+	}
+
+	err = a.authenticate(makeContextWithToken("token4"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	err = a.authenticate(context.Background())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic token1"))
+	err = a.authenticate(ctx)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestJWTAuth(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "EC",
+				"kid": "key1",
+				"use": "sig",
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(privateKey.X.FillBytes(make([]byte, 32))),
+				"y":   base64.RawURLEncoding.EncodeToString(privateKey.Y.FillBytes(make([]byte, 32))),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	a, err := newClientAuthenticator(&config.TClientAuthConfig{
+		Token: &config.TClientAuthConfig_TTokenAuth{
+			Payload: &config.TClientAuthConfig_TTokenAuth_Jwt{
+				Jwt: &config.TClientAuthConfig_TTokenAuth_TJWT{
+					JwksFile: jwksFile,
+					Issuer:   "issuer",
+					Audience: "connector",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	makeToken := func(kid string, claims jwt.RegisteredClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+		token.Header["kid"] = kid
+
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	validClaims := jwt.RegisteredClaims{
+		Issuer:    "issuer",
+		Audience:  jwt.ClaimStrings{"connector"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	require.NoError(t, a.authenticate(makeContextWithToken(makeToken("key1", validClaims))))
+
+	expiredClaims := validClaims
+	expiredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	wrongIssuerClaims := validClaims
+	wrongIssuerClaims.Issuer = "other"
+
+	wrongAudienceClaims := validClaims
+	wrongAudienceClaims.Audience = jwt.ClaimStrings{"other"}
+
+	invalidTokens := map[string]string{
+		"expired":        makeToken("key1", expiredClaims),
+		"wrong issuer":   makeToken("key1", wrongIssuerClaims),
+		"wrong audience": makeToken("key1", wrongAudienceClaims),
+		"unknown key id": makeToken("key2", validClaims),
+		"HMAC":           mustSignHMAC(t, validClaims),
+		"malformed":      "abc",
+	}
+
+	for name, token := range invalidTokens {
+		err := a.authenticate(makeContextWithToken(token))
+		require.Equal(t, codes.Unauthenticated, status.Code(err), name)
+	}
+}
+
+func mustSignHMAC(t *testing.T, claims jwt.RegisteredClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = "key1"
+
+	signed, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	return signed
+}
+
+func TestMutualTLSAllowedNames(t *testing.T) {
+	a, err := newClientAuthenticator(&config.TClientAuthConfig{
+		Mtls: &config.TClientAuthConfig_TMutualTLS{
+			ClientCa:     "unused",
+			AllowedNames: []string{"client.example.com", "trusted-service"},
+		},
+	})
+	require.NoError(t, err)
+
+	makeContext := func(cert *x509.Certificate) context.Context {
+		state := tls.ConnectionState{}
+		if cert != nil {
+			state.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: state},
+		})
+	}
+
+	testCases := []struct {
+		name string
+		cert *x509.Certificate
+		code codes.Code
+	}{
+		{
+			name: "common name",
+			cert: &x509.Certificate{Subject: pkix.Name{CommonName: "trusted-service"}},
+			code: codes.OK,
+		},
+		{
+			name: "DNS name",
+			cert: &x509.Certificate{Subject: pkix.Name{CommonName: "other"}, DNSNames: []string{"client.example.com"}},
+			code: codes.OK,
+		},
+		{
+			name: "unknown name",
+			cert: &x509.Certificate{Subject: pkix.Name{CommonName: "other"}, DNSNames: []string{"other.example.com"}},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "no certificate",
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.code, status.Code(a.authenticate(makeContext(tc.cert))))
+		})
+	}
+
+	err = a.authenticate(context.Background())
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestServerAuthSkipsHealthChecks(t *testing.T) {
+	a, err := newClientAuthenticator(&config.TClientAuthConfig{
+		Mtls: &config.TClientAuthConfig_TMutualTLS{ClientCa: "unused"},
+		Token: &config.TClientAuthConfig_TTokenAuth{
+			Payload: &config.TClientAuthConfig_TTokenAuth_Static{
+				Static: &config.TClientAuthConfig_TTokenAuth_TStaticTokens{Tokens: []string{"token"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// the logger is put into the context by the metadata interceptor
+	metadataInterceptor := utils.UnaryServerMetadata(zap.NewNop())
+	authInterceptor := UnaryServerAuth(a)
+
+	call := func(fullMethod string) error {
+		info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+		handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+		_, err := metadataInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return authInterceptor(ctx, req, info, handler)
+		})
+
+		return err
+	}
+
+	// neither client certificate nor token are passed
+	require.NoError(t, call("/grpc.health.v1.Health/Check"))
+	require.Equal(t, codes.PermissionDenied, status.Code(call("/NYql.NConnector.NApi.Connector/DescribeTable")))
+
+	streamInterceptor := StreamServerAuth(a)
+	streamHandler := func(any, grpc.ServerStream) error { return nil }
+
+	err = streamInterceptor(nil, &testServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}, streamHandler)
+	require.NoError(t, err)
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// drainer tracks in-flight requests and rejects new ones after the server started shutting down
+type drainer struct {
+	mutex    sync.Mutex
+	inFlight int
+	draining bool
+	drained  chan struct{} // closed when the server is draining and the last request is finished
+}
+
+func (d *drainer) enter() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.draining {
+		return false
+	}
+
+	d.inFlight++
+
+	return true
+}
+
+func (d *drainer) leave() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.inFlight--
+
+	if d.draining && d.inFlight == 0 {
+		close(d.drained)
+	}
+}
+
+// drain makes server reject new requests and waits for the in-flight requests to finish.
+// Returns the number of requests that have not finished within the timeout.
+func (d *drainer) drain(timeout time.Duration) int {
+	d.mutex.Lock()
+
+	if !d.draining {
+		d.draining = true
+
+		if d.inFlight == 0 {
+			close(d.drained)
+		}
+	}
+
+	d.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-d.drained:
+	case <-timer.C:
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.inFlight
+}
+
+var errServerDraining = status.Error(codes.Unavailable, "server is shutting down")
+
+// health checks must be served until the very end
+func isHealthMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+grpc_health_v1.Health_ServiceDesc.ServiceName+"/")
+}
+
+func UnaryServerDrain(d *drainer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isHealthMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if !d.enter() {
+			return nil, errServerDraining
+		}
+
+		defer d.leave()
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamServerDrain(d *drainer) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isHealthMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		if !d.enter() {
+			return errServerDraining
+		}
+
+		defer d.leave()
+
+		return handler(srv, stream)
+	}
+}
+
+func newDrainer() *drainer {
+	return &drainer{drained: make(chan struct{})}
+}
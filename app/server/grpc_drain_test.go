@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDrainer(t *testing.T) {
+	t.Run("no in-flight requests", func(t *testing.T) {
+		d := newDrainer()
+
+		require.Equal(t, 0, d.drain(time.Hour))
+		require.False(t, d.enter())
+	})
+
+	t.Run("in-flight request finishes", func(t *testing.T) {
+		d := newDrainer()
+		require.True(t, d.enter())
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			d.leave()
+		}()
+
+		require.Equal(t, 0, d.drain(time.Hour))
+	})
+
+	t.Run("timeout expires", func(t *testing.T) {
+		d := newDrainer()
+		require.True(t, d.enter())
+
+		require.Equal(t, 1, d.drain(10*time.Millisecond))
+		d.leave()
+	})
+}
+
+func TestUnaryServerDrain(t *testing.T) {
+	d := newDrainer()
+	interceptor := UnaryServerDrain(d)
+
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/Connector/DescribeTable"}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+
+	d.drain(time.Second)
+
+	// new requests are rejected
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/Connector/DescribeTable"}, handler)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	// health checks are still served
+	resp, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+}
@@ -15,8 +15,8 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
-	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
 )
 
 type errorGetter interface {
@@ -44,7 +44,16 @@ func maybeRegisterStatusCode(statusCount metrics.CounterVec, opName string, stre
 	// check possible logical error
 	eg, ok := response.(errorGetter)
 	if !ok {
-		panic(fmt.Sprintf("failed to cast response of type %T to errorGetter", response))
+		// Auxiliary services (like health checks or reflection) do not return logical errors
+		if !streamingMethod {
+			statusCount.With(map[string]string{
+				"protocol": "grpc",
+				"endpoint": opName,
+				"status":   "OK",
+			}).Inc()
+		}
+
+		return
 	}
 
 	if eg.GetError() == nil {
@@ -87,12 +96,12 @@ func UnaryServerMetrics(logger *zap.Logger, registry metrics.Registry) grpc.Unar
 	requestBytes := registry.CounterVec("request_bytes", []string{"protocol", "endpoint"})
 	responseBytes := registry.CounterVec("response_bytes", []string{"protocol", "endpoint"})
 
-	solomon.Rated(requestCount)
-	solomon.Rated(requestDuration)
-	solomon.Rated(panicsCount)
-	solomon.Rated(statusCount)
-	solomon.Rated(requestBytes)
-	solomon.Rated(responseBytes)
+	utils.Rated(requestCount)
+	utils.Rated(requestDuration)
+	utils.Rated(panicsCount)
+	utils.Rated(statusCount)
+	utils.Rated(requestBytes)
+	utils.Rated(responseBytes)
 
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ any, err error) {
 		deferFunc := func(startTime time.Time, opName string) {
@@ -145,10 +154,13 @@ func UnaryServerMetrics(logger *zap.Logger, registry metrics.Registry) grpc.Unar
 
 		maybeRegisterStatusCode(statusCount, opName, false, resp, err)
 
-		responseBytes.With(map[string]string{
-			"protocol": "grpc",
-			"endpoint": opName,
-		}).Add(int64(proto.Size(resp.(proto.Message))))
+		// response is empty in case of transport error
+		if resp != nil {
+			responseBytes.With(map[string]string{
+				"protocol": "grpc",
+				"endpoint": opName,
+			}).Add(int64(proto.Size(resp.(proto.Message))))
+		}
 
 		return resp, err
 	}
@@ -169,14 +181,14 @@ func StreamServerMetrics(logger *zap.Logger, registry metrics.Registry) grpc.Str
 	statusCount := registry.CounterVec("stream_status_total", []string{"protocol", "endpoint", "status"})
 	receivedStreamMessages := registry.CounterVec("received_stream_messages_total", []string{"protocol", "endpoint"})
 
-	solomon.Rated(streamCount)
-	solomon.Rated(streamDuration)
-	solomon.Rated(panicsCount)
-	solomon.Rated(sentStreamMessages)
-	solomon.Rated(receivedStreamMessages)
-	solomon.Rated(receivedBytes)
-	solomon.Rated(sentBytes)
-	solomon.Rated(statusCount)
+	utils.Rated(streamCount)
+	utils.Rated(streamDuration)
+	utils.Rated(panicsCount)
+	utils.Rated(sentStreamMessages)
+	utils.Rated(receivedStreamMessages)
+	utils.Rated(receivedBytes)
+	utils.Rated(sentBytes)
+	utils.Rated(statusCount)
 
 	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		deferFunc := func(startTime time.Time, opName string) {
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otel_codes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+)
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+// metadataCarrier makes it possible to extract trace context from the GRPC metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// startServerSpan continues the trace started by client (if any)
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+
+	return utils.StartSpan(
+		ctx,
+		strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", method),
+		),
+	)
+}
+
+// endServerSpan takes into account both transport and logical errors
+func endServerSpan(span trace.Span, response any, err error) {
+	if err != nil {
+		span.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+		span.SetStatus(otel_codes.Error, err.Error())
+	} else if eg, ok := response.(errorGetter); ok && eg.GetError() != nil && eg.GetError().Status != Ydb.StatusIds_SUCCESS {
+		span.SetStatus(otel_codes.Error, eg.GetError().Message)
+	}
+
+	span.End()
+}
+
+func UnaryServerTracing() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isHealthMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+
+		endServerSpan(span, resp, err)
+
+		return resp, err
+	}
+}
+
+// tracedServerStream substitutes the stream context with the one containing server span
+// and remembers the last message sent to client in order to check it for logical errors.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx          context.Context
+	lastResponse any
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tracedServerStream) SendMsg(m any) error {
+	s.lastResponse = m
+
+	return s.ServerStream.SendMsg(m)
+}
+
+func StreamServerTracing() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isHealthMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctx, span := startServerSpan(stream.Context(), info.FullMethod)
+
+		traced := &tracedServerStream{ServerStream: stream, ctx: ctx}
+
+		err := handler(srv, traced)
+
+		endServerSpan(span, traced.lastResponse, err)
+
+		return err
+	}
+}
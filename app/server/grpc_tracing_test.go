@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otel_codes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+const (
+	testTraceID    = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentSpan = "00f067aa0ba902b7"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	prevProvider := otel.GetTracerProvider()
+	prevPropagator := otel.GetTextMapPropagator()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	return recorder
+}
+
+func makeTracedIncomingContext() context.Context {
+	return metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs("traceparent", "00-"+testTraceID+"-"+testParentSpan+"-01"),
+	)
+}
+
+func TestUnaryServerTracing(t *testing.T) {
+	recorder := setupTestTracing(t)
+	interceptor := UnaryServerTracing()
+
+	handler := func(ctx context.Context, _ any) (any, error) {
+		// the trace started by client is continued
+		require.Equal(t, testTraceID, trace.SpanContextFromContext(ctx).TraceID().String())
+
+		return &api_service_protos.TDescribeTableResponse{
+			Error: &api_service_protos.TError{Status: Ydb.StatusIds_NOT_FOUND, Message: "table not found"},
+		}, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/NYql.NConnector.NApi.Connector/DescribeTable"}
+
+	_, err := interceptor(makeTracedIncomingContext(), nil, info, handler)
+	require.NoError(t, err)
+
+	// health checks are not traced
+	healthHandler := func(context.Context, any) (any, error) { return nil, nil }
+
+	_, err = interceptor(makeTracedIncomingContext(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, healthHandler)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "NYql.NConnector.NApi.Connector/DescribeTable", spans[0].Name())
+	require.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	require.Equal(t, testParentSpan, spans[0].Parent().SpanID().String())
+	require.True(t, spans[0].Parent().IsRemote())
+
+	// logical error makes span failed
+	require.Equal(t, otel_codes.Error, spans[0].Status().Code)
+	require.Equal(t, "table not found", spans[0].Status().Description)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func (*testServerStream) SendMsg(any) error { return nil }
+
+func TestStreamServerTracing(t *testing.T) {
+	recorder := setupTestTracing(t)
+	interceptor := StreamServerTracing()
+
+	handler := func(_ any, stream grpc.ServerStream) error {
+		require.Equal(t, testTraceID, trace.SpanContextFromContext(stream.Context()).TraceID().String())
+
+		// child spans belong to the same trace
+		_, span := otel.Tracer("test").Start(stream.Context(), "child")
+		span.End()
+
+		return stream.SendMsg(&api_service_protos.TReadSplitsResponse{Error: &api_service_protos.TError{Status: Ydb.StatusIds_SUCCESS}})
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/NYql.NConnector.NApi.Connector/ReadSplits"}
+
+	err := interceptor(nil, &testServerStream{ctx: makeTracedIncomingContext()}, info, handler)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	child, server := spans[0], spans[1]
+	require.Equal(t, "NYql.NConnector.NApi.Connector/ReadSplits", server.Name())
+	require.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+	require.Equal(t, otel_codes.Unset, server.Status().Code)
+}
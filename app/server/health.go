@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+)
+
+type readinessChecker interface {
+	CheckReadiness(ctx context.Context) map[api_common.EGenericDataSourceKind]error
+}
+
+// healthReporter periodically checks the data sources readiness
+// and publishes the results via the standard grpc.health.v1 service.
+type healthReporter struct {
+	server   *health.Server
+	checker  readinessChecker
+	interval time.Duration
+	logger   *zap.Logger
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func dataSourceHealthServiceName(kind api_common.EGenericDataSourceKind) string {
+	return "datasource/" + kind.String()
+}
+
+func (r *healthReporter) start() {
+	r.server.SetServingStatus(api_service.Connector_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	go r.run()
+}
+
+func (r *healthReporter) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.check()
+
+		select {
+		case <-ticker.C:
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *healthReporter) check() {
+	ctx, cancel := context.WithTimeout(r.ctx, r.interval)
+	defer cancel()
+
+	for kind, err := range r.checker.CheckReadiness(ctx) {
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+
+		if err != nil {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+
+			r.logger.Warn("data source is not ready", zap.String("data_source_kind", kind.String()), zap.Error(err))
+		}
+
+		// has no effect after the shutdown
+		r.server.SetServingStatus(dataSourceHealthServiceName(kind), status)
+	}
+}
+
+// shutdown switches all services to NOT_SERVING status and stops the readiness checks
+func (r *healthReporter) shutdown() {
+	r.server.Shutdown()
+	r.cancel()
+	<-r.done
+}
+
+func newHealthReporter(
+	logger *zap.Logger,
+	server *health.Server,
+	checker readinessChecker,
+	interval time.Duration,
+) *healthReporter {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &healthReporter{
+		server:   server,
+		checker:  checker,
+		interval: interval,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
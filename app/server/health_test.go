@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+)
+
+type readinessCheckerMock map[api_common.EGenericDataSourceKind]error
+
+func (m readinessCheckerMock) CheckReadiness(context.Context) map[api_common.EGenericDataSourceKind]error {
+	result := make(map[api_common.EGenericDataSourceKind]error, len(m))
+	for kind, err := range m {
+		result[kind] = err
+	}
+
+	return result
+}
+
+func TestHealthReporter(t *testing.T) {
+	server := health.NewServer()
+	checker := readinessCheckerMock{
+		api_common.EGenericDataSourceKind_POSTGRESQL: nil,
+		api_common.EGenericDataSourceKind_LOGGING:    errors.New("Logging API is unavailable"),
+	}
+
+	reporter := newHealthReporter(zap.NewNop(), server, checker, time.Hour)
+	reporter.start()
+
+	getStatus := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := server.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+
+		return resp.Status
+	}
+
+	// wait for the first check to be completed
+	require.Eventually(t, func() bool {
+		for kind := range checker {
+			_, err := server.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{
+				Service: dataSourceHealthServiceName(kind),
+			})
+			if err != nil {
+				return false
+			}
+		}
+
+		return true
+	}, time.Second, time.Millisecond)
+
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, getStatus(""))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, getStatus(api_service.Connector_ServiceDesc.ServiceName))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING,
+		getStatus(dataSourceHealthServiceName(api_common.EGenericDataSourceKind_POSTGRESQL)))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		getStatus(dataSourceHealthServiceName(api_common.EGenericDataSourceKind_LOGGING)))
+
+	reporter.shutdown()
+
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getStatus(""))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		getStatus(dataSourceHealthServiceName(api_common.EGenericDataSourceKind_POSTGRESQL)))
+}
@@ -9,13 +9,14 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
-	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
 )
 
 type Launcher struct {
 	services map[string]utils.Service
+	tracing  *tracing
 	logger   *zap.Logger
 }
 
@@ -33,10 +34,45 @@ func (l *Launcher) Start() <-chan error {
 }
 
 func (l *Launcher) Stop() {
+	// connector service is stopped first, because draining may take a while,
+	// and the auxiliary services (metrics, observation) should be available during this time
+	if s, exists := l.services[connectorServiceKey]; exists {
+		l.logger.Info("stopping service", zap.String("service", connectorServiceKey))
+		s.Stop()
+	}
+
 	for key, s := range l.services {
+		if key == connectorServiceKey {
+			continue
+		}
+
 		l.logger.Info("stopping service", zap.String("service", key))
 		s.Stop()
 	}
+
+	// flush the spans that were produced during the shutdown
+	if l.tracing != nil {
+		l.tracing.shutdown()
+	}
+}
+
+// reloadableService is a service that is able to apply the new config without restart
+type reloadableService interface {
+	Reload(cfg *config.TServerConfig) error
+}
+
+// Reload applies the new config to the services that support it.
+// The config must be checked with CheckReloadable in advance.
+func (l *Launcher) Reload(cfg *config.TServerConfig) error {
+	for key, s := range l.services {
+		if r, ok := s.(reloadableService); ok {
+			if err := r.Reload(cfg); err != nil {
+				return fmt.Errorf("reload service '%s': %w", key, err)
+			}
+		}
+	}
+
+	return nil
 }
 
 const (
@@ -54,11 +90,14 @@ func NewLauncher(logger *zap.Logger, cfg *config.TServerConfig) (*Launcher, erro
 
 	var err error
 
-	// initialize storage for solomon metrics
-	solomonRegistry := solomon.NewRegistry(&solomon.RegistryOpts{
-		Separator:  '.',
-		UseNameTag: true,
-	})
+	// tracer provider must be registered before any instrumented code is called
+	l.tracing, err = setupTracing(logger, cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("setup tracing: %w", err)
+	}
+
+	// initialize storage for metrics
+	registry := utils.NewMetricsRegistry()
 
 	// initialize storage for query observation system
 	observationStorage, err := observation.NewStorage(logger, cfg.Observation)
@@ -66,19 +105,33 @@ func NewLauncher(logger *zap.Logger, cfg *config.TServerConfig) (*Launcher, erro
 		return nil, fmt.Errorf("new observation storage: %w", err)
 	}
 
+	// running queries are shared between connector and observation services
+	queryRegistry := observation.NewQueryRegistry()
+
+	// cached metadata is invalidated via observation service
+	metadataCache, err := cache.NewMetadataCache(cfg.MetadataCache, registry.WithPrefix("metadata_cache"))
+	if err != nil {
+		return nil, fmt.Errorf("new metadata cache: %w", err)
+	}
+
 	// init metrics server
 	if cfg.MetricsServer != nil {
-		l.services[metricsServiceKey] = newServiceMetrics(
+		l.services[metricsServiceKey], err = newServiceMetrics(
 			logger.With(zap.String("service", metricsServiceKey)),
-			cfg.MetricsServer, solomonRegistry)
+			cfg.MetricsServer, registry)
+		if err != nil {
+			return nil, fmt.Errorf("new metrics service: %w", err)
+		}
 	}
 
 	// init GRPC server
 	l.services[connectorServiceKey], err = newServiceConnector(
 		logger.With(zap.String("service", connectorServiceKey)),
 		cfg,
-		solomonRegistry,
+		registry,
 		observationStorage,
+		queryRegistry,
+		metadataCache,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("new connector service: %w", err)
@@ -97,6 +150,8 @@ func NewLauncher(logger *zap.Logger, cfg *config.TServerConfig) (*Launcher, erro
 			logger.With(zap.String("service", observationServiceKey)),
 			cfg.Observation,
 			observationStorage,
+			queryRegistry,
+			metadataCache,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("new observation service: %w", err)
@@ -106,19 +161,38 @@ func NewLauncher(logger *zap.Logger, cfg *config.TServerConfig) (*Launcher, erro
 	return l, nil
 }
 
-func startLauncherAndWaitForSignalOrError(logger *zap.Logger, l *Launcher) {
+func startLauncherAndWaitForSignalOrError(logger *zap.Logger, l *Launcher, reloader *configReloader) {
 	errChan := l.Start()
 
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
 
-	select {
-	case err := <-errChan:
-		if err != nil {
-			logger.Error("service fatal error", zap.Error(err))
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
+
+	defer signal.Stop(reloadChan)
+
+loop:
+	for {
+		select {
+		case err := <-errChan:
+			if err != nil {
+				logger.Error("service fatal error", zap.Error(err))
+			}
+
+			break loop
+		case sig := <-signalChan:
+			logger.Info("interrupting signal", zap.Any("value", sig))
+
+			break loop
+		case <-reloadChan:
+			// the service keeps working with the previous config if the new one is wrong
+			if err := reloader.reload(); err != nil {
+				logger.Error("config reload failed", zap.Error(err))
+			} else {
+				logger.Info("config reloaded")
+			}
 		}
-	case sig := <-signalChan:
-		logger.Info("interrupting signal", zap.Any("value", sig))
 	}
 
 	l.Stop()
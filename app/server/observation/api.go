@@ -0,0 +1,319 @@
+package observation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+const (
+	apiDefaultLimit = 50
+	apiMaxLimit     = 1000
+	// explainTimeout restricts the time spent on the plan capture, which requires a new connection to the data source
+	explainTimeout = 30 * time.Second
+)
+
+// apiError is a body of the unsuccessful API response
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// apiCancelResponse is a body of the successful query cancellation response
+type apiCancelResponse struct {
+	ID       IncomingQueryID `json:"id"`
+	Canceled bool            `json:"canceled"`
+}
+
+// apiInvalidateResponse is a body of the successful metadata cache invalidation response
+type apiInvalidateResponse struct {
+	Invalidated int `json:"invalidated"`
+}
+
+// MetadataCacheInvalidator drops the cached table schemas and splits
+type MetadataCacheInvalidator interface {
+	// Invalidate drops the entries of the data source instances matching the arguments,
+	// empty arguments match any value. Returns the number of the dropped entries.
+	Invalidate(kind api_common.EGenericDataSourceKind, database string) int
+}
+
+// apiExplainResponse is a body of the successful plan capture response
+type apiExplainResponse struct {
+	ID   OutgoingQueryID `json:"id"`
+	Plan string          `json:"plan"`
+}
+
+// registerAPIHandlers registers the JSON endpoints mirroring every page of the service
+func (s *serviceImpl) registerAPIHandlers(mux *http.ServeMux) {
+	handlers := map[string]http.HandlerFunc{
+		"GET /api/v1/incoming":                              s.handleAPIListIncomingQueries,
+		"GET /api/v1/incoming/running":                      s.handleAPIListRunningIncomingQueries,
+		"POST /api/v1/incoming/{id}/cancel":                 s.handleAPICancelIncomingQuery,
+		"GET /api/v1/outgoing":                              s.handleAPIListOutgoingQueries,
+		"GET /api/v1/outgoing/running":                      s.handleAPIListRunningOutgoingQueries,
+		"POST /api/v1/outgoing/{id}/explain":                s.handleAPIExplainOutgoingQuery,
+		"GET /api/v1/outgoing/similar_with_different_stats": s.handleAPIListSimilarOutgoingQueries,
+		"POST /api/v1/metadata_cache/invalidate":            s.handleAPIInvalidateMetadataCache,
+	}
+
+	for pattern, handler := range handlers {
+		mux.Handle(pattern, s.requestLoggerMiddleware(handler))
+	}
+}
+
+func (s *serviceImpl) handleAPIListIncomingQueries(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseQueryFilter(r)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.writeAPIIncomingQueries(w, filter)
+}
+
+func (s *serviceImpl) handleAPIListRunningIncomingQueries(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseQueryFilter(r)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	state := QueryStateRunning
+	filter.State = &state
+
+	s.writeAPIIncomingQueries(w, filter)
+}
+
+func (s *serviceImpl) writeAPIIncomingQueries(w http.ResponseWriter, filter *QueryFilter) {
+	queries, err := s.storage.FindIncomingQueries(filter)
+	if err != nil {
+		s.writeAPIError(w, http.StatusInternalServerError, fmt.Errorf("find incoming queries: %w", err))
+		return
+	}
+
+	if queries == nil {
+		queries = []*IncomingQuery{}
+	}
+
+	s.writeAPIResponse(w, http.StatusOK, queries)
+}
+
+func (s *serviceImpl) handleAPIListOutgoingQueries(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseQueryFilter(r)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.writeAPIOutgoingQueries(w, filter)
+}
+
+func (s *serviceImpl) handleAPIListRunningOutgoingQueries(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseQueryFilter(r)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	state := QueryStateRunning
+	filter.State = &state
+
+	s.writeAPIOutgoingQueries(w, filter)
+}
+
+func (s *serviceImpl) writeAPIOutgoingQueries(w http.ResponseWriter, filter *QueryFilter) {
+	queries, err := s.storage.FindOutgoingQueries(filter)
+	if err != nil {
+		s.writeAPIError(w, http.StatusInternalServerError, fmt.Errorf("find outgoing queries: %w", err))
+		return
+	}
+
+	if queries == nil {
+		queries = []*OutgoingQuery{}
+	}
+
+	s.writeAPIResponse(w, http.StatusOK, queries)
+}
+
+func (s *serviceImpl) handleAPIListSimilarOutgoingQueries(w http.ResponseWriter, _ *http.Request) {
+	groups, err := s.storage.ListSimilarOutgoingQueriesWithDifferentStats(s.logger)
+	if err != nil {
+		s.writeAPIError(w, http.StatusInternalServerError, fmt.Errorf("list similar outgoing queries: %w", err))
+		return
+	}
+
+	if groups == nil {
+		groups = [][]*OutgoingQuery{}
+	}
+
+	s.writeAPIResponse(w, http.StatusOK, groups)
+}
+
+// handleAPICancelIncomingQuery interrupts the ReadSplits stream serving the incoming query
+func (s *serviceImpl) handleAPICancelIncomingQuery(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid query id: %w", err))
+		return
+	}
+
+	if !s.queryRegistry.Cancel(IncomingQueryID(id)) {
+		s.writeAPIError(w, http.StatusNotFound, fmt.Errorf("query %d is not running within this process", id))
+		return
+	}
+
+	s.logger.Info("incoming query canceled via API", zap.Uint64("incoming_query_id", id))
+
+	s.writeAPIResponse(w, http.StatusAccepted, &apiCancelResponse{ID: IncomingQueryID(id), Canceled: true})
+}
+
+// handleAPIExplainOutgoingQuery captures the execution plan of the running outgoing query and saves it
+func (s *serviceImpl) handleAPIExplainOutgoingQuery(w http.ResponseWriter, r *http.Request) {
+	value, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid query id: %w", err))
+		return
+	}
+
+	id := OutgoingQueryID(value)
+
+	ctx, cancel := context.WithTimeout(r.Context(), explainTimeout)
+	defer cancel()
+
+	plan, found, err := s.queryRegistry.Explain(ctx, id)
+	if !found {
+		s.writeAPIError(w, http.StatusNotFound, fmt.Errorf("query %d is not running within this process", id))
+		return
+	}
+
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, common.ErrMethodNotSupported) {
+			statusCode = http.StatusNotImplemented
+		}
+
+		s.writeAPIError(w, statusCode, fmt.Errorf("explain: %w", err))
+
+		return
+	}
+
+	if err := s.storage.SaveOutgoingQueryPlan(id, plan); err != nil {
+		s.writeAPIError(w, http.StatusInternalServerError, fmt.Errorf("save plan: %w", err))
+		return
+	}
+
+	s.writeAPIResponse(w, http.StatusOK, &apiExplainResponse{ID: id, Plan: plan})
+}
+
+// handleAPIInvalidateMetadataCache drops the cached metadata of the data sources
+// selected with optional `data_source_kind` and `database` parameters
+func (s *serviceImpl) handleAPIInvalidateMetadataCache(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	kind := api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED
+
+	if value := params.Get("data_source_kind"); value != "" {
+		number, exists := api_common.EGenericDataSourceKind_value[value]
+		if !exists {
+			s.writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid data_source_kind '%s'", value))
+			return
+		}
+
+		kind = api_common.EGenericDataSourceKind(number)
+	}
+
+	invalidated := s.metadataCache.Invalidate(kind, params.Get("database"))
+
+	s.logger.Info("metadata cache invalidated via API",
+		zap.Stringer("data_source_kind", kind), zap.String("database", params.Get("database")), zap.Int("entries", invalidated))
+
+	s.writeAPIResponse(w, http.StatusOK, &apiInvalidateResponse{Invalidated: invalidated})
+}
+
+func (s *serviceImpl) writeAPIResponse(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error("encode API response", zap.Error(err))
+	}
+}
+
+func (s *serviceImpl) writeAPIError(w http.ResponseWriter, statusCode int, err error) {
+	s.writeAPIResponse(w, statusCode, &apiError{Error: err.Error()})
+}
+
+// parseQueryFilter extracts query filter from the URL parameters
+//
+//nolint:gocyclo
+func parseQueryFilter(r *http.Request) (*QueryFilter, error) {
+	params := r.URL.Query()
+
+	filter := &QueryFilter{
+		DataSourceKind: params.Get("data_source_kind"),
+		DatabaseName:   params.Get("database"),
+		Limit:          apiDefaultLimit,
+	}
+
+	if value := params.Get("state"); value != "" {
+		state := QueryState(value)
+
+		switch state {
+		case QueryStateRunning, QueryStateFinished, QueryStateCancelled:
+		default:
+			return nil, fmt.Errorf("invalid state '%s'", value)
+		}
+
+		filter.State = &state
+	}
+
+	if value := params.Get("incoming_query_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid incoming_query_id: %w", err)
+		}
+
+		incomingQueryID := IncomingQueryID(id)
+		filter.IncomingQueryID = &incomingQueryID
+	}
+
+	var err error
+
+	if filter.CreatedFrom, err = parseTimeParam(params.Get("from")); err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+
+	if filter.CreatedTo, err = parseTimeParam(params.Get("to")); err != nil {
+		return nil, fmt.Errorf("invalid to: %w", err)
+	}
+
+	if value := params.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil || filter.Limit <= 0 || filter.Limit > apiMaxLimit {
+			return nil, fmt.Errorf("invalid limit '%s': must be within [1, %d]", value, apiMaxLimit)
+		}
+	}
+
+	if value := params.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil || filter.Offset < 0 {
+			return nil, fmt.Errorf("invalid offset '%s'", value)
+		}
+	}
+
+	return filter, nil
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
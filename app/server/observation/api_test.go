@@ -0,0 +1,215 @@
+package observation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func getAPI[T any](t *testing.T, url string, expectedStatus int) T {
+	resp, err := http.Get(url) //nolint:gosec,noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatus, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var result T
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	return result
+}
+
+func TestAPI(t *testing.T) {
+	storage, err := newStorageSQLite(zap.NewNop(), &config.TObservationConfig_TStorage{
+		Payload: &config.TObservationConfig_TStorage_Sqlite{
+			Sqlite: &config.TObservationConfig_TStorage_TSQLite{Path: filepath.Join(t.TempDir(), "observation.db")},
+		},
+	})
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, storage.Close()) }()
+
+	fillTestStorage(t, storage, 3)
+
+	registry := NewQueryRegistry()
+	s := &serviceImpl{storage: storage, queryRegistry: registry, logger: zap.NewNop()}
+
+	mux := http.NewServeMux()
+	s.registerAPIHandlers(mux)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("list incoming queries", func(t *testing.T) {
+		queries := getAPI[[]*IncomingQuery](t, server.URL+"/api/v1/incoming?data_source_kind=POSTGRESQL&database=db", http.StatusOK)
+		require.Len(t, queries, 3)
+
+		queries = getAPI[[]*IncomingQuery](t, server.URL+"/api/v1/incoming?database=unknown", http.StatusOK)
+		require.Empty(t, queries)
+
+		queries = getAPI[[]*IncomingQuery](t, server.URL+"/api/v1/incoming?limit=2&offset=2", http.StatusOK)
+		require.Len(t, queries, 1)
+
+		queries = getAPI[[]*IncomingQuery](t, server.URL+"/api/v1/incoming/running", http.StatusOK)
+		require.Empty(t, queries)
+	})
+
+	t.Run("list outgoing queries", func(t *testing.T) {
+		queries := getAPI[[]*OutgoingQuery](t, server.URL+"/api/v1/outgoing?state=finished&incoming_query_id=2", http.StatusOK)
+		require.Len(t, queries, 1)
+		require.Equal(t, IncomingQueryID(2), queries[0].IncomingQueryID)
+
+		queries = getAPI[[]*OutgoingQuery](t, server.URL+"/api/v1/outgoing?data_source_kind=CLICKHOUSE", http.StatusOK)
+		require.Empty(t, queries)
+
+		queries = getAPI[[]*OutgoingQuery](t, server.URL+"/api/v1/outgoing?from=2000-01-01T00:00:00Z&to=2001-01-01T00:00:00Z", http.StatusOK)
+		require.Empty(t, queries)
+	})
+
+	t.Run("invalid list filter", func(t *testing.T) {
+		for _, query := range []string{"state=unknown", "limit=0", "offset=-1", "from=yesterday", "incoming_query_id=x"} {
+			apiErr := getAPI[apiError](t, server.URL+"/api/v1/incoming?"+query, http.StatusBadRequest)
+			require.NotEmpty(t, apiErr.Error, query)
+		}
+	})
+
+	t.Run("cancel incoming query", func(t *testing.T) {
+		ctx, unregister := registry.Register(context.Background(), 42)
+		defer unregister()
+
+		cancelQuery := func(id string) *http.Response {
+			resp, err := http.Post(server.URL+"/api/v1/incoming/"+id+"/cancel", "application/json", nil) //nolint:noctx
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			return resp
+		}
+
+		require.Equal(t, http.StatusNotFound, cancelQuery("43").StatusCode)
+		require.NoError(t, ctx.Err())
+
+		require.Equal(t, http.StatusAccepted, cancelQuery("42").StatusCode)
+		require.ErrorIs(t, context.Cause(ctx), ErrCanceledViaAPI)
+		require.True(t, errors.Is(context.Cause(ctx), common.ErrQueryCanceled))
+
+		require.Equal(t, http.StatusBadRequest, cancelQuery("abc").StatusCode)
+
+		// the finished query cannot be canceled anymore
+		unregister()
+		require.Equal(t, http.StatusNotFound, cancelQuery("42").StatusCode)
+	})
+
+	t.Run("explain outgoing query", func(t *testing.T) {
+		explainQuery := func(id string) (*http.Response, []byte) {
+			resp, err := http.Post(server.URL+"/api/v1/outgoing/"+id+"/explain", "application/json", nil) //nolint:noctx
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			return resp, body
+		}
+
+		unregister := registry.RegisterExplainer(1, func(_ context.Context) (string, error) {
+			return "Seq Scan on example", nil
+		})
+		defer unregister()
+
+		resp, body := explainQuery("1")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var explained apiExplainResponse
+
+		require.NoError(t, json.Unmarshal(body, &explained))
+		require.Equal(t, "Seq Scan on example", explained.Plan)
+
+		queries, err := storage.FindOutgoingQueries(&QueryFilter{Limit: 10})
+		require.NoError(t, err)
+
+		for _, query := range queries {
+			if query.ID == 1 {
+				require.Equal(t, "Seq Scan on example", query.Plan)
+			}
+		}
+
+		unregisterUnsupported := registry.RegisterExplainer(2, func(_ context.Context) (string, error) {
+			return "", fmt.Errorf("capture plan: %w", common.ErrMethodNotSupported)
+		})
+		defer unregisterUnsupported()
+
+		resp, _ = explainQuery("2")
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+		resp, _ = explainQuery("3")
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+type metadataCacheInvalidatorMock struct {
+	kind     api_common.EGenericDataSourceKind
+	database string
+}
+
+func (m *metadataCacheInvalidatorMock) Invalidate(kind api_common.EGenericDataSourceKind, database string) int {
+	m.kind, m.database = kind, database
+
+	return 3
+}
+
+func TestAPIInvalidateMetadataCache(t *testing.T) {
+	metadataCache := &metadataCacheInvalidatorMock{}
+
+	s := &serviceImpl{metadataCache: metadataCache, logger: zap.NewNop()}
+
+	mux := http.NewServeMux()
+	s.registerAPIHandlers(mux)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	invalidate := func(params string) *http.Response {
+		resp, err := http.Post(server.URL+"/api/v1/metadata_cache/invalidate"+params, "application/json", nil) //nolint:noctx
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	resp := invalidate("?data_source_kind=MONGO_DB&database=db")
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result apiInvalidateResponse
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Equal(t, 3, result.Invalidated)
+	require.Equal(t, api_common.EGenericDataSourceKind_MONGO_DB, metadataCache.kind)
+	require.Equal(t, "db", metadataCache.database)
+
+	resp = invalidate("")
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED, metadataCache.kind)
+
+	resp = invalidate("?data_source_kind=UNKNOWN")
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -19,6 +19,13 @@ func getTemplates() (*template.Template, error) {
 		"inc": func(i int) int {
 			return i + 1
 		},
+		"ms": func(v *float64) string {
+			if v == nil {
+				return "-"
+			}
+
+			return fmt.Sprintf("%.3f", *v)
+		},
 		"lower": func(v any) string {
 			// Handle different types gracefully
 			switch value := v.(type) {
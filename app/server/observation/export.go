@@ -0,0 +1,247 @@
+package observation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet"
+	"github.com/apache/arrow/go/v13/parquet/compress"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+)
+
+// ExportFormat is a file format used for the query records export
+type ExportFormat string
+
+const (
+	ExportFormatJSONLines ExportFormat = "jsonl"
+	ExportFormatParquet   ExportFormat = "parquet"
+)
+
+// ExportTable determines which kind of queries is exported
+type ExportTable string
+
+const (
+	ExportTableIncoming ExportTable = "incoming"
+	ExportTableOutgoing ExportTable = "outgoing"
+)
+
+// ExportParams describes the export of the query records created within [From, To) time window
+type ExportParams struct {
+	Table  ExportTable
+	Format ExportFormat
+	From   time.Time
+	To     time.Time
+}
+
+// parquetBatchSize is the number of rows accumulated in memory before being written to the Parquet file
+const parquetBatchSize = 1024
+
+var timestampType = &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+
+var incomingQuerySchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Uint64},
+	{Name: "data_source_kind", Type: arrow.BinaryTypes.String},
+	{Name: "rows_read", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "bytes_read", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "state", Type: arrow.BinaryTypes.String},
+	{Name: "created_at", Type: timestampType},
+	{Name: "finished_at", Type: timestampType, Nullable: true},
+	{Name: "error", Type: arrow.BinaryTypes.String},
+	{Name: "policy", Type: arrow.BinaryTypes.String},
+}, nil)
+
+func appendIncomingQuery(builder *array.RecordBuilder, query *IncomingQuery) {
+	builder.Field(0).(*array.Uint64Builder).Append(uint64(query.ID))
+	builder.Field(1).(*array.StringBuilder).Append(query.DataSourceKind)
+	builder.Field(2).(*array.Int64Builder).Append(query.RowsRead)
+	builder.Field(3).(*array.Int64Builder).Append(query.BytesRead)
+	builder.Field(4).(*array.StringBuilder).Append(string(query.State))
+	appendTimestamp(builder.Field(5).(*array.TimestampBuilder), &query.CreatedAt)
+	appendTimestamp(builder.Field(6).(*array.TimestampBuilder), query.FinishedAt)
+	builder.Field(7).(*array.StringBuilder).Append(query.Error)
+	builder.Field(8).(*array.StringBuilder).Append(query.Policy)
+}
+
+var outgoingQuerySchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Uint64},
+	{Name: "incoming_query_id", Type: arrow.PrimitiveTypes.Uint64},
+	{Name: "database_name", Type: arrow.BinaryTypes.String},
+	{Name: "database_endpoint", Type: arrow.BinaryTypes.String},
+	{Name: "query_text", Type: arrow.BinaryTypes.String},
+	{Name: "query_args", Type: arrow.BinaryTypes.String},
+	{Name: "state", Type: arrow.BinaryTypes.String},
+	{Name: "created_at", Type: timestampType},
+	{Name: "finished_at", Type: timestampType, Nullable: true},
+	{Name: "rows_read", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "error", Type: arrow.BinaryTypes.String},
+	{Name: "bytes_read", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "pages_emitted", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "time_to_first_row_ms", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "plan", Type: arrow.BinaryTypes.String},
+}, nil)
+
+func appendOutgoingQuery(builder *array.RecordBuilder, query *OutgoingQuery) {
+	builder.Field(0).(*array.Uint64Builder).Append(uint64(query.ID))
+	builder.Field(1).(*array.Uint64Builder).Append(uint64(query.IncomingQueryID))
+	builder.Field(2).(*array.StringBuilder).Append(query.DatabaseName)
+	builder.Field(3).(*array.StringBuilder).Append(query.DatabaseEndpoint)
+	builder.Field(4).(*array.StringBuilder).Append(query.QueryText)
+	builder.Field(5).(*array.StringBuilder).Append(query.QueryArgs)
+	builder.Field(6).(*array.StringBuilder).Append(string(query.State))
+	appendTimestamp(builder.Field(7).(*array.TimestampBuilder), &query.CreatedAt)
+	appendTimestamp(builder.Field(8).(*array.TimestampBuilder), query.FinishedAt)
+	builder.Field(9).(*array.Int64Builder).Append(query.RowsRead)
+	builder.Field(10).(*array.StringBuilder).Append(query.Error)
+	builder.Field(11).(*array.Int64Builder).Append(query.BytesRead)
+	builder.Field(12).(*array.Int64Builder).Append(query.PagesEmitted)
+
+	if query.TimeToFirstRowMs != nil {
+		builder.Field(13).(*array.Float64Builder).Append(*query.TimeToFirstRowMs)
+	} else {
+		builder.Field(13).(*array.Float64Builder).AppendNull()
+	}
+
+	builder.Field(14).(*array.StringBuilder).Append(query.Plan)
+}
+
+func appendTimestamp(builder *array.TimestampBuilder, t *time.Time) {
+	if t == nil {
+		builder.AppendNull()
+		return
+	}
+
+	builder.Append(arrow.Timestamp(t.UnixMicro()))
+}
+
+// Export writes the query records to the writer in the requested format.
+// Returns the number of exported records.
+func Export(storage Storage, params *ExportParams, w io.Writer) (int, error) {
+	switch params.Table {
+	case ExportTableIncoming:
+		return exportTable(
+			params.Format, w, incomingQuerySchema, appendIncomingQuery,
+			func(handler func(*IncomingQuery) error) error {
+				return storage.ExportIncomingQueries(params.From, params.To, handler)
+			},
+		)
+	case ExportTableOutgoing:
+		return exportTable(
+			params.Format, w, outgoingQuerySchema, appendOutgoingQuery,
+			func(handler func(*OutgoingQuery) error) error {
+				return storage.ExportOutgoingQueries(params.From, params.To, handler)
+			},
+		)
+	default:
+		return 0, fmt.Errorf("unknown table '%s'", params.Table)
+	}
+}
+
+func exportTable[T any](
+	format ExportFormat,
+	w io.Writer,
+	schema *arrow.Schema,
+	appendRow func(*array.RecordBuilder, *T),
+	export func(handler func(*T) error) error,
+) (int, error) {
+	switch format {
+	case ExportFormatJSONLines:
+		return exportJSONLines(w, export)
+	case ExportFormatParquet:
+		return exportParquet(w, schema, appendRow, export)
+	default:
+		return 0, fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+func exportJSONLines[T any](w io.Writer, export func(handler func(*T) error) error) (int, error) {
+	var (
+		encoder = json.NewEncoder(w)
+		total   int
+	)
+
+	err := export(func(row *T) error {
+		if err := encoder.Encode(row); err != nil {
+			return fmt.Errorf("encode JSON: %w", err)
+		}
+
+		total++
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("export: %w", err)
+	}
+
+	return total, nil
+}
+
+func exportParquet[T any](
+	w io.Writer,
+	schema *arrow.Schema,
+	appendRow func(*array.RecordBuilder, *T),
+	export func(handler func(*T) error) error,
+) (int, error) {
+	writer, err := pqarrow.NewFileWriter(
+		schema,
+		// hide the Close method, otherwise Parquet writer would close the caller's writer
+		struct{ io.Writer }{w},
+		parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Zstd)),
+		pqarrow.DefaultWriterProps(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("new Parquet file writer: %w", err)
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	var total, batchRows int
+
+	flush := func() error {
+		record := builder.NewRecord()
+		defer record.Release()
+
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+
+		batchRows = 0
+
+		return nil
+	}
+
+	err = export(func(row *T) error {
+		appendRow(builder, row)
+
+		total++
+
+		if batchRows++; batchRows == parquetBatchSize {
+			return flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = writer.Close()
+		return 0, fmt.Errorf("export: %w", err)
+	}
+
+	if batchRows > 0 {
+		if err := flush(); err != nil {
+			_ = writer.Close()
+			return 0, err
+		}
+	}
+
+	// footer is written on close
+	if err := writer.Close(); err != nil {
+		return 0, fmt.Errorf("close Parquet file writer: %w", err)
+	}
+
+	return total, nil
+}
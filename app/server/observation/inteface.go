@@ -31,6 +31,8 @@ type IncomingQuery struct {
 	CreatedAt      time.Time       `json:"created_at"`
 	FinishedAt     *time.Time      `json:"finished_at,omitempty"`
 	Error          string          `json:"error,omitempty"`
+	// Data policy decisions applied to the query, empty if the query was not affected by the policy
+	Policy string `json:"policy,omitempty"`
 }
 
 // OutgoingQuery represents an outgoing query to a data source
@@ -45,16 +47,45 @@ type OutgoingQuery struct {
 	CreatedAt        time.Time       `json:"created_at"`
 	FinishedAt       *time.Time      `json:"finished_at,omitempty"`
 	RowsRead         int64           `json:"rows_read"`
+	BytesRead        int64           `json:"bytes_read"`
+	PagesEmitted     int64           `json:"pages_emitted"`
+	TimeToFirstRowMs *float64        `json:"time_to_first_row_ms,omitempty"`
+	Plan             string          `json:"plan,omitempty"`
 	Error            string          `json:"error,omitempty"`
 }
 
+// OutgoingQueryStats describes the resources consumed by the outgoing query
+type OutgoingQueryStats struct {
+	RowsRead     int64
+	BytesRead    int64
+	PagesEmitted int64
+	// Time elapsed between the start of the query and the first row received, nil if there were no rows
+	TimeToFirstRow *time.Duration
+}
+
+// QueryFilter restricts the list of queries; zero values of the fields mean no restriction
+type QueryFilter struct {
+	State          *QueryState
+	DataSourceKind string
+	DatabaseName   string
+	// Applicable to outgoing queries only
+	IncomingQueryID *IncomingQueryID
+	// Queries created within [CreatedFrom, CreatedTo) time window
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Limit       int
+	Offset      int
+}
+
 // Storage interface defines methods for query storage
 type Storage interface {
 	// Incoming query operations
 	CreateIncomingQuery(dataSourceKind api_common.EGenericDataSourceKind) (IncomingQueryID, error)
 	FinishIncomingQuery(id IncomingQueryID, stats *api_service_protos.TReadSplitsResponse_TStats) error
 	CancelIncomingQuery(id IncomingQueryID, errorMsg string, stats *api_service_protos.TReadSplitsResponse_TStats) error
+	SaveIncomingQueryPolicy(id IncomingQueryID, policy string) error
 	ListIncomingQueries(state *QueryState, limit, offset int) ([]*IncomingQuery, error)
+	FindIncomingQueries(filter *QueryFilter) ([]*IncomingQuery, error)
 
 	// Outgoing query operations
 	CreateOutgoingQuery(
@@ -64,13 +95,19 @@ type Storage interface {
 		queryText string,
 		queryArgs []any,
 	) (OutgoingQueryID, error)
-	FinishOutgoingQuery(id OutgoingQueryID, rowsRead int64) error
+	FinishOutgoingQuery(id OutgoingQueryID, stats *OutgoingQueryStats) error
 	CancelOutgoingQuery(id OutgoingQueryID, errorMsg string) error
+	SaveOutgoingQueryPlan(id OutgoingQueryID, plan string) error
 	ListOutgoingQueries(incomingQueryID *IncomingQueryID, state *QueryState, limit, offset int) ([]*OutgoingQuery, error)
+	FindOutgoingQueries(filter *QueryFilter) ([]*OutgoingQuery, error)
 
 	// Analysis operations
 	ListSimilarOutgoingQueriesWithDifferentStats(logger *zap.Logger) ([][]*OutgoingQuery, error)
 
+	// Export operations, handler is called for every query created within [from, to) time window
+	ExportIncomingQueries(from, to time.Time, handler func(*IncomingQuery) error) error
+	ExportOutgoingQueries(from, to time.Time, handler func(*OutgoingQuery) error) error
+
 	// Lifecycle
 	Close() error
 }
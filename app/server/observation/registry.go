@@ -0,0 +1,92 @@
+package observation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// ErrCanceledViaAPI is the cause of the context cancellation for the queries stopped by operator
+var ErrCanceledViaAPI = fmt.Errorf("canceled via observation API: %w", common.ErrQueryCanceled)
+
+// PlanExplainer captures the execution plan of the outgoing query
+type PlanExplainer func(ctx context.Context) (string, error)
+
+// QueryRegistry keeps the contexts of the incoming queries running within this process,
+// so that they could be canceled via observation API.
+// It also keeps the means to capture the execution plans of the running outgoing queries.
+type QueryRegistry struct {
+	mutex      sync.Mutex
+	cancels    map[IncomingQueryID]context.CancelCauseFunc
+	explainers map[OutgoingQueryID]PlanExplainer
+}
+
+// Register derives a cancellable context for the incoming query.
+// The returned function must be called when the query is finished.
+func (r *QueryRegistry) Register(ctx context.Context, id IncomingQueryID) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	r.mutex.Lock()
+	r.cancels[id] = cancel
+	r.mutex.Unlock()
+
+	return ctx, func() {
+		r.mutex.Lock()
+		delete(r.cancels, id)
+		r.mutex.Unlock()
+
+		cancel(nil)
+	}
+}
+
+// Cancel interrupts the incoming query. Returns false if the query is not running within this process.
+func (r *QueryRegistry) Cancel(id IncomingQueryID) bool {
+	r.mutex.Lock()
+	cancel, exists := r.cancels[id]
+	r.mutex.Unlock()
+
+	if exists {
+		cancel(ErrCanceledViaAPI)
+	}
+
+	return exists
+}
+
+// RegisterExplainer makes it possible to capture the plan of the outgoing query while it's running.
+// The returned function must be called when the query is finished.
+func (r *QueryRegistry) RegisterExplainer(id OutgoingQueryID, explainer PlanExplainer) func() {
+	r.mutex.Lock()
+	r.explainers[id] = explainer
+	r.mutex.Unlock()
+
+	return func() {
+		r.mutex.Lock()
+		delete(r.explainers, id)
+		r.mutex.Unlock()
+	}
+}
+
+// Explain captures the execution plan of the outgoing query.
+// Returns false if the query is not running within this process.
+func (r *QueryRegistry) Explain(ctx context.Context, id OutgoingQueryID) (string, bool, error) {
+	r.mutex.Lock()
+	explainer, exists := r.explainers[id]
+	r.mutex.Unlock()
+
+	if !exists {
+		return "", false, nil
+	}
+
+	plan, err := explainer(ctx)
+
+	return plan, true, err
+}
+
+func NewQueryRegistry() *QueryRegistry {
+	return &QueryRegistry{
+		cancels:    make(map[IncomingQueryID]context.CancelCauseFunc),
+		explainers: make(map[OutgoingQueryID]PlanExplainer),
+	}
+}
@@ -0,0 +1,114 @@
+package observation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// retentionPolicy describes which query records must be removed from storage
+type retentionPolicy struct {
+	maxAge        time.Duration // zero means no limit
+	maxRows       uint64        // zero means no limit
+	checkInterval time.Duration
+}
+
+func newRetentionPolicy(cfg *config.TObservationConfig_TStorage_TRetention) (*retentionPolicy, error) {
+	policy := &retentionPolicy{maxRows: cfg.MaxRows}
+
+	var err error
+
+	if cfg.MaxAge != "" {
+		policy.maxAge, err = common.DurationFromString(cfg.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("parse max age: %w", err)
+		}
+	}
+
+	policy.checkInterval, err = common.DurationFromString(cfg.CheckInterval)
+	if err != nil {
+		return nil, fmt.Errorf("parse check interval: %w", err)
+	}
+
+	return policy, nil
+}
+
+// retentionApplier is implemented by the storages supporting retention
+type retentionApplier interface {
+	// applyRetention removes outdated query records and returns the number of removed incoming queries
+	applyRetention(policy *retentionPolicy, now time.Time) (int64, error)
+	// compact returns the space occupied by the removed records to the file system
+	compact() error
+}
+
+// janitor periodically applies retention policy to the storage
+type janitor struct {
+	storage retentionApplier
+	policy  *retentionPolicy
+	logger  *zap.Logger
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func (j *janitor) run() {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.policy.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		j.cleanup()
+
+		select {
+		case <-ticker.C:
+		case <-j.ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *janitor) cleanup() {
+	removed, err := j.storage.applyRetention(j.policy, time.Now().UTC())
+	if err != nil {
+		j.logger.Error("apply retention policy", zap.Error(err))
+		return
+	}
+
+	if removed == 0 {
+		return
+	}
+
+	j.logger.Info("outdated queries removed", zap.Int64("incoming_queries", removed))
+
+	if err := j.storage.compact(); err != nil {
+		j.logger.Error("compact storage", zap.Error(err))
+	}
+}
+
+func (j *janitor) stop() {
+	j.cancel()
+	<-j.done
+}
+
+func startJanitor(logger *zap.Logger, storage retentionApplier, policy *retentionPolicy) *janitor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	j := &janitor{
+		storage: storage,
+		policy:  policy,
+		logger:  logger.With(zap.String("component", "observation_janitor")),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	go j.run()
+
+	return j
+}
@@ -26,11 +26,13 @@ var _ utils.Service = (*serviceImpl)(nil)
 
 // serviceImpl represents the HTTP service implementation
 type serviceImpl struct {
-	storage   Storage
-	server    *http.Server
-	listener  net.Listener
-	logger    *zap.Logger
-	templates *template.Template
+	storage       Storage
+	queryRegistry *QueryRegistry
+	metadataCache MetadataCacheInvalidator
+	server        *http.Server
+	listener      net.Listener
+	logger        *zap.Logger
+	templates     *template.Template
 }
 
 // Start starts the HTTP server on the specified address
@@ -599,6 +601,8 @@ func NewService(
 	logger *zap.Logger,
 	cfg *config.TObservationConfig,
 	storage Storage,
+	queryRegistry *QueryRegistry,
+	metadataCache MetadataCacheInvalidator,
 ) (utils.Service, error) {
 	// Load templates
 	templates, err := getTemplates()
@@ -607,9 +611,11 @@ func NewService(
 	}
 
 	var s = &serviceImpl{
-		logger:    logger,
-		storage:   storage,
-		templates: templates,
+		logger:        logger,
+		storage:       storage,
+		queryRegistry: queryRegistry,
+		metadataCache: metadataCache,
+		templates:     templates,
 	}
 
 	mux := http.NewServeMux()
@@ -647,6 +653,9 @@ func NewService(
 		s.requestLoggerMiddleware(http.HandlerFunc(s.handleListSimilarOutgoingQueriesWithDifferentStats)),
 	)
 
+	// Register JSON API handlers
+	s.registerAPIHandlers(mux)
+
 	// Create listener
 	addr := common.EndpointToString(cfg.Server.GetEndpoint())
 
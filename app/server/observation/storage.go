@@ -13,10 +13,20 @@ func NewStorage(logger *zap.Logger, cfg *config.TObservationConfig) (Storage, er
 		return storageDummyImpl{}, nil
 	}
 
-	storage, err := newStorageSQLite(logger, cfg.Storage.GetSqlite())
-	if err != nil {
-		return nil, fmt.Errorf("new storage SQLite: %w", err)
-	}
+	switch cfg.Storage.GetPayload().(type) {
+	case *config.TObservationConfig_TStorage_Postgresql:
+		storage, err := newStoragePostgreSQL(logger, cfg.Storage)
+		if err != nil {
+			return nil, fmt.Errorf("new storage PostgreSQL: %w", err)
+		}
+
+		return storage, nil
+	default:
+		storage, err := newStorageSQLite(logger, cfg.Storage)
+		if err != nil {
+			return nil, fmt.Errorf("new storage SQLite: %w", err)
+		}
 
-	return storage, nil
+		return storage, nil
+	}
 }
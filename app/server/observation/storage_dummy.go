@@ -1,6 +1,8 @@
 package observation
 
 import (
+	"time"
+
 	"go.uber.org/zap"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
@@ -25,6 +27,10 @@ func (storageDummyImpl) CancelIncomingQuery(_ IncomingQueryID, _ string, _ *api_
 	return nil
 }
 
+func (storageDummyImpl) FindIncomingQueries(_ *QueryFilter) ([]*IncomingQuery, error) {
+	return nil, nil
+}
+
 func (storageDummyImpl) ListIncomingQueries(_ *QueryState, _ int, _ int) ([]*IncomingQuery, error) {
 	return nil, nil
 }
@@ -40,7 +46,7 @@ func (storageDummyImpl) CreateOutgoingQuery(
 	return 0, nil
 }
 
-func (storageDummyImpl) FinishOutgoingQuery(_ OutgoingQueryID, _ int64) error {
+func (storageDummyImpl) FinishOutgoingQuery(_ OutgoingQueryID, _ *OutgoingQueryStats) error {
 	return nil
 }
 
@@ -48,6 +54,18 @@ func (storageDummyImpl) CancelOutgoingQuery(_ OutgoingQueryID, _ string) error {
 	return nil
 }
 
+func (storageDummyImpl) SaveIncomingQueryPolicy(_ IncomingQueryID, _ string) error {
+	return nil
+}
+
+func (storageDummyImpl) SaveOutgoingQueryPlan(_ OutgoingQueryID, _ string) error {
+	return nil
+}
+
+func (storageDummyImpl) FindOutgoingQueries(_ *QueryFilter) ([]*OutgoingQuery, error) {
+	return nil, nil
+}
+
 func (storageDummyImpl) ListOutgoingQueries(_ *IncomingQueryID, _ *QueryState, _ int, _ int) ([]*OutgoingQuery, error) {
 	return nil, nil
 }
@@ -57,6 +75,15 @@ func (storageDummyImpl) ListSimilarOutgoingQueriesWithDifferentStats(_ *zap.Logg
 	return nil, nil
 }
 
+// Export operations
+func (storageDummyImpl) ExportIncomingQueries(_, _ time.Time, _ func(*IncomingQuery) error) error {
+	return nil
+}
+
+func (storageDummyImpl) ExportOutgoingQueries(_, _ time.Time, _ func(*OutgoingQuery) error) error {
+	return nil
+}
+
 // Lifecycle
 func (storageDummyImpl) Close() error {
 	return nil
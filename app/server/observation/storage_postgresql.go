@@ -0,0 +1,719 @@
+package observation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ Storage = (*storagePostgreSQL)(nil)
+
+const (
+	// postgresqlQueryTimeout restricts the duration of every storage operation,
+	// so that the unavailable database does not block the queries being observed
+	postgresqlQueryTimeout = 10 * time.Second
+	// postgresqlExportTimeout restricts the duration of the export, which reads the whole time window
+	postgresqlExportTimeout = 5 * time.Minute
+	// postgresqlMigrationLockID is the key of the advisory lock preventing the concurrent migrations
+	// performed by several connector replicas started simultaneously
+	postgresqlMigrationLockID = 0x66715f6f6273 // "fq_obs"
+)
+
+// postgresqlMigrations contains the schema changes; the index of the migration plus one is the schema version.
+// Never edit the released migrations, append the new ones instead.
+var postgresqlMigrations = []string{
+	`
+	CREATE TABLE incoming_queries (
+		id BIGSERIAL PRIMARY KEY,
+		data_source_kind TEXT NOT NULL,
+		rows_read BIGINT NOT NULL DEFAULT 0,
+		bytes_read BIGINT NOT NULL DEFAULT 0,
+		state TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		finished_at TIMESTAMPTZ,
+		error TEXT
+	);
+
+	CREATE INDEX idx_incoming_queries_state ON incoming_queries(state);
+	CREATE INDEX idx_incoming_queries_created_at ON incoming_queries(created_at);
+	CREATE INDEX idx_incoming_queries_datasource_kind ON incoming_queries(data_source_kind);
+
+	CREATE TABLE outgoing_queries (
+		id BIGSERIAL PRIMARY KEY,
+		incoming_query_id BIGINT NOT NULL REFERENCES incoming_queries(id) ON DELETE CASCADE,
+		database_name TEXT NOT NULL,
+		database_endpoint TEXT NOT NULL,
+		query_text TEXT,
+		query_args TEXT,
+		rows_read BIGINT NOT NULL DEFAULT 0,
+		bytes_read BIGINT NOT NULL DEFAULT 0,
+		pages_emitted BIGINT NOT NULL DEFAULT 0,
+		time_to_first_row_ms DOUBLE PRECISION,
+		plan TEXT,
+		state TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		finished_at TIMESTAMPTZ,
+		error TEXT
+	);
+
+	CREATE INDEX idx_outgoing_queries_state ON outgoing_queries(state);
+	CREATE INDEX idx_outgoing_queries_created_at ON outgoing_queries(created_at);
+	CREATE INDEX idx_outgoing_queries_incoming_id ON outgoing_queries(incoming_query_id);
+	CREATE INDEX idx_outgoing_queries_database_name ON outgoing_queries(database_name);
+	`,
+	`
+	ALTER TABLE incoming_queries ADD COLUMN policy TEXT;
+	`,
+}
+
+// storagePostgreSQL keeps query data in PostgreSQL database that can be shared by several connector replicas
+type storagePostgreSQL struct {
+	pool    *pgxpool.Pool
+	janitor *janitor // nil if retention policy is not configured
+}
+
+// postgresqlArgs collects query arguments and renders the positional placeholders for them
+type postgresqlArgs struct {
+	values []any
+}
+
+func (a *postgresqlArgs) add(value any) string {
+	a.values = append(a.values, value)
+
+	return "$" + strconv.Itoa(len(a.values))
+}
+
+func (*storagePostgreSQL) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), postgresqlQueryTimeout)
+}
+
+// migrate applies the migrations missing in the database.
+// The whole process is serialized with an advisory lock, since replicas may be started simultaneously.
+func (s *storagePostgreSQL) migrate() error {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", postgresqlMigrationLockID); err != nil {
+			return fmt.Errorf("acquiring migration lock: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			CREATE TABLE IF NOT EXISTS observation_schema_migrations (
+				version INTEGER PRIMARY KEY,
+				applied_at TIMESTAMPTZ NOT NULL
+			)`,
+		); err != nil {
+			return fmt.Errorf("creating migrations table: %w", err)
+		}
+
+		var version int
+
+		if err := tx.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM observation_schema_migrations").Scan(&version); err != nil {
+			return fmt.Errorf("selecting schema version: %w", err)
+		}
+
+		if version > len(postgresqlMigrations) {
+			return fmt.Errorf("schema version %d is newer than the latest known version %d", version, len(postgresqlMigrations))
+		}
+
+		for i := version; i < len(postgresqlMigrations); i++ {
+			if _, err := tx.Exec(ctx, postgresqlMigrations[i]); err != nil {
+				return fmt.Errorf("applying migration %d: %w", i+1, err)
+			}
+
+			if _, err := tx.Exec(
+				ctx,
+				"INSERT INTO observation_schema_migrations (version, applied_at) VALUES ($1, $2)",
+				i+1, time.Now().UTC(),
+			); err != nil {
+				return fmt.Errorf("saving schema version %d: %w", i+1, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+
+	return nil
+}
+
+// exec runs the statement expected to modify exactly one row
+func (s *storagePostgreSQL) exec(entity string, id uint64, querySQL string, args ...any) error {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	tag, err := s.pool.Exec(ctx, querySQL, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s not found: %d", entity, id)
+	}
+
+	return nil
+}
+
+// CreateIncomingQuery creates a new incoming query record
+func (s *storagePostgreSQL) CreateIncomingQuery(dataSourceKind api_common.EGenericDataSourceKind) (IncomingQueryID, error) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	var id IncomingQueryID
+
+	err := s.pool.QueryRow(
+		ctx,
+		`INSERT INTO incoming_queries (data_source_kind, created_at, rows_read, bytes_read, state)
+		VALUES ($1, $2, 0, 0, $3) RETURNING id`,
+		dataSourceKind.String(), time.Now().UTC(), string(QueryStateRunning),
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("creating incoming query: %w", err)
+	}
+
+	return id, nil
+}
+
+// FinishIncomingQuery marks an incoming query as finished with final stats
+func (s *storagePostgreSQL) FinishIncomingQuery(id IncomingQueryID, stats *api_service_protos.TReadSplitsResponse_TStats) error {
+	err := s.exec(
+		"incoming query", uint64(id),
+		"UPDATE incoming_queries SET state = $1, finished_at = $2, rows_read = $3, bytes_read = $4 WHERE id = $5",
+		string(QueryStateFinished), time.Now().UTC(), int64(stats.Rows), int64(stats.Bytes), int64(id),
+	)
+	if err != nil {
+		return fmt.Errorf("marking incoming query as finished: %w", err)
+	}
+
+	return nil
+}
+
+// CancelIncomingQuery marks an incoming query as canceled with an error message
+func (s *storagePostgreSQL) CancelIncomingQuery(
+	id IncomingQueryID,
+	errorMsg string,
+	stats *api_service_protos.TReadSplitsResponse_TStats,
+) error {
+	err := s.exec(
+		"incoming query", uint64(id),
+		"UPDATE incoming_queries SET state = $1, finished_at = $2, error = $3, rows_read = $4, bytes_read = $5 WHERE id = $6",
+		string(QueryStateCancelled), time.Now().UTC(), errorMsg, int64(stats.Rows), int64(stats.Bytes), int64(id),
+	)
+	if err != nil {
+		return fmt.Errorf("canceling incoming query: %w", err)
+	}
+
+	return nil
+}
+
+// ListIncomingQueries retrieves a list of incoming queries with optional filtering
+func (s *storagePostgreSQL) ListIncomingQueries(state *QueryState, limit, offset int) ([]*IncomingQuery, error) {
+	queries, err := s.FindIncomingQueries(&QueryFilter{State: state, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, fmt.Errorf("listing incoming queries: %w", err)
+	}
+
+	return queries, nil
+}
+
+// SaveIncomingQueryPolicy stores the data policy decisions applied to the incoming query
+func (s *storagePostgreSQL) SaveIncomingQueryPolicy(id IncomingQueryID, policy string) error {
+	err := s.exec("incoming query", uint64(id), "UPDATE incoming_queries SET policy = $1 WHERE id = $2", policy, int64(id))
+	if err != nil {
+		return fmt.Errorf("saving incoming query policy: %w", err)
+	}
+
+	return nil
+}
+
+// CreateOutgoingQuery creates a new outgoing query associated with an incoming query
+func (s *storagePostgreSQL) CreateOutgoingQuery(
+	_ *zap.Logger,
+	incomingQueryID IncomingQueryID,
+	dsi *api_common.TGenericDataSourceInstance,
+	queryText string,
+	queryArgs []any,
+) (OutgoingQueryID, error) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	var id OutgoingQueryID
+
+	// The existence of the incoming query is checked by the foreign key constraint
+	err := s.pool.QueryRow(
+		ctx,
+		`INSERT INTO outgoing_queries
+		(incoming_query_id, database_name, database_endpoint, rows_read, query_text, query_args, created_at, state)
+		VALUES ($1, $2, $3, 0, $4, $5, $6, $7) RETURNING id`,
+		int64(incomingQueryID), dsi.Database, common.EndpointToString(dsi.Endpoint),
+		queryText, fmt.Sprint(queryArgs), time.Now().UTC(), string(QueryStateRunning),
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return 0, fmt.Errorf("incoming query not found: %d", incomingQueryID)
+		}
+
+		return 0, fmt.Errorf("creating outgoing query: %w", err)
+	}
+
+	return id, nil
+}
+
+// FinishOutgoingQuery marks an outgoing query as finished
+func (s *storagePostgreSQL) FinishOutgoingQuery(id OutgoingQueryID, stats *OutgoingQueryStats) error {
+	var timeToFirstRowMs *float64
+
+	if stats.TimeToFirstRow != nil {
+		ms := durationToMs(*stats.TimeToFirstRow)
+		timeToFirstRowMs = &ms
+	}
+
+	err := s.exec(
+		"outgoing query", uint64(id),
+		`UPDATE outgoing_queries SET state = $1, finished_at = $2, rows_read = $3, bytes_read = $4,
+		pages_emitted = $5, time_to_first_row_ms = $6 WHERE id = $7`,
+		string(QueryStateFinished), time.Now().UTC(), stats.RowsRead, stats.BytesRead,
+		stats.PagesEmitted, timeToFirstRowMs, int64(id),
+	)
+	if err != nil {
+		return fmt.Errorf("marking outgoing query as finished: %w", err)
+	}
+
+	return nil
+}
+
+// CancelOutgoingQuery marks an outgoing query as canceled with an error message
+func (s *storagePostgreSQL) CancelOutgoingQuery(id OutgoingQueryID, errorMsg string) error {
+	err := s.exec(
+		"outgoing query", uint64(id),
+		"UPDATE outgoing_queries SET state = $1, finished_at = $2, error = $3 WHERE id = $4",
+		string(QueryStateCancelled), time.Now().UTC(), errorMsg, int64(id),
+	)
+	if err != nil {
+		return fmt.Errorf("canceling outgoing query: %w", err)
+	}
+
+	return nil
+}
+
+// SaveOutgoingQueryPlan stores the execution plan captured for the outgoing query
+func (s *storagePostgreSQL) SaveOutgoingQueryPlan(id OutgoingQueryID, plan string) error {
+	err := s.exec("outgoing query", uint64(id), "UPDATE outgoing_queries SET plan = $1 WHERE id = $2", plan, int64(id))
+	if err != nil {
+		return fmt.Errorf("saving outgoing query plan: %w", err)
+	}
+
+	return nil
+}
+
+// ListOutgoingQueries retrieves a list of outgoing queries with optional filtering
+func (s *storagePostgreSQL) ListOutgoingQueries(
+	incomingQueryID *IncomingQueryID,
+	state *QueryState,
+	limit, offset int,
+) ([]*OutgoingQuery, error) {
+	queries, err := s.FindOutgoingQueries(&QueryFilter{
+		IncomingQueryID: incomingQueryID,
+		State:           state,
+		Limit:           limit,
+		Offset:          offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing outgoing queries: %w", err)
+	}
+
+	return queries, nil
+}
+
+// ListSimilarOutgoingQueriesWithDifferentStats finds outgoing queries with the same characteristics but different resource usage.
+// Unlike SQLite implementation, the groups are fetched within a single round trip.
+func (s *storagePostgreSQL) ListSimilarOutgoingQueriesWithDifferentStats(_ *zap.Logger) ([][]*OutgoingQuery, error) {
+	querySQL := `
+	WITH query_groups AS (
+		SELECT
+			database_name,
+			database_endpoint,
+			query_text,
+			query_args,
+			COUNT(*) AS queries_count
+		FROM
+			outgoing_queries
+		WHERE
+			query_text IS NOT NULL AND
+			query_text != '' AND
+			state = $1
+		GROUP BY
+			database_name, database_endpoint, query_text, query_args
+		HAVING
+			COUNT(*) > 1 AND
+			COUNT(DISTINCT rows_read) > 1
+		ORDER BY
+			queries_count DESC
+		LIMIT 100
+	)
+	SELECT ` + outgoingQueryColumns("o.") + `
+	FROM
+		outgoing_queries o
+		JOIN query_groups g USING (database_name, database_endpoint, query_text, query_args)
+	WHERE
+		o.state = $1
+	ORDER BY
+		g.queries_count DESC, o.database_name, o.database_endpoint, o.query_text, o.query_args,
+		o.rows_read DESC, o.created_at DESC
+	`
+
+	var (
+		result [][]*OutgoingQuery
+		group  []*OutgoingQuery
+	)
+
+	sameGroup := func(a, b *OutgoingQuery) bool {
+		return a.DatabaseName == b.DatabaseName && a.DatabaseEndpoint == b.DatabaseEndpoint &&
+			a.QueryText == b.QueryText && a.QueryArgs == b.QueryArgs
+	}
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	err := s.selectOutgoingQueries(ctx, querySQL, []any{string(QueryStateFinished)}, func(query *OutgoingQuery) error {
+		if len(group) > 0 && !sameGroup(group[0], query) {
+			result = append(result, group)
+			group = nil
+		}
+
+		group = append(group, query)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding similar outgoing queries: %w", err)
+	}
+
+	if len(group) > 0 {
+		result = append(result, group)
+	}
+
+	return result, nil
+}
+
+// postgresqlQueryFilterConditions converts the filter into SQL conditions over the table with the given alias
+func postgresqlQueryFilterConditions(filter *QueryFilter, alias string, args *postgresqlArgs) []string {
+	var conditions []string
+
+	if filter.State != nil {
+		conditions = append(conditions, alias+".state = "+args.add(string(*filter.State)))
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, alias+".created_at >= "+args.add(filter.CreatedFrom.UTC()))
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, alias+".created_at < "+args.add(filter.CreatedTo.UTC()))
+	}
+
+	return conditions
+}
+
+// FindIncomingQueries lists incoming queries matching the filter, the latest ones go first
+func (s *storagePostgreSQL) FindIncomingQueries(filter *QueryFilter) ([]*IncomingQuery, error) {
+	args := &postgresqlArgs{}
+	conditions := postgresqlQueryFilterConditions(filter, "i", args)
+
+	if filter.DataSourceKind != "" {
+		conditions = append(conditions, "i.data_source_kind = "+args.add(filter.DataSourceKind))
+	}
+
+	if filter.DatabaseName != "" {
+		conditions = append(conditions,
+			"EXISTS (SELECT 1 FROM outgoing_queries o WHERE o.incoming_query_id = i.id AND o.database_name = "+
+				args.add(filter.DatabaseName)+")")
+	}
+
+	querySQL := fmt.Sprintf(`
+		SELECT %s
+		FROM incoming_queries i %s ORDER BY i.created_at DESC LIMIT %s OFFSET %s`,
+		incomingQueryColumns("i."),
+		whereClause(conditions), args.add(filter.Limit), args.add(filter.Offset),
+	)
+
+	var queries []*IncomingQuery
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	err := s.selectIncomingQueries(ctx, querySQL, args.values, func(query *IncomingQuery) error {
+		queries = append(queries, query)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// FindOutgoingQueries lists outgoing queries matching the filter, the latest ones go first
+func (s *storagePostgreSQL) FindOutgoingQueries(filter *QueryFilter) ([]*OutgoingQuery, error) {
+	args := &postgresqlArgs{}
+	conditions := postgresqlQueryFilterConditions(filter, "o", args)
+
+	if filter.IncomingQueryID != nil {
+		conditions = append(conditions, "o.incoming_query_id = "+args.add(int64(*filter.IncomingQueryID)))
+	}
+
+	if filter.DataSourceKind != "" {
+		conditions = append(conditions, "i.data_source_kind = "+args.add(filter.DataSourceKind))
+	}
+
+	if filter.DatabaseName != "" {
+		conditions = append(conditions, "o.database_name = "+args.add(filter.DatabaseName))
+	}
+
+	querySQL := fmt.Sprintf(`
+		SELECT %s
+		FROM outgoing_queries o JOIN incoming_queries i ON o.incoming_query_id = i.id
+		%s ORDER BY o.created_at DESC LIMIT %s OFFSET %s`,
+		outgoingQueryColumns("o."),
+		whereClause(conditions), args.add(filter.Limit), args.add(filter.Offset),
+	)
+
+	var queries []*OutgoingQuery
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	err := s.selectOutgoingQueries(ctx, querySQL, args.values, func(query *OutgoingQuery) error {
+		queries = append(queries, query)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// ExportIncomingQueries calls handler for every incoming query created within [from, to) time window
+func (s *storagePostgreSQL) ExportIncomingQueries(from, to time.Time, handler func(*IncomingQuery) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), postgresqlExportTimeout)
+	defer cancel()
+
+	return s.selectIncomingQueries(ctx, `
+		SELECT `+incomingQueryColumns("")+`
+		FROM incoming_queries WHERE created_at >= $1 AND created_at < $2 ORDER BY id`,
+		[]any{from.UTC(), to.UTC()},
+		handler,
+	)
+}
+
+// ExportOutgoingQueries calls handler for every outgoing query created within [from, to) time window
+func (s *storagePostgreSQL) ExportOutgoingQueries(from, to time.Time, handler func(*OutgoingQuery) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), postgresqlExportTimeout)
+	defer cancel()
+
+	return s.selectOutgoingQueries(ctx, `
+		SELECT `+outgoingQueryColumns("")+`
+		FROM outgoing_queries WHERE created_at >= $1 AND created_at < $2 ORDER BY id`,
+		[]any{from.UTC(), to.UTC()},
+		handler,
+	)
+}
+
+// selectIncomingQueries streams the rows to the handler, the context restricts the duration of the whole operation
+func (s *storagePostgreSQL) selectIncomingQueries(
+	ctx context.Context,
+	querySQL string,
+	args []any,
+	handler func(*IncomingQuery) error,
+) error {
+	rows, err := s.pool.Query(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("selecting incoming queries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			query            IncomingQuery
+			errorMsg, policy pgtype.Text
+		)
+
+		if err := rows.Scan(
+			&query.ID, &query.DataSourceKind, &query.RowsRead, &query.BytesRead,
+			&query.State, &query.CreatedAt, &query.FinishedAt, &errorMsg, &policy,
+		); err != nil {
+			return fmt.Errorf("scanning incoming query: %w", err)
+		}
+
+		query.Error = errorMsg.String
+		query.Policy = policy.String
+
+		if err := handler(&query); err != nil {
+			return fmt.Errorf("handle incoming query: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating incoming queries: %w", err)
+	}
+
+	return nil
+}
+
+func (s *storagePostgreSQL) selectOutgoingQueries(
+	ctx context.Context,
+	querySQL string,
+	args []any,
+	handler func(*OutgoingQuery) error,
+) error {
+	rows, err := s.pool.Query(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("selecting outgoing queries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			query                                OutgoingQuery
+			queryText, queryArgs, errorMsg, plan pgtype.Text
+		)
+
+		// Nullable timestamps and numbers are scanned into the pointer fields directly
+		if err := rows.Scan(
+			&query.ID, &query.IncomingQueryID, &query.DatabaseName, &query.DatabaseEndpoint,
+			&queryText, &queryArgs, &query.State, &query.CreatedAt, &query.FinishedAt, &errorMsg,
+			&query.RowsRead, &query.BytesRead, &query.PagesEmitted, &query.TimeToFirstRowMs, &plan,
+		); err != nil {
+			return fmt.Errorf("scanning outgoing query: %w", err)
+		}
+
+		query.QueryText = queryText.String
+		query.QueryArgs = queryArgs.String
+		query.Error = errorMsg.String
+		query.Plan = plan.String
+
+		if err := handler(&query); err != nil {
+			return fmt.Errorf("handle outgoing query: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating outgoing queries: %w", err)
+	}
+
+	return nil
+}
+
+// applyRetention removes incoming queries exceeding the retention limits,
+// outgoing queries are removed by the foreign key cascade.
+func (s *storagePostgreSQL) applyRetention(policy *retentionPolicy, now time.Time) (int64, error) {
+	var (
+		conditions []string
+		args       = &postgresqlArgs{}
+	)
+
+	if policy.maxAge > 0 {
+		conditions = append(conditions, "created_at < "+args.add(now.Add(-policy.maxAge)))
+	}
+
+	if policy.maxRows > 0 {
+		conditions = append(conditions,
+			"id IN (SELECT id FROM incoming_queries ORDER BY id DESC OFFSET "+args.add(int64(policy.maxRows))+")")
+	}
+
+	if len(conditions) == 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	tag, err := s.pool.Exec(ctx, "DELETE FROM incoming_queries WHERE "+strings.Join(conditions, " OR "), args.values...)
+	if err != nil {
+		return 0, fmt.Errorf("deleting incoming queries: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// compact does nothing, since the space occupied by the removed records is reclaimed by autovacuum
+func (*storagePostgreSQL) compact() error {
+	return nil
+}
+
+// Close closes the connection pool
+func (s *storagePostgreSQL) Close() error {
+	if s.janitor != nil {
+		s.janitor.stop()
+	}
+
+	s.pool.Close()
+
+	return nil
+}
+
+// newStoragePostgreSQL creates a new Storage instance
+func newStoragePostgreSQL(logger *zap.Logger, storageCfg *config.TObservationConfig_TStorage) (Storage, error) {
+	cfg := storageCfg.GetPostgresql()
+
+	poolCfg, err := pgxpool.ParseConfig(cfg.Dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse DSN: %w", err)
+	}
+
+	if cfg.MaxConnections > 0 {
+		poolCfg.MaxConns = int32(cfg.MaxConnections)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), postgresqlQueryTimeout)
+	defer cancel()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("new pool: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ping: %w", err)
+	}
+
+	storage := &storagePostgreSQL{pool: pool}
+
+	if err := storage.migrate(); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	if storageCfg.Retention != nil {
+		policy, err := newRetentionPolicy(storageCfg.Retention)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("new retention policy: %w", err)
+		}
+
+		storage.janitor = startJanitor(logger, storage, policy)
+	}
+
+	return storage, nil
+}
@@ -3,6 +3,7 @@ package observation
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -18,8 +19,9 @@ var _ Storage = (*storageSQLite)(nil)
 
 // storageSQLite handles storing and retrieving query data
 type storageSQLite struct {
-	db   *sql.DB
-	path string
+	db      *sql.DB
+	path    string
+	janitor *janitor // nil if retention policy is not configured
 }
 
 // initialize creates the necessary tables if they don't exist
@@ -34,7 +36,8 @@ func (s *storageSQLite) initialize() error {
 		state TEXT NOT NULL,
 		created_at TIMESTAMP NOT NULL,
 		finished_at TIMESTAMP,
-		error TEXT
+		error TEXT,
+		policy TEXT
 	);
 	
 	CREATE INDEX IF NOT EXISTS idx_incoming_queries_state ON incoming_queries(state);
@@ -52,6 +55,10 @@ func (s *storageSQLite) initialize() error {
 		query_text TEXT,
 		query_args TEXT,
 		rows_read INTEGER NOT NULL DEFAULT 0,
+		bytes_read INTEGER NOT NULL DEFAULT 0,
+		pages_emitted INTEGER NOT NULL DEFAULT 0,
+		time_to_first_row_ms REAL,
+		plan TEXT,
 		state TEXT NOT NULL,
 		created_at TIMESTAMP NOT NULL,
 		finished_at TIMESTAMP,
@@ -84,6 +91,79 @@ func (s *storageSQLite) initialize() error {
 		return fmt.Errorf("creating outgoing_queries table: %w", err)
 	}
 
+	if err := s.migrate(); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	return nil
+}
+
+// sqliteMigration describes the column added to the table after its first release
+type sqliteMigration struct {
+	column     string
+	definition string
+}
+
+// incomingQueriesMigrations lists the columns added to the incoming_queries table after its first release
+var incomingQueriesMigrations = []sqliteMigration{
+	{"policy", "TEXT"},
+}
+
+// outgoingQueriesMigrations lists the columns added to the outgoing_queries table after its first release
+var outgoingQueriesMigrations = []sqliteMigration{
+	{"bytes_read", "INTEGER NOT NULL DEFAULT 0"},
+	{"pages_emitted", "INTEGER NOT NULL DEFAULT 0"},
+	{"time_to_first_row_ms", "REAL"},
+	{"plan", "TEXT"},
+}
+
+// migrate adds the missing columns to the tables created by the previous versions of the service
+func (s *storageSQLite) migrate() error {
+	if err := s.migrateTable("incoming_queries", incomingQueriesMigrations); err != nil {
+		return fmt.Errorf("migrate incoming_queries: %w", err)
+	}
+
+	if err := s.migrateTable("outgoing_queries", outgoingQueriesMigrations); err != nil {
+		return fmt.Errorf("migrate outgoing_queries: %w", err)
+	}
+
+	return nil
+}
+
+func (s *storageSQLite) migrateTable(table string, migrations []sqliteMigration) error {
+	rows, err := s.db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return fmt.Errorf("selecting table info: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool)
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("scanning column name: %w", err)
+		}
+
+		existing[name] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating columns: %w", err)
+	}
+
+	for _, migration := range migrations {
+		if existing[migration.column] {
+			continue
+		}
+
+		if _, err := s.db.Exec(
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, migration.column, migration.definition),
+		); err != nil {
+			return fmt.Errorf("adding column %s: %w", migration.column, err)
+		}
+	}
+
 	return nil
 }
 
@@ -175,54 +255,46 @@ func (s *storageSQLite) ListIncomingQueries(state *QueryState, limit, offset int
 
 	if state == nil {
 		querySQL = `
-			SELECT id, data_source_kind, rows_read, bytes_read, state, created_at, finished_at, error
+			SELECT ` + incomingQueryColumns("") + `
 			FROM incoming_queries ORDER BY created_at DESC LIMIT ? OFFSET ?`
 		args = []any{limit, offset}
 	} else {
 		querySQL = `
-			SELECT id, data_source_kind, rows_read, bytes_read, state, created_at, finished_at, error
+			SELECT ` + incomingQueryColumns("") + `
 			FROM incoming_queries WHERE state = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`
 		args = []any{string(*state), limit, offset}
 	}
 
-	rows, err := s.db.Query(querySQL, args...)
+	var queries []*IncomingQuery
+
+	err := s.selectIncomingQueries(querySQL, args, func(query *IncomingQuery) error {
+		queries = append(queries, query)
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("listing incoming queries: %w", err)
 	}
-	defer rows.Close()
-
-	var queries []*IncomingQuery
-
-	for rows.Next() {
-		var (
-			query      IncomingQuery
-			finishedAt sql.NullTime
-			errorMsg   sql.NullString
-		)
 
-		if err := rows.Scan(
-			&query.ID, &query.DataSourceKind, &query.RowsRead, &query.BytesRead,
-			&query.State, &query.CreatedAt, &finishedAt, &errorMsg,
-		); err != nil {
-			return nil, fmt.Errorf("scanning incoming query: %w", err)
-		}
-
-		if errorMsg.Valid {
-			query.Error = errorMsg.String
-		}
+	return queries, nil
+}
 
-		if finishedAt.Valid {
-			query.FinishedAt = &finishedAt.Time
-		}
+// SaveIncomingQueryPolicy stores the data policy decisions applied to the incoming query
+func (s *storageSQLite) SaveIncomingQueryPolicy(id IncomingQueryID, policy string) error {
+	result, err := s.db.Exec("UPDATE incoming_queries SET policy = ? WHERE id = ?", policy, id)
+	if err != nil {
+		return fmt.Errorf("saving incoming query policy: %w", err)
+	}
 
-		queries = append(queries, &query)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking rows affected: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating incoming queries: %w", err)
+	if rowsAffected == 0 {
+		return fmt.Errorf("incoming query not found: %d", id)
 	}
 
-	return queries, nil
+	return nil
 }
 
 // CreateOutgoingQuery creates a new outgoing query associated with an incoming query
@@ -301,12 +373,20 @@ func (s *storageSQLite) CreateOutgoingQuery(
 }
 
 // FinishOutgoingQuery marks an outgoing query as finished
-func (s *storageSQLite) FinishOutgoingQuery(id OutgoingQueryID, rowsRead int64) error {
+func (s *storageSQLite) FinishOutgoingQuery(id OutgoingQueryID, stats *OutgoingQueryStats) error {
 	finishedAt := time.Now().UTC()
 
+	var timeToFirstRowMs sql.NullFloat64
+
+	if stats.TimeToFirstRow != nil {
+		timeToFirstRowMs = sql.NullFloat64{Float64: durationToMs(*stats.TimeToFirstRow), Valid: true}
+	}
+
 	result, err := s.db.Exec(
-		"UPDATE outgoing_queries SET state = ?, finished_at = ?, rows_read = ? WHERE id = ?",
-		string(QueryStateFinished), finishedAt, rowsRead, id,
+		`UPDATE outgoing_queries SET state = ?, finished_at = ?, rows_read = ?, bytes_read = ?,
+		pages_emitted = ?, time_to_first_row_ms = ? WHERE id = ?`,
+		string(QueryStateFinished), finishedAt, stats.RowsRead, stats.BytesRead,
+		stats.PagesEmitted, timeToFirstRowMs, id,
 	)
 	if err != nil {
 		return fmt.Errorf("marking outgoing query as finished: %w", err)
@@ -348,95 +428,40 @@ func (s *storageSQLite) CancelOutgoingQuery(id OutgoingQueryID, errorMsg string)
 	return nil
 }
 
+// SaveOutgoingQueryPlan stores the execution plan captured for the outgoing query
+func (s *storageSQLite) SaveOutgoingQueryPlan(id OutgoingQueryID, plan string) error {
+	result, err := s.db.Exec("UPDATE outgoing_queries SET plan = ? WHERE id = ?", plan, id)
+	if err != nil {
+		return fmt.Errorf("saving outgoing query plan: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("outgoing query not found: %d", id)
+	}
+
+	return nil
+}
+
 // ListOutgoingQueries retrieves a list of outgoing queries with optional filtering
 func (s *storageSQLite) ListOutgoingQueries(
 	incomingQueryID *IncomingQueryID,
 	state *QueryState,
 	limit, offset int,
 ) ([]*OutgoingQuery, error) {
-	var (
-		querySQL string
-		args     []any
-	)
-
-	// Build the query based on which filters are provided
-	if incomingQueryID != nil && state != nil {
-		querySQL = `
-			SELECT id, incoming_query_id, database_name, database_endpoint, query_text, 
-			       query_args, state, created_at, finished_at, error, rows_read
-			FROM outgoing_queries 
-			WHERE incoming_query_id = ? AND state = ? 
-			ORDER BY created_at DESC LIMIT ? OFFSET ?`
-		args = []any{*incomingQueryID, string(*state), limit, offset}
-	} else if incomingQueryID != nil {
-		querySQL = `
-			SELECT id, incoming_query_id, database_name, database_endpoint, query_text, 
-			       query_args, state, created_at, finished_at, error, rows_read
-			FROM outgoing_queries 
-			WHERE incoming_query_id = ? 
-			ORDER BY created_at DESC LIMIT ? OFFSET ?`
-		args = []any{*incomingQueryID, limit, offset}
-	} else if state != nil {
-		querySQL = `
-			SELECT id, incoming_query_id, database_name, database_endpoint, query_text, 
-			       query_args, state, created_at, finished_at, error, rows_read
-			FROM outgoing_queries 
-			WHERE state = ? 
-			ORDER BY created_at DESC LIMIT ? OFFSET ?`
-		args = []any{string(*state), limit, offset}
-	} else {
-		querySQL = `
-			SELECT id, incoming_query_id, database_name, database_endpoint, query_text, 
-			       query_args, state, created_at, finished_at, error, rows_read
-			FROM outgoing_queries 
-			ORDER BY created_at DESC LIMIT ? OFFSET ?`
-		args = []any{limit, offset}
-	}
-
-	rows, err := s.db.Query(querySQL, args...)
+	queries, err := s.FindOutgoingQueries(&QueryFilter{
+		IncomingQueryID: incomingQueryID,
+		State:           state,
+		Limit:           limit,
+		Offset:          offset,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("listing outgoing queries: %w", err)
 	}
-	defer rows.Close()
-
-	var queries []*OutgoingQuery
-
-	for rows.Next() {
-		var (
-			query                          OutgoingQuery
-			finishedAt                     sql.NullTime
-			queryText, queryArgs, errorMsg sql.NullString
-		)
-
-		if err := rows.Scan(
-			&query.ID, &query.IncomingQueryID, &query.DatabaseName, &query.DatabaseEndpoint,
-			&queryText, &queryArgs, &query.State, &query.CreatedAt, &finishedAt, &errorMsg, &query.RowsRead,
-		); err != nil {
-			return nil, fmt.Errorf("scanning outgoing query: %w", err)
-		}
-
-		if queryText.Valid {
-			query.QueryText = queryText.String
-		}
-
-		if queryArgs.Valid {
-			query.QueryArgs = queryArgs.String
-		}
-
-		if errorMsg.Valid {
-			query.Error = errorMsg.String
-		}
-
-		if finishedAt.Valid {
-			query.FinishedAt = &finishedAt.Time
-		}
-
-		queries = append(queries, &query)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating outgoing queries: %w", err)
-	}
 
 	return queries, nil
 }
@@ -510,10 +535,7 @@ func (s *storageSQLite) ListSimilarOutgoingQueriesWithDifferentStats(logger *zap
 
 	for _, key := range keys {
 		fetchSQL := `
-        SELECT 
-            id, incoming_query_id, database_name, database_endpoint, 
-            query_text, query_args, rows_read, state, created_at, 
-            finished_at, error
+        SELECT ` + outgoingQueryColumns("") + `
         FROM 
             outgoing_queries
         WHERE 
@@ -534,43 +556,14 @@ func (s *storageSQLite) ListSimilarOutgoingQueriesWithDifferentStats(logger *zap
 		var group []*OutgoingQuery
 
 		for rows2.Next() {
-			var (
-				query                          OutgoingQuery
-				finishedAt                     sql.NullTime
-				queryText, queryArgs, errorMsg sql.NullString
-				rowsRead                       int64
-			)
-
-			if err := rows2.Scan(
-				&query.ID, &query.IncomingQueryID, &query.DatabaseName, &query.DatabaseEndpoint,
-				&queryText, &queryArgs, &rowsRead, &query.State, &query.CreatedAt,
-				&finishedAt, &errorMsg,
-			); err != nil {
+			query, err := scanOutgoingQuery(rows2)
+			if err != nil {
 				common.LogCloserError(logger, rows2, "rows close")
 
-				return nil, fmt.Errorf("scanning outgoing query: %w", err)
-			}
-
-			if queryText.Valid {
-				query.QueryText = queryText.String
-			}
-
-			if queryArgs.Valid {
-				query.QueryArgs = queryArgs.String
-			}
-
-			if errorMsg.Valid {
-				query.Error = errorMsg.String
+				return nil, err
 			}
 
-			if finishedAt.Valid {
-				query.FinishedAt = &finishedAt.Time
-			}
-
-			// Set the rows_read value
-			query.RowsRead = rowsRead
-
-			group = append(group, &query)
+			group = append(group, query)
 		}
 
 		common.LogCloserError(logger, rows2, "close rows")
@@ -601,8 +594,339 @@ func (s *storageSQLite) ListSimilarOutgoingQueriesWithDifferentStats(logger *zap
 	return result, nil
 }
 
+// queryFilterConditions converts the filter into SQL conditions over the table with the given alias
+func queryFilterConditions(filter *QueryFilter, alias string) ([]string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.State != nil {
+		conditions = append(conditions, alias+".state = ?")
+		args = append(args, string(*filter.State))
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, alias+".created_at >= ?")
+		args = append(args, filter.CreatedFrom.UTC())
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, alias+".created_at < ?")
+		args = append(args, filter.CreatedTo.UTC())
+	}
+
+	return conditions, args
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// FindIncomingQueries lists incoming queries matching the filter, the latest ones go first
+func (s *storageSQLite) FindIncomingQueries(filter *QueryFilter) ([]*IncomingQuery, error) {
+	conditions, args := queryFilterConditions(filter, "i")
+
+	if filter.DataSourceKind != "" {
+		conditions = append(conditions, "i.data_source_kind = ?")
+		args = append(args, filter.DataSourceKind)
+	}
+
+	if filter.DatabaseName != "" {
+		conditions = append(conditions,
+			"EXISTS (SELECT 1 FROM outgoing_queries o WHERE o.incoming_query_id = i.id AND o.database_name = ?)")
+		args = append(args, filter.DatabaseName)
+	}
+
+	querySQL := fmt.Sprintf(`
+		SELECT %s
+		FROM incoming_queries i %s ORDER BY i.created_at DESC LIMIT ? OFFSET ?`,
+		incomingQueryColumns("i."),
+		whereClause(conditions),
+	)
+
+	args = append(args, filter.Limit, filter.Offset)
+
+	var queries []*IncomingQuery
+
+	err := s.selectIncomingQueries(querySQL, args, func(query *IncomingQuery) error {
+		queries = append(queries, query)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// FindOutgoingQueries lists outgoing queries matching the filter, the latest ones go first
+func (s *storageSQLite) FindOutgoingQueries(filter *QueryFilter) ([]*OutgoingQuery, error) {
+	conditions, args := queryFilterConditions(filter, "o")
+
+	if filter.IncomingQueryID != nil {
+		conditions = append(conditions, "o.incoming_query_id = ?")
+		args = append(args, *filter.IncomingQueryID)
+	}
+
+	if filter.DataSourceKind != "" {
+		conditions = append(conditions, "i.data_source_kind = ?")
+		args = append(args, filter.DataSourceKind)
+	}
+
+	if filter.DatabaseName != "" {
+		conditions = append(conditions, "o.database_name = ?")
+		args = append(args, filter.DatabaseName)
+	}
+
+	querySQL := fmt.Sprintf(`
+		SELECT %s
+		FROM outgoing_queries o JOIN incoming_queries i ON o.incoming_query_id = i.id
+		%s ORDER BY o.created_at DESC LIMIT ? OFFSET ?`,
+		outgoingQueryColumns("o."),
+		whereClause(conditions),
+	)
+
+	args = append(args, filter.Limit, filter.Offset)
+
+	var queries []*OutgoingQuery
+
+	err := s.selectOutgoingQueries(querySQL, args, func(query *OutgoingQuery) error {
+		queries = append(queries, query)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// ExportIncomingQueries calls handler for every incoming query created within [from, to) time window
+func (s *storageSQLite) ExportIncomingQueries(from, to time.Time, handler func(*IncomingQuery) error) error {
+	return s.selectIncomingQueries(`
+		SELECT `+incomingQueryColumns("")+`
+		FROM incoming_queries WHERE created_at >= ? AND created_at < ? ORDER BY id`,
+		[]any{from.UTC(), to.UTC()},
+		handler,
+	)
+}
+
+func (s *storageSQLite) selectIncomingQueries(querySQL string, args []any, handler func(*IncomingQuery) error) error {
+	rows, err := s.db.Query(querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("selecting incoming queries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			query            IncomingQuery
+			finishedAt       sql.NullTime
+			errorMsg, policy sql.NullString
+		)
+
+		if err := rows.Scan(
+			&query.ID, &query.DataSourceKind, &query.RowsRead, &query.BytesRead,
+			&query.State, &query.CreatedAt, &finishedAt, &errorMsg, &policy,
+		); err != nil {
+			return fmt.Errorf("scanning incoming query: %w", err)
+		}
+
+		query.Error = errorMsg.String
+		query.Policy = policy.String
+
+		if finishedAt.Valid {
+			query.FinishedAt = &finishedAt.Time
+		}
+
+		if err := handler(&query); err != nil {
+			return fmt.Errorf("handle incoming query: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating incoming queries: %w", err)
+	}
+
+	return nil
+}
+
+// ExportOutgoingQueries calls handler for every outgoing query created within [from, to) time window
+func (s *storageSQLite) ExportOutgoingQueries(from, to time.Time, handler func(*OutgoingQuery) error) error {
+	return s.selectOutgoingQueries(`
+		SELECT `+outgoingQueryColumns("")+`
+		FROM outgoing_queries WHERE created_at >= ? AND created_at < ? ORDER BY id`,
+		[]any{from.UTC(), to.UTC()},
+		handler,
+	)
+}
+
+// incomingQueryColumns lists the columns expected by selectIncomingQueries, prefix is used to qualify columns in joins
+func incomingQueryColumns(prefix string) string {
+	columns := []string{
+		"id", "data_source_kind", "rows_read", "bytes_read", "state", "created_at", "finished_at", "error", "policy",
+	}
+
+	for i := range columns {
+		columns[i] = prefix + columns[i]
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// outgoingQueryColumns lists the columns expected by scanOutgoingQuery, prefix is used to qualify columns in joins
+func outgoingQueryColumns(prefix string) string {
+	columns := []string{
+		"id", "incoming_query_id", "database_name", "database_endpoint", "query_text", "query_args",
+		"state", "created_at", "finished_at", "error", "rows_read", "bytes_read", "pages_emitted",
+		"time_to_first_row_ms", "plan",
+	}
+
+	for i := range columns {
+		columns[i] = prefix + columns[i]
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+func scanOutgoingQuery(rows *sql.Rows) (*OutgoingQuery, error) {
+	var (
+		query                                OutgoingQuery
+		finishedAt                           sql.NullTime
+		queryText, queryArgs, errorMsg, plan sql.NullString
+		timeToFirstRowMs                     sql.NullFloat64
+	)
+
+	if err := rows.Scan(
+		&query.ID, &query.IncomingQueryID, &query.DatabaseName, &query.DatabaseEndpoint,
+		&queryText, &queryArgs, &query.State, &query.CreatedAt, &finishedAt, &errorMsg,
+		&query.RowsRead, &query.BytesRead, &query.PagesEmitted, &timeToFirstRowMs, &plan,
+	); err != nil {
+		return nil, fmt.Errorf("scanning outgoing query: %w", err)
+	}
+
+	query.QueryText = queryText.String
+	query.QueryArgs = queryArgs.String
+	query.Error = errorMsg.String
+	query.Plan = plan.String
+
+	if finishedAt.Valid {
+		query.FinishedAt = &finishedAt.Time
+	}
+
+	if timeToFirstRowMs.Valid {
+		query.TimeToFirstRowMs = &timeToFirstRowMs.Float64
+	}
+
+	return &query, nil
+}
+
+func durationToMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func (s *storageSQLite) selectOutgoingQueries(querySQL string, args []any, handler func(*OutgoingQuery) error) error {
+	rows, err := s.db.Query(querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("selecting outgoing queries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		query, err := scanOutgoingQuery(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := handler(query); err != nil {
+			return fmt.Errorf("handle outgoing query: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating outgoing queries: %w", err)
+	}
+
+	return nil
+}
+
+// applyRetention removes incoming queries exceeding the retention limits together with their outgoing queries.
+// Outgoing queries are removed explicitly, because foreign keys support is a per-connection setting.
+func (s *storageSQLite) applyRetention(policy *retentionPolicy, now time.Time) (int64, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if policy.maxAge > 0 {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, now.Add(-policy.maxAge))
+	}
+
+	if policy.maxRows > 0 {
+		conditions = append(conditions, "id IN (SELECT id FROM incoming_queries ORDER BY id DESC LIMIT -1 OFFSET ?)")
+		args = append(args, policy.maxRows)
+	}
+
+	if len(conditions) == 0 {
+		return 0, nil
+	}
+
+	outdated := "SELECT id FROM incoming_queries WHERE " + strings.Join(conditions, " OR ")
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM outgoing_queries WHERE incoming_query_id IN ("+outdated+")", args...); err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("deleting outgoing queries: %w", err)
+	}
+
+	result, err := tx.Exec("DELETE FROM incoming_queries WHERE id IN ("+outdated+")", args...)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("deleting incoming queries: %w", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, fmt.Errorf("checking rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return removed, nil
+}
+
+// compact rebuilds the database file and truncates the write-ahead log
+func (s *storageSQLite) compact() error {
+	if _, err := s.db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("vacuum: %w", err)
+	}
+
+	if _, err := s.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("WAL checkpoint: %w", err)
+	}
+
+	return nil
+}
+
 // Close closes the database connection
 func (s *storageSQLite) Close() error {
+	if s.janitor != nil {
+		s.janitor.stop()
+	}
+
 	if s.db != nil {
 		return s.db.Close()
 	}
@@ -611,7 +935,9 @@ func (s *storageSQLite) Close() error {
 }
 
 // newStorageSQLite creates a new Storage instance
-func newStorageSQLite(logger *zap.Logger, cfg *config.TObservationConfig_TStorage_TSQLite) (Storage, error) {
+func newStorageSQLite(logger *zap.Logger, storageCfg *config.TObservationConfig_TStorage) (Storage, error) {
+	cfg := storageCfg.GetSqlite()
+
 	db, err := sql.Open("sqlite3", cfg.Path)
 	if err != nil {
 		return nil, fmt.Errorf("opening SQLite database: %w", err)
@@ -647,5 +973,15 @@ func newStorageSQLite(logger *zap.Logger, cfg *config.TObservationConfig_TStorag
 		return nil, fmt.Errorf("initialize: %w", err)
 	}
 
+	if storageCfg.Retention != nil {
+		policy, err := newRetentionPolicy(storageCfg.Retention)
+		if err != nil {
+			common.LogCloserError(logger, db, "close SQLite database")
+			return nil, fmt.Errorf("new retention policy: %w", err)
+		}
+
+		storage.janitor = startJanitor(logger, storage, policy)
+	}
+
 	return storage, nil
 }
@@ -0,0 +1,234 @@
+package observation
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func countTestStorage(t *testing.T, storage *storageSQLite) (int, int) {
+	var incoming, outgoing int
+
+	require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM incoming_queries").Scan(&incoming))
+	require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM outgoing_queries").Scan(&outgoing))
+
+	return incoming, outgoing
+}
+
+func TestStorageSQLiteRetention(t *testing.T) {
+	testCases := []struct {
+		name      string
+		policy    *retentionPolicy
+		elapsed   time.Duration // the time passed since the queries were created
+		removed   int64
+		remaining int
+	}{
+		{name: "max rows", policy: &retentionPolicy{maxRows: 3}, removed: 2, remaining: 3},
+		{name: "max age not reached", policy: &retentionPolicy{maxAge: time.Hour}, removed: 0, remaining: 5},
+		{name: "max age exceeded", policy: &retentionPolicy{maxAge: time.Hour}, elapsed: 2 * time.Hour, removed: 5, remaining: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage, err := newStorageSQLite(zap.NewNop(), &config.TObservationConfig_TStorage{
+				Payload: &config.TObservationConfig_TStorage_Sqlite{
+					Sqlite: &config.TObservationConfig_TStorage_TSQLite{Path: filepath.Join(t.TempDir(), "observation.db")},
+				},
+			})
+			require.NoError(t, err)
+
+			defer func() { require.NoError(t, storage.Close()) }()
+
+			fillTestStorage(t, storage, 5)
+
+			removed, err := storage.(*storageSQLite).applyRetention(tc.policy, time.Now().Add(tc.elapsed))
+			require.NoError(t, err)
+			require.Equal(t, tc.removed, removed)
+
+			incoming, outgoing := countTestStorage(t, storage.(*storageSQLite))
+			require.Equal(t, tc.remaining, incoming)
+			require.Equal(t, tc.remaining, outgoing)
+
+			// the latest queries are kept
+			queries, err := storage.ListIncomingQueries(nil, 10, 0)
+			require.NoError(t, err)
+
+			for _, query := range queries {
+				require.Greater(t, query.ID, IncomingQueryID(tc.removed))
+			}
+
+			require.NoError(t, storage.(*storageSQLite).compact())
+		})
+	}
+}
+
+func TestStorageSQLiteOutgoingQueryStats(t *testing.T) {
+	storage, err := newStorageSQLite(zap.NewNop(), &config.TObservationConfig_TStorage{
+		Payload: &config.TObservationConfig_TStorage_Sqlite{
+			Sqlite: &config.TObservationConfig_TStorage_TSQLite{Path: filepath.Join(t.TempDir(), "observation.db")},
+		},
+	})
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, storage.Close()) }()
+
+	incomingID, err := storage.CreateIncomingQuery(api_common.EGenericDataSourceKind_CLICKHOUSE)
+	require.NoError(t, err)
+
+	dsi := &api_common.TGenericDataSourceInstance{Database: "db", Endpoint: &api_common.TGenericEndpoint{Host: "host", Port: 9000}}
+
+	withRows, err := storage.CreateOutgoingQuery(zap.NewNop(), incomingID, dsi, "SELECT 1", nil)
+	require.NoError(t, err)
+
+	withoutRows, err := storage.CreateOutgoingQuery(zap.NewNop(), incomingID, dsi, "SELECT 2", nil)
+	require.NoError(t, err)
+
+	timeToFirstRow := 1500 * time.Microsecond
+
+	require.NoError(t, storage.FinishOutgoingQuery(withRows, &OutgoingQueryStats{
+		RowsRead:       10,
+		BytesRead:      100,
+		PagesEmitted:   2,
+		TimeToFirstRow: &timeToFirstRow,
+	}))
+	require.NoError(t, storage.FinishOutgoingQuery(withoutRows, &OutgoingQueryStats{}))
+	require.NoError(t, storage.SaveOutgoingQueryPlan(withRows, "ReadFromMergeTree"))
+	require.Error(t, storage.SaveOutgoingQueryPlan(100, "plan"))
+
+	queries, err := storage.ListOutgoingQueries(&incomingID, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, queries, 2)
+
+	for _, query := range queries {
+		switch query.ID {
+		case withRows:
+			require.Equal(t, int64(10), query.RowsRead)
+			require.Equal(t, int64(100), query.BytesRead)
+			require.Equal(t, int64(2), query.PagesEmitted)
+			require.NotNil(t, query.TimeToFirstRowMs)
+			require.InDelta(t, 1.5, *query.TimeToFirstRowMs, 1e-9)
+			require.Equal(t, "ReadFromMergeTree", query.Plan)
+		case withoutRows:
+			require.Nil(t, query.TimeToFirstRowMs)
+			require.Empty(t, query.Plan)
+		}
+	}
+}
+
+func TestStorageSQLiteMigration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "observation.db")
+
+	// the schema of the previous versions of the service
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`
+	CREATE TABLE outgoing_queries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		incoming_query_id INTEGER NOT NULL,
+		database_name TEXT NOT NULL,
+		database_endpoint TEXT NOT NULL,
+		query_text TEXT,
+		query_args TEXT,
+		rows_read INTEGER NOT NULL DEFAULT 0,
+		state TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		finished_at TIMESTAMP,
+		error TEXT
+	);`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	storage, err := newStorageSQLite(zap.NewNop(), &config.TObservationConfig_TStorage{
+		Payload: &config.TObservationConfig_TStorage_Sqlite{
+			Sqlite: &config.TObservationConfig_TStorage_TSQLite{Path: path},
+		},
+	})
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, storage.Close()) }()
+
+	fillTestStorage(t, storage, 1)
+
+	queries, err := storage.ListOutgoingQueries(nil, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+}
+
+func TestExport(t *testing.T) {
+	storage, err := newStorageSQLite(zap.NewNop(), &config.TObservationConfig_TStorage{
+		Payload: &config.TObservationConfig_TStorage_Sqlite{
+			Sqlite: &config.TObservationConfig_TStorage_TSQLite{Path: filepath.Join(t.TempDir(), "observation.db")},
+		},
+	})
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, storage.Close()) }()
+
+	fillTestStorage(t, storage, 3)
+
+	now := time.Now()
+
+	t.Run("JSON lines", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		total, err := Export(storage, &ExportParams{
+			Table:  ExportTableOutgoing,
+			Format: ExportFormatJSONLines,
+			From:   now.Add(-time.Hour),
+			To:     now.Add(time.Hour),
+		}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, 3, total)
+
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var query OutgoingQuery
+
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &query))
+			require.Equal(t, "SELECT 1", query.QueryText)
+			require.Equal(t, QueryStateFinished, query.State)
+		}
+	})
+
+	t.Run("Parquet", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		total, err := Export(storage, &ExportParams{
+			Table:  ExportTableIncoming,
+			Format: ExportFormatParquet,
+			From:   now.Add(-time.Hour),
+			To:     now.Add(time.Hour),
+		}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, 3, total)
+
+		// Parquet files start and end with magic bytes
+		require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("PAR1")))
+		require.True(t, bytes.HasSuffix(buf.Bytes(), []byte("PAR1")))
+	})
+
+	t.Run("empty time window", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		total, err := Export(storage, &ExportParams{
+			Table:  ExportTableIncoming,
+			Format: ExportFormatJSONLines,
+			From:   now.Add(time.Hour),
+			To:     now.Add(2 * time.Hour),
+		}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, 0, total)
+		require.Empty(t, buf.Bytes())
+	})
+}
@@ -0,0 +1,30 @@
+package observation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+var testDataSourceInstance = &api_common.TGenericDataSourceInstance{
+	Database: "db",
+	Endpoint: &api_common.TGenericEndpoint{Host: "host", Port: 5432},
+}
+
+// fillTestStorage creates incoming queries, each of them having a single outgoing query
+func fillTestStorage(t *testing.T, storage Storage, count int) {
+	for i := 0; i < count; i++ {
+		incomingID, err := storage.CreateIncomingQuery(api_common.EGenericDataSourceKind_POSTGRESQL)
+		require.NoError(t, err)
+
+		outgoingID, err := storage.CreateOutgoingQuery(zap.NewNop(), incomingID, testDataSourceInstance, "SELECT 1", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, storage.FinishOutgoingQuery(outgoingID, &OutgoingQueryStats{RowsRead: 1}))
+		require.NoError(t, storage.FinishIncomingQuery(incomingID, &api_service_protos.TReadSplitsResponse_TStats{Rows: 1}))
+	}
+}
@@ -19,6 +19,10 @@ type columnarBufferArrowIPCStreamingDefault[T Acceptor] struct {
 	arrowAllocator memory.Allocator
 	builders       []array.Builder
 	schema         *arrow.Schema
+	writerOptions  []ipc.Option
+	masking        *maskingRowTransformer[T] // nil if no columns need to be masked
+	records        []arrow.Record            // batches obtained from the datasource in Arrow format
+	recordsRows    int                       // the number of rows in records
 	logger         *zap.Logger
 }
 
@@ -32,6 +36,10 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) addRow(transformer RowTrans
 			len(cb.builders), len(transformer.GetAcceptors()))
 	}
 
+	if cb.masking != nil {
+		transformer = cb.masking.wrap(transformer)
+	}
+
 	if err := transformer.AppendToArrowBuilders(cb.builders); err != nil {
 		return fmt.Errorf("append values to arrow builders: %w", err)
 	}
@@ -39,26 +47,127 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) addRow(transformer RowTrans
 	return nil
 }
 
-// ToResponse returns all the accumulated data and clears buffer
-func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
+// addRecord saves a batch of rows obtained from the datasource into the buffer
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) addRecord(record arrow.Record) error {
+	if int(record.NumCols()) != len(cb.builders) {
+		return fmt.Errorf("expected %v columns in a record, got %v instead", len(cb.builders), record.NumCols())
+	}
+
+	columns := make([]arrow.Array, 0, len(cb.builders))
+
+	for i, column := range record.Columns() {
+		if expected := cb.schema.Field(i).Type; !arrow.TypeEqual(column.DataType(), expected) {
+			releaseArrays(columns)
+
+			return fmt.Errorf("column #%d: expected type %v, got %v instead", i, expected, column.DataType())
+		}
+
+		column.Retain()
+		columns = append(columns, column)
+	}
+
+	if cb.masking != nil {
+		if err := cb.masking.maskColumns(columns); err != nil {
+			releaseArrays(columns)
+
+			return fmt.Errorf("mask columns: %w", err)
+		}
+	}
+
+	// keep the order of rows if some of them have been added one by one
+	cb.flushBuilders()
+
+	// the record gets the names of the columns from the buffer schema
+	cb.records = append(cb.records, array.NewRecord(cb.schema, columns, record.NumRows()))
+	cb.recordsRows += int(record.NumRows())
+
+	releaseArrays(columns)
+
+	return nil
+}
+
+// flushBuilders moves the rows accumulated in the builders into the records
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) flushBuilders() {
+	if cb.builders[0].Len() == 0 {
+		return
+	}
+
+	record := cb.buildersToRecord()
+
+	cb.records = append(cb.records, record)
+	cb.recordsRows += int(record.NumRows())
+}
+
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) buildersToRecord() arrow.Record {
 	// chunk consists of columns
 	chunk := make([]arrow.Array, 0, len(cb.builders))
 
-	// prepare arrow record
 	for _, builder := range cb.builders {
 		chunk = append(chunk, builder.NewArray())
 	}
 
 	record := array.NewRecord(cb.schema, chunk, -1)
 
-	for _, col := range chunk {
-		col.Release()
+	releaseArrays(chunk)
+
+	return record
+}
+
+// makeRecord merges everything accumulated in the buffer into a single record
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) makeRecord() (arrow.Record, error) {
+	if len(cb.records) == 0 {
+		return cb.buildersToRecord(), nil
 	}
 
+	cb.flushBuilders()
+
+	if len(cb.records) == 1 {
+		record := cb.records[0]
+		record.Retain()
+
+		return record, nil
+	}
+
+	chunk := make([]arrow.Array, 0, len(cb.builders))
+
+	for i := range cb.builders {
+		parts := make([]arrow.Array, 0, len(cb.records))
+		for _, record := range cb.records {
+			parts = append(parts, record.Column(i))
+		}
+
+		column, err := array.Concatenate(parts, cb.arrowAllocator)
+		if err != nil {
+			releaseArrays(chunk)
+
+			return nil, fmt.Errorf("concatenate column #%d: %w", i, err)
+		}
+
+		chunk = append(chunk, column)
+	}
+
+	record := array.NewRecord(cb.schema, chunk, int64(cb.recordsRows))
+
+	releaseArrays(chunk)
+
+	return record, nil
+}
+
+// ToResponse returns all the accumulated data and clears buffer
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
+	// prepare arrow record
+	record, err := cb.makeRecord()
+	if err != nil {
+		return nil, fmt.Errorf("make record: %w", err)
+	}
+
+	defer record.Release()
+
 	// prepare arrow writer
 	var buf bytes.Buffer
 
-	writer := ipc.NewWriter(&buf, ipc.WithSchema(cb.schema), ipc.WithAllocator(cb.arrowAllocator))
+	options := append([]ipc.Option{ipc.WithSchema(cb.schema), ipc.WithAllocator(cb.arrowAllocator)}, cb.writerOptions...)
+	writer := ipc.NewWriter(&buf, options...)
 
 	if err := writer.Write(record); err != nil {
 		return nil, fmt.Errorf("write record: %w", err)
@@ -77,7 +186,9 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_
 	return out, nil
 }
 
-func (cb *columnarBufferArrowIPCStreamingDefault[T]) TotalRows() int { return cb.builders[0].Len() }
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) TotalRows() int {
+	return cb.builders[0].Len() + cb.recordsRows
+}
 
 // Frees resources if buffer is no longer used
 func (cb *columnarBufferArrowIPCStreamingDefault[T]) Release() {
@@ -85,4 +196,21 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) Release() {
 	for _, b := range cb.builders {
 		b.Release()
 	}
+
+	if cb.masking != nil {
+		cb.masking.release()
+	}
+
+	for _, record := range cb.records {
+		record.Release()
+	}
+
+	cb.records = nil
+	cb.recordsRows = 0
+}
+
+func releaseArrays(arrays []arrow.Array) {
+	for _, arr := range arrays {
+		arr.Release()
+	}
 }
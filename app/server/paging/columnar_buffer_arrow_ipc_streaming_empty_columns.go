@@ -18,6 +18,7 @@ var _ ColumnarBuffer[any] = (*columnarBufferArrowIPCStreamingEmptyColumns[any])(
 type columnarBufferArrowIPCStreamingEmptyColumns[T Acceptor] struct {
 	arrowAllocator memory.Allocator
 	schema         *arrow.Schema
+	writerOptions  []ipc.Option
 	rowsAdded      int
 }
 
@@ -34,6 +35,13 @@ func (cb *columnarBufferArrowIPCStreamingEmptyColumns[T]) addRow(transformer Row
 	return nil
 }
 
+// addRecord saves a batch of rows obtained from the datasource into the buffer
+func (cb *columnarBufferArrowIPCStreamingEmptyColumns[T]) addRecord(record arrow.Record) error {
+	cb.rowsAdded += int(record.NumRows())
+
+	return nil
+}
+
 // ToResponse returns all the accumulated data and clears buffer
 func (cb *columnarBufferArrowIPCStreamingEmptyColumns[T]) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
 	columns := make([]arrow.Array, 0)
@@ -43,7 +51,8 @@ func (cb *columnarBufferArrowIPCStreamingEmptyColumns[T]) ToResponse() (*api_ser
 	// prepare arrow writer
 	var buf bytes.Buffer
 
-	writer := ipc.NewWriter(&buf, ipc.WithSchema(cb.schema), ipc.WithAllocator(cb.arrowAllocator))
+	options := append([]ipc.Option{ipc.WithSchema(cb.schema), ipc.WithAllocator(cb.arrowAllocator)}, cb.writerOptions...)
+	writer := ipc.NewWriter(&buf, options...)
 
 	if err := writer.Write(record); err != nil {
 		return nil, fmt.Errorf("write record: %w", err)
@@ -4,12 +4,14 @@ import (
 	"fmt"
 
 	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
 	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -17,8 +19,10 @@ type columnarBufferFactoryImpl[T Acceptor] struct {
 	arrowAllocator memory.Allocator
 	logger         *zap.Logger
 	format         api_service_protos.TReadSplitsRequest_EFormat
+	writerOptions  []ipc.Option // Arrow IPC writer options, e. g. compression
 	schema         *arrow.Schema
 	ydbTypes       []*Ydb.Type
+	maskers        []ColumnMasker // nil if no columns need to be masked
 }
 
 func (cbf *columnarBufferFactoryImpl[T]) MakeBuffer() (ColumnarBuffer[T], error) {
@@ -33,16 +37,29 @@ func (cbf *columnarBufferFactoryImpl[T]) MakeBuffer() (ColumnarBuffer[T], error)
 			return &columnarBufferArrowIPCStreamingEmptyColumns[T]{
 				arrowAllocator: cbf.arrowAllocator,
 				schema:         cbf.schema,
+				writerOptions:  cbf.writerOptions,
 				rowsAdded:      0,
 			}, nil
 		}
 
-		return &columnarBufferArrowIPCStreamingDefault[T]{
+		cb := &columnarBufferArrowIPCStreamingDefault[T]{
 			arrowAllocator: cbf.arrowAllocator,
 			builders:       builders,
 			schema:         cbf.schema,
+			writerOptions:  cbf.writerOptions,
 			logger:         cbf.logger,
-		}, nil
+		}
+
+		if cbf.maskers != nil {
+			cb.masking, err = newMaskingRowTransformer[T](cbf.maskers, cbf.ydbTypes, cbf.arrowAllocator)
+			if err != nil {
+				cb.Release()
+
+				return nil, fmt.Errorf("new masking row transformer: %w", err)
+			}
+		}
+
+		return cb, nil
 	default:
 		return nil, fmt.Errorf("unknown format: %v", cbf.format)
 	}
@@ -52,7 +69,9 @@ func NewColumnarBufferFactory[T Acceptor](
 	logger *zap.Logger,
 	arrowAllocator memory.Allocator,
 	format api_service_protos.TReadSplitsRequest_EFormat,
+	compression config.TPagingConfig_EArrowCompression,
 	selectWhat *api_service_protos.TSelect_TWhat,
+	maskers []ColumnMasker, // one per column of selectWhat (nil items for the columns returned as is) or nil
 ) (ColumnarBufferFactory[T], error) {
 	ydbTypes, err := common.SelectWhatToYDBTypes(selectWhat)
 	if err != nil {
@@ -68,9 +87,22 @@ func NewColumnarBufferFactory[T Acceptor](
 		logger:         logger,
 		arrowAllocator: arrowAllocator,
 		format:         format,
+		writerOptions:  arrowIPCWriterOptions(compression),
 		schema:         schema,
 		ydbTypes:       ydbTypes,
 	}
 
+	for _, masker := range maskers {
+		if masker != nil {
+			cbf.maskers = maskers
+
+			break
+		}
+	}
+
+	if cbf.maskers != nil && len(cbf.maskers) != len(ydbTypes) {
+		return nil, fmt.Errorf("expected %d column maskers, got %d: %w", len(ydbTypes), len(maskers), common.ErrInvariantViolation)
+	}
+
 	return cbf, nil
 }
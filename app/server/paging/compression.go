@@ -0,0 +1,41 @@
+package paging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// NegotiateArrowCompression chooses the codec for the compression of Arrow IPC pages.
+// The codec requested by client takes precedence over the one from the service config.
+func NegotiateArrowCompression(
+	cfg *config.TPagingConfig,
+	requested string,
+) (config.TPagingConfig_EArrowCompression, error) {
+	if requested == "" {
+		return cfg.GetArrowCompression(), nil
+	}
+
+	value, ok := config.TPagingConfig_EArrowCompression_value[strings.ToUpper(requested)]
+	if !ok {
+		return config.TPagingConfig_ARROW_COMPRESSION_UNSPECIFIED,
+			fmt.Errorf("unknown Arrow compression codec '%s': %w", requested, common.ErrInvalidRequest)
+	}
+
+	return config.TPagingConfig_EArrowCompression(value), nil
+}
+
+func arrowIPCWriterOptions(compression config.TPagingConfig_EArrowCompression) []ipc.Option {
+	switch compression {
+	case config.TPagingConfig_LZ4_FRAME:
+		return []ipc.Option{ipc.WithLZ4()}
+	case config.TPagingConfig_ZSTD:
+		return []ipc.Option{ipc.WithZstd()}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,47 @@
+package paging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestNegotiateArrowCompression(t *testing.T) {
+	cfg := &config.TPagingConfig{ArrowCompression: config.TPagingConfig_LZ4_FRAME}
+
+	testCases := []struct {
+		requested string
+		expected  config.TPagingConfig_EArrowCompression
+		err       error
+	}{
+		{requested: "", expected: config.TPagingConfig_LZ4_FRAME},
+		{requested: "ZSTD", expected: config.TPagingConfig_ZSTD},
+		{requested: "zstd", expected: config.TPagingConfig_ZSTD},
+		{requested: "none", expected: config.TPagingConfig_NONE},
+		{requested: "gzip", err: common.ErrInvalidRequest},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.requested, func(t *testing.T) {
+			actual, err := NegotiateArrowCompression(cfg, tc.requested)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("no config", func(t *testing.T) {
+		actual, err := NegotiateArrowCompression(nil, "")
+		require.NoError(t, err)
+		require.Equal(t, config.TPagingConfig_ARROW_COMPRESSION_UNSPECIFIED, actual)
+	})
+}
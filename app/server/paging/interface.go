@@ -1,6 +1,7 @@
 package paging
 
 import (
+	"github.com/apache/arrow/go/v13/arrow"
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
@@ -9,6 +10,8 @@ import (
 type ColumnarBuffer[T Acceptor] interface {
 	// addRow saves a row obtained from the datasource into the columnar buffer
 	addRow(rowTransformer RowTransformer[T]) error
+	// addRecord saves a batch of rows that has already been converted to Arrow by the datasource
+	addRecord(record arrow.Record) error
 	// ToResponse returns all the accumulated data and clears buffer
 	ToResponse() (*api_service_protos.TReadSplitsResponse, error)
 	// Release frees resources if buffer is no longer used
@@ -22,16 +25,26 @@ type ColumnarBufferFactory[T Acceptor] interface {
 }
 
 // ReadResult is an algebraic data type containing:
-// 1. a buffer (e. g. page) packed with data
-// 2. stats describing data that is kept in buffer
+// 1. a response (e. g. page) packed with serialized data
+// 2. stats describing data that is kept in response
 // 3. result of read operation (potentially with error)
 // 4. flag marking this stream as completed
 type ReadResult[T Acceptor] struct {
-	ColumnarBuffer    ColumnarBuffer[T]
+	Response          *api_service_protos.TReadSplitsResponse
 	Stats             *api_service_protos.TReadSplitsResponse_TStats
 	Error             error
 	IsTerminalMessage bool
 	Logger            *zap.Logger // logger annotated with the data source instance description
+
+	reservation MemoryReservation // memory occupied by the page within the global budget
+}
+
+// Release returns the memory occupied by the page to the global budget.
+// It must be called when the result is no longer needed.
+func (r *ReadResult[T]) Release() {
+	if r.reservation != nil {
+		r.reservation.Release()
+	}
 }
 
 // Sink is a destination for a data stream that is read out of an external data source connection.
@@ -39,6 +52,11 @@ type Sink[T Acceptor] interface {
 	// AddRow saves the row obtained from a stream incoming from an external data source.
 	AddRow(rowTransformer RowTransformer[T]) error
 
+	// AddArrowRecord saves the batch of rows that the external data source returned in Arrow format.
+	// The columns must already have the types of the YDB-mapped Arrow schema.
+	// The record is split into several pages if it doesn't fit into the current one.
+	AddArrowRecord(record arrow.Record) error
+
 	// Finish reports the successful (!) completion of data stream reading.
 	// Never call this method if the request has failed.
 	// This method can be called only once.
@@ -46,6 +64,18 @@ type Sink[T Acceptor] interface {
 
 	// Annotated logger that should be used to track all the activities related to sink
 	Logger() *zap.Logger
+
+	// Stats returns the amount of data that has been passed through the sink so far.
+	Stats() *SinkStats
+}
+
+// SinkStats describes the data passed through a single sink
+type SinkStats struct {
+	Rows  uint64
+	Bytes uint64
+	Pages uint64
+	// the size of the pages after serialization and compression
+	CompressedBytes uint64
 }
 
 type SinkParams struct {
@@ -58,8 +88,10 @@ type SinkFactory[T Acceptor] interface {
 	// MakeSinks is used to generate Sink objects, one per each data source connection.
 	// This method can be called only once.
 	MakeSinks([]*SinkParams) ([]Sink[T], error)
-	// ResultQueue returns a channel with columnar buffers generated by all sinks
+	// ResultQueue returns a channel with pages generated by all sinks
 	ResultQueue() <-chan *ReadResult[T]
 	// FinalStats returns the overall statistics collected during the request processing.
 	FinalStats() *api_service_protos.TReadSplitsResponse_TStats
+	// PageSizer returns the object choosing the page size for the request.
+	PageSizer() PageSizer
 }
@@ -0,0 +1,143 @@
+package paging
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// MemoryAccountant limits the total size of the pages prefetched by all the ReadSplits requests
+// served by the process. It's shared across all sink factories.
+type MemoryAccountant interface {
+	// Reserve blocks until the page of the given size fits into the global budget.
+	Reserve(ctx context.Context, bytes uint64) (MemoryReservation, error)
+	// Allocator returns Arrow allocator that tracks the memory actually used by columnar buffers.
+	Allocator() memory.Allocator
+}
+
+// MemoryReservation must be released as soon as the page is sent to the client or dropped.
+type MemoryReservation interface {
+	Release()
+}
+
+type memoryAccountantMetrics struct {
+	budgetBytes   metrics.IntGauge
+	reservedBytes metrics.IntGauge
+	usedBytes     metrics.IntGauge
+	waitTime      metrics.Counter
+}
+
+func newMemoryAccountantMetrics(registry metrics.Registry) *memoryAccountantMetrics {
+	return &memoryAccountantMetrics{
+		budgetBytes:   registry.IntGauge("memory_budget_bytes"),
+		reservedBytes: registry.IntGauge("memory_reserved_bytes"),
+		usedBytes:     registry.IntGauge("memory_used_bytes"),
+		waitTime:      registry.Counter("memory_wait_time_ms"),
+	}
+}
+
+var _ MemoryAccountant = (*memoryAccountantImpl)(nil)
+
+type memoryAccountantImpl struct {
+	budget    uint64
+	semaphore *semaphore.Weighted // nil if the budget is unlimited
+	allocator *accountingAllocator
+	metrics   *memoryAccountantMetrics
+}
+
+func (ma *memoryAccountantImpl) Reserve(ctx context.Context, bytes uint64) (MemoryReservation, error) {
+	// the page can't be larger than the whole budget, otherwise it would never fit
+	if ma.semaphore != nil && bytes > ma.budget {
+		bytes = ma.budget
+	}
+
+	if ma.semaphore != nil {
+		startTime := time.Now()
+
+		if err := ma.semaphore.Acquire(ctx, int64(bytes)); err != nil {
+			return nil, fmt.Errorf("acquire %d bytes from memory budget: %w", bytes, err)
+		}
+
+		ma.metrics.waitTime.Add(time.Since(startTime).Milliseconds())
+	}
+
+	ma.metrics.reservedBytes.Add(int64(bytes))
+
+	return &memoryReservationImpl{accountant: ma, bytes: bytes}, nil
+}
+
+func (ma *memoryAccountantImpl) Allocator() memory.Allocator { return ma.allocator }
+
+func (ma *memoryAccountantImpl) release(bytes uint64) {
+	if ma.semaphore != nil {
+		ma.semaphore.Release(int64(bytes))
+	}
+
+	ma.metrics.reservedBytes.Add(-int64(bytes))
+}
+
+var _ MemoryReservation = (*memoryReservationImpl)(nil)
+
+type memoryReservationImpl struct {
+	accountant *memoryAccountantImpl
+	bytes      uint64
+	released   atomic.Bool
+}
+
+func (mr *memoryReservationImpl) Release() {
+	// protects the budget from double release
+	if mr.released.CompareAndSwap(false, true) {
+		mr.accountant.release(mr.bytes)
+	}
+}
+
+var _ memory.Allocator = (*accountingAllocator)(nil)
+
+// accountingAllocator tracks the amount of memory allocated by the Arrow builders and writers
+type accountingAllocator struct {
+	memory.Allocator
+	usedBytes metrics.IntGauge
+}
+
+func (a *accountingAllocator) Allocate(size int) []byte {
+	a.usedBytes.Add(int64(size))
+
+	return a.Allocator.Allocate(size)
+}
+
+func (a *accountingAllocator) Reallocate(size int, b []byte) []byte {
+	a.usedBytes.Add(int64(size - len(b)))
+
+	return a.Allocator.Reallocate(size, b)
+}
+
+func (a *accountingAllocator) Free(b []byte) {
+	a.usedBytes.Add(-int64(len(b)))
+
+	a.Allocator.Free(b)
+}
+
+func NewMemoryAccountant(cfg *config.TPagingConfig, allocator memory.Allocator, registry metrics.Registry) MemoryAccountant {
+	m := newMemoryAccountantMetrics(registry)
+
+	ma := &memoryAccountantImpl{
+		budget:    cfg.GetMemoryBudgetBytes(),
+		allocator: &accountingAllocator{Allocator: allocator, usedBytes: m.usedBytes},
+		metrics:   m,
+	}
+
+	if ma.budget != 0 {
+		ma.semaphore = semaphore.NewWeighted(int64(ma.budget))
+	}
+
+	m.budgetBytes.Set(int64(ma.budget))
+
+	return ma
+}
@@ -0,0 +1,110 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/mock"
+)
+
+func TestMemoryAccountant(t *testing.T) {
+	makeAccountant := func(budget uint64) *memoryAccountantImpl {
+		cfg := &config.TPagingConfig{MemoryBudgetBytes: budget}
+
+		return NewMemoryAccountant(cfg, memory.NewGoAllocator(), mock.NewRegistry(nil)).(*memoryAccountantImpl)
+	}
+
+	reservedBytes := func(ma *memoryAccountantImpl) int64 {
+		return ma.metrics.reservedBytes.(*mock.IntGauge).Value.Load()
+	}
+
+	t.Run("reserve blocks until budget is released", func(t *testing.T) {
+		ma := makeAccountant(100)
+
+		first, err := ma.Reserve(context.Background(), 60)
+		require.NoError(t, err)
+		require.Equal(t, int64(60), reservedBytes(ma))
+
+		reserved := make(chan MemoryReservation)
+
+		go func() {
+			second, err := ma.Reserve(context.Background(), 60)
+			require.NoError(t, err)
+			reserved <- second
+		}()
+
+		select {
+		case <-reserved:
+			require.FailNow(t, "reservation must wait for the budget")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		first.Release()
+
+		second := <-reserved
+		require.Equal(t, int64(60), reservedBytes(ma))
+
+		second.Release()
+		// double release doesn't affect the budget
+		second.Release()
+		require.Zero(t, reservedBytes(ma))
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ma := makeAccountant(100)
+
+		first, err := ma.Reserve(context.Background(), 100)
+		require.NoError(t, err)
+
+		defer first.Release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = ma.Reserve(ctx, 1)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Equal(t, int64(100), reservedBytes(ma))
+	})
+
+	t.Run("page larger than budget", func(t *testing.T) {
+		ma := makeAccountant(100)
+
+		reservation, err := ma.Reserve(context.Background(), 1000)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), reservedBytes(ma))
+
+		reservation.Release()
+		require.Zero(t, reservedBytes(ma))
+	})
+
+	t.Run("unlimited budget", func(t *testing.T) {
+		ma := makeAccountant(0)
+
+		reservation, err := ma.Reserve(context.Background(), 1<<40)
+		require.NoError(t, err)
+		require.Equal(t, int64(1<<40), reservedBytes(ma))
+
+		reservation.Release()
+		require.Zero(t, reservedBytes(ma))
+	})
+
+	t.Run("allocator", func(t *testing.T) {
+		ma := makeAccountant(0)
+		usedBytes := ma.metrics.usedBytes.(*mock.IntGauge).Value
+
+		buf := ma.Allocator().Allocate(64)
+		require.Equal(t, int64(64), usedBytes.Load())
+
+		buf = ma.Allocator().Reallocate(256, buf)
+		require.Equal(t, int64(256), usedBytes.Load())
+
+		ma.Allocator().Free(buf)
+		require.Zero(t, usedBytes.Load())
+	})
+}
@@ -0,0 +1,42 @@
+package paging
+
+import (
+	"time"
+
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// Metrics describe the pages sent to the clients by all the sinks of the service
+type Metrics struct {
+	pages                metrics.Counter
+	pagesRawBytes        metrics.Counter   // estimated size of the data before serialization
+	pagesCompressedBytes metrics.Counter   // size of the Arrow IPC messages actually sent
+	bytesPerPage         metrics.Histogram // page size limits chosen for the pages
+	pageSizeChanges      metrics.Counter   // adjustments made by adaptive paging
+	fillDuration         metrics.Timer     // time spent to fill the page with data
+	sendDuration         metrics.Timer     // time spent to send the page to the client
+}
+
+func (m *Metrics) addPage(rawBytes, compressedBytes, bytesPerPage uint64) {
+	m.pages.Inc()
+	m.pagesRawBytes.Add(int64(rawBytes))
+	m.pagesCompressedBytes.Add(int64(compressedBytes))
+
+	if bytesPerPage != 0 {
+		m.bytesPerPage.RecordValue(float64(bytesPerPage))
+	}
+}
+
+func NewMetrics(registry metrics.Registry) *Metrics {
+	return &Metrics{
+		pages:                registry.Counter("pages"),
+		pagesRawBytes:        registry.Counter("pages_raw_bytes"),
+		pagesCompressedBytes: registry.Counter("pages_compressed_bytes"),
+		bytesPerPage:         registry.Histogram("bytes_per_page", metrics.MakeExponentialBuckets(64*1024, 2, 10)),
+		pageSizeChanges:      registry.Counter("page_size_changes"),
+		fillDuration: registry.DurationHistogram(
+			"page_fill_duration", metrics.MakeExponentialDurationBuckets(250*time.Microsecond, 1.5, 35)),
+		sendDuration: registry.DurationHistogram(
+			"page_send_duration", metrics.MakeExponentialDurationBuckets(250*time.Microsecond, 1.5, 35)),
+	}
+}
@@ -1,6 +1,7 @@
 package paging
 
 import (
+	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 
@@ -19,6 +20,12 @@ func (m *SinkMock) AddRow(transformer RowTransformer[any]) error {
 	return args.Error(0)
 }
 
+func (m *SinkMock) AddArrowRecord(record arrow.Record) error {
+	args := m.Called(record)
+
+	return args.Error(0)
+}
+
 func (m *SinkMock) AddError(err error) {
 	m.Called(err)
 }
@@ -35,6 +42,10 @@ func (m *SinkMock) Logger() *zap.Logger {
 	return m.Called().Get(0).(*zap.Logger)
 }
 
+func (m *SinkMock) Stats() *SinkStats {
+	return m.Called().Get(0).(*SinkStats)
+}
+
 var _ SinkFactory[any] = (*SinkFactoryMock)(nil)
 
 type SinkFactoryMock struct {
@@ -55,6 +66,10 @@ func (m *SinkFactoryMock) FinalStats() *api_service_protos.TReadSplitsResponse_T
 	return m.Called().Get(0).(*api_service_protos.TReadSplitsResponse_TStats)
 }
 
+func (m *SinkFactoryMock) PageSizer() PageSizer {
+	return m.Called().Get(0).(PageSizer)
+}
+
 var _ ColumnarBuffer[any] = (*ColumnarBufferMock)(nil)
 
 type ColumnarBufferMock struct {
@@ -66,6 +81,11 @@ func (*ColumnarBufferMock) addRow(_ RowTransformer[any]) error {
 	panic("not implemented") // TODO: Implement
 }
 
+//nolint:unused
+func (*ColumnarBufferMock) addRecord(_ arrow.Record) error {
+	panic("not implemented") // TODO: Implement
+}
+
 func (m *ColumnarBufferMock) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
 	args := m.Called()
 
@@ -0,0 +1,131 @@
+package paging
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+// PageSizer chooses the page size limit for a single ReadSplits request.
+// It's shared by all the sinks of the request and the streamer.
+type PageSizer interface {
+	// ObserveSend is called by the streamer every time the full page is sent to the client.
+	ObserveSend(duration time.Duration)
+	// observeFill is called by the sink every time the full page is filled with the data.
+	observeFill(duration time.Duration)
+	// bytesPerPage returns the current page size limit; zero means no limit.
+	bytesPerPage() uint64
+	// maxBytesPerPage returns the upper bound for the page size; zero means no limit.
+	maxBytesPerPage() uint64
+}
+
+var _ PageSizer = (*pageSizerStatic)(nil)
+
+// pageSizerStatic returns the page size from the config
+type pageSizerStatic struct {
+	cfg     *config.TPagingConfig
+	metrics *Metrics
+}
+
+func (ps *pageSizerStatic) ObserveSend(duration time.Duration) {
+	ps.metrics.sendDuration.RecordDuration(duration)
+}
+
+func (ps *pageSizerStatic) observeFill(duration time.Duration) {
+	ps.metrics.fillDuration.RecordDuration(duration)
+}
+
+func (ps *pageSizerStatic) bytesPerPage() uint64 { return ps.cfg.BytesPerPage }
+
+func (ps *pageSizerStatic) maxBytesPerPage() uint64 { return ps.cfg.BytesPerPage }
+
+const (
+	// the number of pages both filled and sent required to make a decision
+	pageSizerWindow = 2
+	// pages grow if the client consumes them this times faster than they are filled
+	pageSizerGrowthThreshold = 4
+)
+
+var _ PageSizer = (*pageSizerAdaptive)(nil)
+
+// pageSizerAdaptive compares the time spent to fill the pages with the time spent to send them.
+// If the client is slower than the data source, the pages are halved to reduce latency and memory consumption.
+// If the client is much faster, the pages are doubled to reduce the per-message overhead.
+type pageSizerAdaptive struct {
+	cfg     *config.TAdaptivePagingConfig
+	metrics *Metrics
+	limit   atomic.Uint64
+
+	mutex sync.Mutex
+	// durations observed since the last change of the limit
+	fillTotal time.Duration
+	fillCount int
+	sendTotal time.Duration
+	sendCount int
+}
+
+func (ps *pageSizerAdaptive) ObserveSend(duration time.Duration) {
+	ps.metrics.sendDuration.RecordDuration(duration)
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	ps.sendTotal += duration
+	ps.sendCount++
+
+	ps.maybeAdjust()
+}
+
+func (ps *pageSizerAdaptive) observeFill(duration time.Duration) {
+	ps.metrics.fillDuration.RecordDuration(duration)
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+
+	ps.fillTotal += duration
+	ps.fillCount++
+
+	ps.maybeAdjust()
+}
+
+func (ps *pageSizerAdaptive) maybeAdjust() {
+	if ps.fillCount < pageSizerWindow || ps.sendCount < pageSizerWindow {
+		return
+	}
+
+	fillAvg := ps.fillTotal / time.Duration(ps.fillCount)
+	sendAvg := ps.sendTotal / time.Duration(ps.sendCount)
+	limit := ps.limit.Load()
+
+	switch {
+	case sendAvg > fillAvg:
+		limit = max(limit/2, ps.cfg.MinBytesPerPage)
+	case sendAvg*pageSizerGrowthThreshold < fillAvg:
+		limit = min(limit*2, ps.cfg.MaxBytesPerPage)
+	}
+
+	if limit != ps.limit.Load() {
+		ps.limit.Store(limit)
+		ps.metrics.pageSizeChanges.Inc()
+	}
+
+	// the next decision is made with the new page size
+	ps.fillTotal, ps.fillCount, ps.sendTotal, ps.sendCount = 0, 0, 0, 0
+}
+
+func (ps *pageSizerAdaptive) bytesPerPage() uint64 { return ps.limit.Load() }
+
+func (ps *pageSizerAdaptive) maxBytesPerPage() uint64 { return ps.cfg.MaxBytesPerPage }
+
+func newPageSizer(cfg *config.TPagingConfig, metrics *Metrics) PageSizer {
+	if cfg.GetAdaptive() == nil {
+		return &pageSizerStatic{cfg: cfg, metrics: metrics}
+	}
+
+	ps := &pageSizerAdaptive{cfg: cfg.Adaptive, metrics: metrics}
+	ps.limit.Store(cfg.BytesPerPage)
+
+	return ps
+}
@@ -0,0 +1,92 @@
+package paging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+func TestPageSizer(t *testing.T) {
+	makeConfig := func() *config.TPagingConfig {
+		return &config.TPagingConfig{
+			BytesPerPage: 4 << 20,
+			Adaptive: &config.TAdaptivePagingConfig{
+				MinBytesPerPage: 1 << 20,
+				MaxBytesPerPage: 16 << 20,
+			},
+		}
+	}
+
+	observe := func(ps PageSizer, fill, send time.Duration) {
+		for i := 0; i < pageSizerWindow; i++ {
+			ps.observeFill(fill)
+			ps.ObserveSend(send)
+		}
+	}
+
+	t.Run("static", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.Adaptive = nil
+
+		ps := newPageSizer(cfg, NewMetrics(nop.Registry{}))
+
+		observe(ps, time.Second, time.Millisecond)
+		require.Equal(t, uint64(4<<20), ps.bytesPerPage())
+		require.Equal(t, uint64(4<<20), ps.maxBytesPerPage())
+	})
+
+	t.Run("fast client", func(t *testing.T) {
+		ps := newPageSizer(makeConfig(), NewMetrics(nop.Registry{}))
+
+		observe(ps, time.Second, time.Millisecond)
+		require.Equal(t, uint64(8<<20), ps.bytesPerPage())
+
+		// limited with upper bound
+		observe(ps, time.Second, time.Millisecond)
+		observe(ps, time.Second, time.Millisecond)
+		require.Equal(t, uint64(16<<20), ps.bytesPerPage())
+		require.Equal(t, uint64(16<<20), ps.maxBytesPerPage())
+	})
+
+	t.Run("slow client", func(t *testing.T) {
+		ps := newPageSizer(makeConfig(), NewMetrics(nop.Registry{}))
+
+		observe(ps, time.Millisecond, time.Second)
+		require.Equal(t, uint64(2<<20), ps.bytesPerPage())
+
+		// limited with lower bound
+		observe(ps, time.Millisecond, time.Second)
+		observe(ps, time.Millisecond, time.Second)
+		require.Equal(t, uint64(1<<20), ps.bytesPerPage())
+	})
+
+	t.Run("balanced client", func(t *testing.T) {
+		ps := newPageSizer(makeConfig(), NewMetrics(nop.Registry{}))
+
+		observe(ps, 2*time.Millisecond, time.Millisecond)
+		require.Equal(t, uint64(4<<20), ps.bytesPerPage())
+	})
+
+	t.Run("row larger than current page", func(t *testing.T) {
+		cfg := makeConfig()
+		cfg.BytesPerPage = 1 << 20
+
+		tt := newTrafficTracker[any](cfg, newPageSizer(cfg, NewMetrics(nop.Registry{})))
+
+		acceptor := new([]byte)
+		*acceptor = make([]byte, 2<<20)
+
+		// the row gets a page of its own
+		ok, err := tt.tryAddRow([]any{acceptor})
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = tt.tryAddRow([]any{acceptor})
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
@@ -13,6 +13,7 @@ import (
 // This is generally should be avoided after https://st.yandex-team.ru/YQ-2057
 type ReadLimiter interface {
 	addRow() error
+	addRows(count uint64) error
 }
 
 type readLimiterNoop struct {
@@ -20,19 +21,23 @@ type readLimiterNoop struct {
 
 func (readLimiterNoop) addRow() error { return nil }
 
+func (readLimiterNoop) addRows(uint64) error { return nil }
+
 type readLimiterRows struct {
 	rowsRead  uint64
 	rowsLimit uint64
 }
 
-func (rl *readLimiterRows) addRow() error {
-	if rl.rowsRead >= rl.rowsLimit {
+func (rl *readLimiterRows) addRow() error { return rl.addRows(1) }
+
+func (rl *readLimiterRows) addRows(count uint64) error {
+	if rl.rowsRead+count > rl.rowsLimit {
 		return fmt.Errorf("can read only %d line(s) from data source per request: %w",
 			rl.rowsLimit,
 			common.ErrReadLimitExceeded)
 	}
 
-	rl.rowsRead++
+	rl.rowsRead += count
 
 	return nil
 }
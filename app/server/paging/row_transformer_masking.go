@@ -0,0 +1,133 @@
+package paging
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// ColumnMasker replaces the values of the sensitive column with the masked ones
+type ColumnMasker interface {
+	// Mask takes the value from the only item of the array and appends the masked value to the builder
+	Mask(value arrow.Array, builder array.Builder) error
+}
+
+var _ RowTransformer[any] = (*maskingRowTransformer[any])(nil)
+
+// maskingRowTransformer passes the values of the masked columns through the scratch builders,
+// so that the maskers deal with the values already converted to Arrow regardless of the data source.
+type maskingRowTransformer[T Acceptor] struct {
+	RowTransformer[T]
+	maskers  []ColumnMasker  // nil items stand for the columns returned as is
+	scratch  []array.Builder // nil items stand for the columns returned as is
+	builders []array.Builder // reused between rows to avoid allocations
+}
+
+func (rt *maskingRowTransformer[T]) AppendToArrowBuilders(builders []array.Builder) error {
+	for i, builder := range builders {
+		if rt.scratch[i] != nil {
+			rt.builders[i] = rt.scratch[i]
+		} else {
+			rt.builders[i] = builder
+		}
+	}
+
+	if err := rt.RowTransformer.AppendToArrowBuilders(rt.builders); err != nil {
+		return err
+	}
+
+	for i, masker := range rt.maskers {
+		if masker == nil {
+			continue
+		}
+
+		value := rt.scratch[i].NewArray()
+		err := masker.Mask(value, builders[i])
+
+		value.Release()
+
+		if err != nil {
+			return fmt.Errorf("mask column #%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// maskColumns replaces the masked columns of the batch with the masked copies.
+// The replaced columns are released, the caller owns the new ones.
+func (rt *maskingRowTransformer[T]) maskColumns(columns []arrow.Array) error {
+	for i, masker := range rt.maskers {
+		if masker == nil {
+			continue
+		}
+
+		for j := 0; j < columns[i].Len(); j++ {
+			value := array.NewSlice(columns[i], int64(j), int64(j+1))
+			err := masker.Mask(value, rt.scratch[i])
+
+			value.Release()
+
+			if err != nil {
+				// drop the values masked so far
+				rt.scratch[i].NewArray().Release()
+
+				return fmt.Errorf("mask column #%d: %w", i, err)
+			}
+		}
+
+		columns[i].Release()
+		columns[i] = rt.scratch[i].NewArray()
+	}
+
+	return nil
+}
+
+// wrap substitutes the transformer provided by the data source
+func (rt *maskingRowTransformer[T]) wrap(transformer RowTransformer[T]) RowTransformer[T] {
+	rt.RowTransformer = transformer
+
+	return rt
+}
+
+func (rt *maskingRowTransformer[T]) release() {
+	for _, builder := range rt.scratch {
+		if builder != nil {
+			builder.Release()
+		}
+	}
+}
+
+func newMaskingRowTransformer[T Acceptor](
+	maskers []ColumnMasker,
+	ydbTypes []*Ydb.Type,
+	arrowAllocator memory.Allocator,
+) (*maskingRowTransformer[T], error) {
+	rt := &maskingRowTransformer[T]{
+		maskers:  maskers,
+		scratch:  make([]array.Builder, len(maskers)),
+		builders: make([]array.Builder, len(maskers)),
+	}
+
+	for i, masker := range maskers {
+		if masker == nil {
+			continue
+		}
+
+		builders, err := common.YdbTypesToArrowBuilders([]*Ydb.Type{ydbTypes[i]}, arrowAllocator)
+		if err != nil {
+			rt.release()
+
+			return nil, fmt.Errorf("make scratch builder for column #%d: %w", i, err)
+		}
+
+		rt.scratch[i] = builders[0]
+	}
+
+	return rt, nil
+}
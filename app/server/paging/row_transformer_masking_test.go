@@ -0,0 +1,67 @@
+package paging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type maskerUpper struct{}
+
+func (maskerUpper) Mask(value arrow.Array, builder array.Builder) error {
+	builder.(*array.StringBuilder).Append(strings.ToUpper(value.(*array.String).Value(0)))
+
+	return nil
+}
+
+func TestMaskingRowTransformer(t *testing.T) {
+	allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer allocator.AssertSize(t, 0)
+
+	ydbTypes := []*Ydb.Type{common.MakePrimitiveType(Ydb.Type_UTF8), common.MakePrimitiveType(Ydb.Type_UTF8)}
+
+	masking, err := newMaskingRowTransformer[any]([]ColumnMasker{nil, maskerUpper{}}, ydbTypes, allocator)
+	require.NoError(t, err)
+
+	defer masking.release()
+
+	appendString := func(acceptor any, builder array.Builder) error {
+		builder.(*array.StringBuilder).Append(*acceptor.(*string))
+
+		return nil
+	}
+
+	builders, err := common.YdbTypesToArrowBuilders(ydbTypes, allocator)
+	require.NoError(t, err)
+
+	for _, row := range [][2]string{{"alice", "alice@example.com"}, {"bob", "bob@example.com"}} {
+		transformer := NewRowTransformer[any](
+			[]any{&row[0], &row[1]},
+			[]func(acceptor any, builder array.Builder) error{appendString, appendString},
+			nil,
+		)
+
+		require.NoError(t, masking.wrap(transformer).AppendToArrowBuilders(builders))
+	}
+
+	expected := [][]string{{"alice", "bob"}, {"ALICE@EXAMPLE.COM", "BOB@EXAMPLE.COM"}}
+
+	for i, builder := range builders {
+		column := builder.NewArray()
+		require.Equal(t, len(expected[i]), column.Len())
+
+		for j, value := range expected[i] {
+			require.Equal(t, value, column.(*array.String).Value(j))
+		}
+
+		column.Release()
+		builder.Release()
+	}
+}
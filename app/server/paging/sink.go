@@ -4,10 +4,16 @@ package paging
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -28,9 +34,15 @@ type sinkImpl[T Acceptor] struct {
 	terminateChan  chan<- Sink[T]           // notify factory when the data reading is finished
 	bufferFactory  ColumnarBufferFactory[T] // creates new buffer
 	trafficTracker *trafficTracker[T]       // tracks the amount of data passed through the sink
+	metrics        *Metrics                 // service-wide page metrics
 	readLimiter    ReadLimiter              // helps to restrict the number of rows read in every request
+	throttler      Throttler                // helps to restrict the reading speed
+	memory         MemoryAccountant         // restricts the total size of prefetched pages
+	pageSizer      PageSizer                // tunes the page size according to the client throughput
+	pageStartedAt  time.Time                // the moment when the first row of the current page was added
 	logger         *zap.Logger              // annotated logger
 	state          sinkState                // flag showing if it's ready to return data
+	pagesEmitted   uint64                   // the number of pages sent to the result queue
 	ctx            context.Context          // client context
 }
 
@@ -62,6 +74,10 @@ func (s *sinkImpl[T]) AddRow(rowTransformer RowTransformer[T]) error {
 		}
 	}
 
+	if s.currBuffer.TotalRows() == 0 {
+		s.pageStartedAt = time.Now()
+	}
+
 	// Append row data to the columnar buffer
 	if err := s.currBuffer.addRow(rowTransformer); err != nil {
 		return fmt.Errorf("add row to buffer: %w", err)
@@ -70,6 +86,57 @@ func (s *sinkImpl[T]) AddRow(rowTransformer RowTransformer[T]) error {
 	return nil
 }
 
+func (s *sinkImpl[T]) AddArrowRecord(record arrow.Record) error {
+	if s.state != sinkOperational {
+		panic(s.unexpectedState(sinkOperational))
+	}
+
+	rows := uint64(record.NumRows())
+	if rows == 0 {
+		return nil
+	}
+
+	if err := s.readLimiter.addRows(rows); err != nil {
+		return fmt.Errorf("add rows to read limiter: %w", err)
+	}
+
+	// the rows of the record are considered to be of the same size
+	bytesPerRow := (uint64(util.TotalRecordSize(record)) + rows - 1) / rows
+
+	for offset := uint64(0); offset < rows; {
+		count, err := s.trafficTracker.tryAddRows(bytesPerRow, rows-offset)
+		if err != nil {
+			return fmt.Errorf("add rows to traffic tracker: %w", err)
+		}
+
+		// If page is already too large, flush buffer to the channel and create a new one
+		if count == 0 {
+			if err := s.flush(true, false); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+
+			continue
+		}
+
+		if s.currBuffer.TotalRows() == 0 {
+			s.pageStartedAt = time.Now()
+		}
+
+		slice := record.NewSlice(int64(offset), int64(offset+count))
+		err = s.currBuffer.addRecord(slice)
+
+		slice.Release()
+
+		if err != nil {
+			return fmt.Errorf("add record to buffer: %w", err)
+		}
+
+		offset += count
+	}
+
+	return nil
+}
+
 func (s *sinkImpl[T]) flush(makeNewBuffer bool, isTerminalMessage bool) error {
 	if s.currBuffer.TotalRows() == 0 {
 		return nil
@@ -77,8 +144,59 @@ func (s *sinkImpl[T]) flush(makeNewBuffer bool, isTerminalMessage bool) error {
 
 	stats := s.trafficTracker.DumpStats(false)
 
+	_, span := utils.StartSpan(
+		s.ctx,
+		"Sink.Flush",
+		trace.WithAttributes(
+			attribute.Int64("rows", int64(stats.Rows)),
+			attribute.Int64("bytes", int64(stats.Bytes)),
+			attribute.Bool("terminal", isTerminalMessage),
+		),
+	)
+
+	err := s.doFlush(stats, makeNewBuffer, isTerminalMessage)
+
+	utils.EndSpan(span, err)
+
+	return err
+}
+
+func (s *sinkImpl[T]) doFlush(stats *api_service_protos.TReadSplitsResponse_TStats, makeNewBuffer bool, isTerminalMessage bool) error {
+	// the last page is usually incomplete, so it tells nothing about the data source speed
+	if !isTerminalMessage {
+		s.pageSizer.observeFill(time.Since(s.pageStartedAt))
+	}
+
+	// wait if the client reads data too fast
+	if err := s.throttler.Throttle(s.ctx, stats.Rows, stats.Bytes); err != nil {
+		return fmt.Errorf("throttle: %w", err)
+	}
+
+	// wait if too many pages are waiting for sending across the whole service
+	reservation, err := s.memory.Reserve(s.ctx, stats.Bytes)
+	if err != nil {
+		return fmt.Errorf("reserve memory: %w", err)
+	}
+
+	// serialize (and compress) the page right here, so that the data is kept in the result queue
+	// in the most compact form, and buffer resources are released as soon as possible
+	response, err := s.currBuffer.ToResponse()
+	s.currBuffer.Release()
+
+	if err != nil {
+		reservation.Release()
+		return fmt.Errorf("buffer to response: %w", err)
+	}
+
+	response.Stats = stats
+
+	compressedBytes := uint64(len(response.GetArrowIpcStreaming()))
+	s.trafficTracker.addPage(compressedBytes)
+	s.metrics.addPage(stats.Bytes, compressedBytes, s.trafficTracker.bytesPerPage())
+
 	// enqueue message to GRPC stream
-	s.respondWith(s.currBuffer, stats, nil, isTerminalMessage)
+	s.respondWith(response, stats, reservation, nil, isTerminalMessage)
+	s.pagesEmitted++
 
 	// create empty buffer and reset counters
 	s.currBuffer = nil
@@ -105,7 +223,7 @@ func (s *sinkImpl[T]) Finish() {
 	if s.state == sinkOperational {
 		err := s.flush(false, true)
 		if err != nil {
-			s.respondWith(nil, nil, fmt.Errorf("flush: %w", err), true)
+			s.respondWith(nil, nil, nil, fmt.Errorf("flush: %w", err), true)
 			s.state = sinkFailed
 		} else {
 			s.state = sinkFinished
@@ -120,21 +238,25 @@ func (s *sinkImpl[T]) Finish() {
 }
 
 func (s *sinkImpl[T]) respondWith(
-	buf ColumnarBuffer[T],
+	response *api_service_protos.TReadSplitsResponse,
 	stats *api_service_protos.TReadSplitsResponse_TStats,
+	reservation MemoryReservation,
 	err error,
 	isTerminalMessage bool) {
 	result := &ReadResult[T]{
-		ColumnarBuffer:    buf,
+		Response:          response,
 		Stats:             stats,
 		Error:             err,
 		IsTerminalMessage: isTerminalMessage,
 		Logger:            s.logger,
+		reservation:       reservation,
 	}
 
 	select {
 	case s.resultQueue <- result:
 	case <-s.ctx.Done():
+		// nobody is going to read this page
+		result.Release()
 	}
 }
 
@@ -147,3 +269,15 @@ func (s *sinkImpl[T]) unexpectedState(expected ...sinkState) error {
 func (s *sinkImpl[T]) Logger() *zap.Logger {
 	return s.logger
 }
+
+func (s *sinkImpl[T]) Stats() *SinkStats {
+	total := s.trafficTracker.DumpStats(true)
+
+	return &SinkStats{
+		Rows:  total.Rows,
+		Bytes: total.Bytes,
+		Pages: s.pagesEmitted,
+
+		CompressedBytes: s.trafficTracker.compressedBytes(),
+	}
+}
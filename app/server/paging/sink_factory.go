@@ -28,6 +28,10 @@ type sinkFactoryImpl[T Acceptor] struct {
 	resultQueue   chan *ReadResult[T]      // outgoing buffer queue
 	bufferFactory ColumnarBufferFactory[T] // factory responsible for ColumnarBuffer generation
 	readLimiter   ReadLimiter              // helps to restrict the number of rows read in every request
+	throttler     Throttler                // helps to restrict the reading speed
+	metrics       *Metrics                 // service-wide page metrics
+	memory        MemoryAccountant         // restricts the total size of prefetched pages across the service
+	pageSizer     PageSizer                // chooses the page size, shared across all sinks of the request
 	state         sinkFactoryState
 	totalSinks    int
 
@@ -58,15 +62,19 @@ func (f *sinkFactoryImpl[T]) MakeSinks(params []*SinkParams) ([]Sink[T], error)
 		}
 
 		// preserve traffic tracker to obtain stats in future
-		trafficTracker := newTrafficTracker[T](f.cfg)
+		trafficTracker := newTrafficTracker[T](f.cfg, f.pageSizer)
 		f.trafficTrackers = append(f.trafficTrackers, trafficTracker)
 
 		sink := &sinkImpl[T]{
 			bufferFactory:  f.bufferFactory,
 			readLimiter:    f.readLimiter,
+			throttler:      f.throttler,
 			resultQueue:    f.resultQueue, // result queue is shared across multiple Sink instances
 			terminateChan:  terminateChan,
 			trafficTracker: trafficTracker,
+			metrics:        f.metrics,
+			memory:         f.memory,
+			pageSizer:      f.pageSizer,
 			currBuffer:     buffer,
 			logger:         params[i].Logger,
 			state:          sinkOperational,
@@ -84,7 +92,7 @@ func (f *sinkFactoryImpl[T]) MakeSinks(params []*SinkParams) ([]Sink[T], error)
 	return result, nil
 }
 
-// ResultQueue returns a channel with columnar buffers generated by all sinks
+// ResultQueue returns a channel with pages generated by all sinks
 func (f *sinkFactoryImpl[T]) ResultQueue() <-chan *ReadResult[T] {
 	return f.resultQueue
 }
@@ -102,6 +110,11 @@ func (f *sinkFactoryImpl[T]) FinalStats() *api_service_protos.TReadSplitsRespons
 	return overallStats
 }
 
+// PageSizer returns the object choosing the page size for the request.
+func (f *sinkFactoryImpl[T]) PageSizer() PageSizer {
+	return f.pageSizer
+}
+
 func (f *sinkFactoryImpl[T]) sinkTerminationHandler(terminateChan <-chan Sink[T]) {
 	terminatedSinks := 0
 
@@ -137,11 +150,18 @@ func NewSinkFactory[T Acceptor](
 	cfg *config.TPagingConfig,
 	columnarBufferFactory ColumnarBufferFactory[T],
 	readLimiter ReadLimiter,
+	throttler Throttler,
+	metrics *Metrics,
+	memoryAccountant MemoryAccountant,
 ) SinkFactory[T] {
 	sf := &sinkFactoryImpl[T]{
 		state:         sinkFactoryIdle,
 		bufferFactory: columnarBufferFactory,
 		readLimiter:   readLimiter,
+		throttler:     throttler,
+		metrics:       metrics,
+		memory:        memoryAccountant,
+		pageSizer:     newPageSizer(cfg, metrics),
 		resultQueue:   make(chan *ReadResult[T], cfg.PrefetchQueueCapacity),
 		cfg:           cfg,
 		ctx:           ctx,
@@ -0,0 +1,94 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// PageReader provides the pages serialized during the previous reading of the same split
+type PageReader interface {
+	// ReadPage returns the next page or io.EOF if there are no more pages
+	ReadPage() (*api_service_protos.TReadSplitsResponse, error)
+}
+
+var _ SinkFactory[any] = (*ReplaySinkFactory[any])(nil)
+
+// ReplaySinkFactory yields the pages saved previously instead of the ones made of the data source rows.
+// The pages are put into the result queue by Replay method.
+type ReplaySinkFactory[T Acceptor] struct {
+	pages       PageReader
+	resultQueue chan *ReadResult[T]
+	pageSizer   PageSizer
+	stats       *api_service_protos.TReadSplitsResponse_TStats
+	logger      *zap.Logger
+}
+
+func (*ReplaySinkFactory[T]) MakeSinks([]*SinkParams) ([]Sink[T], error) {
+	return nil, fmt.Errorf("replayed pages need no sinks: %w", common.ErrMethodNotSupported)
+}
+
+func (f *ReplaySinkFactory[T]) ResultQueue() <-chan *ReadResult[T] { return f.resultQueue }
+
+// FinalStats returns the overall statistics of the replayed pages
+func (f *ReplaySinkFactory[T]) FinalStats() *api_service_protos.TReadSplitsResponse_TStats {
+	return f.stats
+}
+
+func (f *ReplaySinkFactory[T]) PageSizer() PageSizer { return f.pageSizer }
+
+// Replay reads all the pages and puts them into the result queue, the queue is closed when the pages are over.
+func (f *ReplaySinkFactory[T]) Replay(ctx context.Context) error {
+	defer close(f.resultQueue)
+
+	for {
+		response, err := f.pages.ReadPage()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("read page: %w", err)
+		}
+
+		f.stats.Rows += response.GetStats().GetRows()
+		f.stats.Bytes += response.GetStats().GetBytes()
+
+		result := &ReadResult[T]{
+			Response: response,
+			Stats:    response.GetStats(),
+			// only the last page of the stream carries the status
+			IsTerminalMessage: response.GetError() != nil,
+			Logger:            f.logger,
+		}
+
+		select {
+		case f.resultQueue <- result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func NewReplaySinkFactory[T Acceptor](
+	logger *zap.Logger,
+	cfg *config.TPagingConfig,
+	metrics *Metrics,
+	pages PageReader,
+) *ReplaySinkFactory[T] {
+	return &ReplaySinkFactory[T]{
+		pages:       pages,
+		resultQueue: make(chan *ReadResult[T], cfg.PrefetchQueueCapacity),
+		// the pages are already made, so the client throughput is of no use
+		pageSizer: &pageSizerStatic{cfg: cfg, metrics: metrics},
+		stats:     &api_service_protos.TReadSplitsResponse_TStats{},
+		logger:    logger,
+	}
+}
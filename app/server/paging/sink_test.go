@@ -0,0 +1,117 @@
+package paging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+func readTestPage(t *testing.T, response *api_service_protos.TReadSplitsResponse) ([]string, []string) {
+	reader, err := ipc.NewReader(bytes.NewReader(response.GetArrowIpcStreaming()))
+	require.NoError(t, err)
+
+	defer reader.Release()
+
+	var fields, values []string
+
+	for _, field := range reader.Schema().Fields() {
+		fields = append(fields, field.Name)
+	}
+
+	for reader.Next() {
+		column := reader.Record().Column(1).(*array.String)
+		for i := 0; i < column.Len(); i++ {
+			values = append(values, column.Value(i))
+		}
+	}
+
+	require.NoError(t, reader.Err())
+
+	return fields, values
+}
+
+func TestSinkAddArrowRecord(t *testing.T) {
+	allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer allocator.AssertSize(t, 0)
+
+	selectWhat := &api_service_protos.TSelect_TWhat{}
+
+	for _, column := range []*Ydb.Column{
+		{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+		{Name: "name", Type: common.MakePrimitiveType(Ydb.Type_UTF8)},
+	} {
+		selectWhat.Items = append(selectWhat.Items, &api_service_protos.TSelect_TWhat_TItem{
+			Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: column},
+		})
+	}
+
+	bufferFactory, err := NewColumnarBufferFactory[any](
+		zap.NewNop(), allocator, api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING, config.TPagingConfig_NONE, selectWhat, nil)
+	require.NoError(t, err)
+
+	cfg := &config.TPagingConfig{RowsPerPage: 3, PrefetchQueueCapacity: 10}
+
+	sinkFactory := NewSinkFactory[any](
+		context.Background(),
+		zap.NewNop(),
+		cfg,
+		bufferFactory,
+		NewReadLimiterFactory(nil).MakeReadLimiter(zap.NewNop()),
+		ThrottlerNoop{},
+		NewMetrics(nop.Registry{}),
+		NewMemoryAccountant(cfg, allocator, nop.Registry{}),
+	)
+
+	sinks, err := sinkFactory.MakeSinks([]*SinkParams{{Logger: zap.NewNop()}})
+	require.NoError(t, err)
+
+	// the records of the data source are named differently from the requested columns
+	builder := array.NewRecordBuilder(allocator, arrow.NewSchema([]arrow.Field{
+		{Name: "data_source_id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "data_source_name", Type: arrow.BinaryTypes.String},
+	}, nil))
+	defer builder.Release()
+
+	// the records are split and merged to fit the pages
+	for _, names := range [][]string{{"a", "b"}, {"c", "d", "e", "f", "g"}} {
+		for i, name := range names {
+			builder.Field(0).(*array.Int32Builder).Append(int32(i))
+			builder.Field(1).(*array.StringBuilder).Append(name)
+		}
+
+		record := builder.NewRecord()
+		require.NoError(t, sinks[0].AddArrowRecord(record))
+		record.Release()
+	}
+
+	sinks[0].Finish()
+
+	var pages [][]string
+
+	for result := range sinkFactory.ResultQueue() {
+		require.NoError(t, result.Error)
+
+		fields, values := readTestPage(t, result.Response)
+		require.Equal(t, []string{"id", "name"}, fields)
+
+		pages = append(pages, values)
+
+		result.Release()
+	}
+
+	require.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g"}}, pages)
+	require.Equal(t, uint64(7), sinks[0].Stats().Rows)
+}
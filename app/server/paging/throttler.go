@@ -0,0 +1,17 @@
+package paging
+
+import (
+	"context"
+)
+
+// Throttler slows down the data reading if the client exceeds its rate limits.
+// It's called every time when the page is ready to be sent to the client.
+type Throttler interface {
+	Throttle(ctx context.Context, rows, bytes uint64) error
+}
+
+var _ Throttler = ThrottlerNoop{}
+
+type ThrottlerNoop struct{}
+
+func (ThrottlerNoop) Throttle(context.Context, uint64, uint64) error { return nil }
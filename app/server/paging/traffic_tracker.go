@@ -11,12 +11,16 @@ import (
 
 type trafficTracker[T Acceptor] struct {
 	pagination  *config.TPagingConfig
+	pageSizer   PageSizer // provides the limit on the page size in bytes
 	sizePattern *sizePattern[T]
 
 	// cumulative sums of bytes passed and rows handled since the start of the request
 	bytesTotal *utils.Counter[uint64]
 	rowsTotal  *utils.Counter[uint64]
 
+	// cumulative sum of the sizes of serialized (and possibly compressed) pages
+	compressedBytesTotal *utils.Counter[uint64]
+
 	// sums of bytes and rows accumulated since last flush
 	bytesCurr *utils.Counter[uint64]
 	rowsCurr  *utils.Counter[uint64]
@@ -51,6 +55,39 @@ func (tt *trafficTracker[T]) tryAddRow(acceptors []T) (bool, error) {
 	return true, nil
 }
 
+// tryAddRows is the batch version of tryAddRow for the rows of the same (average) size.
+// It returns the number of rows that fit into the current page (possibly zero)
+// and increases the internal counters accordingly.
+func (tt *trafficTracker[T]) tryAddRows(bytesPerRow, rows uint64) (uint64, error) {
+	// make sure that a single row fits into an empty page
+	if _, err := tt.checkPageSizeLimit(bytesPerRow, 1); err != nil {
+		return 0, fmt.Errorf("check page size limit: %w", err)
+	}
+
+	if bytesPerPage := tt.pageSizer.bytesPerPage(); bytesPerPage != 0 && bytesPerRow != 0 {
+		var fit uint64
+
+		switch bytesCurr := tt.bytesCurr.Value(); {
+		case bytesCurr == 0:
+			// the row may exceed the current limit, then it gets a page of its own
+			fit = max(bytesPerPage/bytesPerRow, 1)
+		case bytesCurr < bytesPerPage:
+			fit = (bytesPerPage - bytesCurr) / bytesPerRow
+		}
+
+		rows = min(rows, fit)
+	}
+
+	if rowsPerPage := tt.pagination.RowsPerPage; rowsPerPage != 0 {
+		rows = min(rows, rowsPerPage-min(tt.rowsCurr.Value(), rowsPerPage))
+	}
+
+	tt.bytesCurr.Add(rows * bytesPerRow)
+	tt.rowsCurr.Add(rows)
+
+	return rows, nil
+}
+
 func (tt *trafficTracker[T]) maybeInit(acceptors []T) error {
 	if tt.sizePattern == nil {
 		// lazy initialization when the first row is ready
@@ -66,19 +103,20 @@ func (tt *trafficTracker[T]) maybeInit(acceptors []T) error {
 }
 
 func (tt *trafficTracker[T]) checkPageSizeLimit(bytesDelta, rowsDelta uint64) (bool, error) {
-	if tt.pagination.BytesPerPage != 0 {
+	if bytesPerPage := tt.pageSizer.bytesPerPage(); bytesPerPage != 0 {
 		// almost impossible case, but have to check
-		if bytesDelta > tt.pagination.BytesPerPage {
+		if maxBytesPerPage := tt.pageSizer.maxBytesPerPage(); bytesDelta > maxBytesPerPage {
 			err := fmt.Errorf(
 				"single row size exceeds page size limit (%d > %d bytes): %w",
 				bytesDelta,
-				tt.pagination.BytesPerPage,
+				maxBytesPerPage,
 				common.ErrPageSizeExceeded)
 
 			return true, err
 		}
 
-		if tt.bytesCurr.Value()+bytesDelta > tt.pagination.BytesPerPage {
+		// with adaptive paging the row may exceed the current limit, then it gets a page of its own
+		if tt.bytesCurr.Value() != 0 && tt.bytesCurr.Value()+bytesDelta > bytesPerPage {
 			return true, nil
 		}
 	}
@@ -92,6 +130,21 @@ func (tt *trafficTracker[T]) checkPageSizeLimit(bytesDelta, rowsDelta uint64) (b
 	return false, nil
 }
 
+// addPage accounts the size of the page that has been made from the rows added since last flush
+func (tt *trafficTracker[T]) addPage(compressedBytes uint64) {
+	tt.compressedBytesTotal.Add(compressedBytes)
+}
+
+// bytesPerPage returns the page size limit used for the current page
+func (tt *trafficTracker[T]) bytesPerPage() uint64 {
+	return tt.pageSizer.bytesPerPage()
+}
+
+// compressedBytes returns the total size of pages passed through the tracker
+func (tt *trafficTracker[T]) compressedBytes() uint64 {
+	return tt.compressedBytesTotal.Value()
+}
+
 func (tt *trafficTracker[T]) refreshCounters() {
 	tt.bytesCurr = tt.bytesTotal.MakeChild()
 	tt.rowsCurr = tt.rowsTotal.MakeChild()
@@ -114,11 +167,14 @@ func (tt *trafficTracker[T]) DumpStats(total bool) *api_service_protos.TReadSpli
 	return result
 }
 
-func newTrafficTracker[T Acceptor](pagination *config.TPagingConfig) *trafficTracker[T] {
+func newTrafficTracker[T Acceptor](pagination *config.TPagingConfig, pageSizer PageSizer) *trafficTracker[T] {
 	tt := &trafficTracker[T]{
 		pagination: pagination,
+		pageSizer:  pageSizer,
 		bytesTotal: utils.NewCounter[uint64](),
 		rowsTotal:  utils.NewCounter[uint64](),
+
+		compressedBytesTotal: utils.NewCounter[uint64](),
 	}
 
 	tt.refreshCounters()
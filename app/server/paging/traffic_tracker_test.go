@@ -10,6 +10,7 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
 )
 
 func TestTrafficTracker(t *testing.T) {
@@ -18,7 +19,7 @@ func TestTrafficTracker(t *testing.T) {
 			RowsPerPage: 2,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](cfg, newPageSizer(cfg, NewMetrics(nop.Registry{})))
 
 		col1Acceptor := new(int32)
 		col2Acceptor := new(string)
@@ -88,7 +89,7 @@ func TestTrafficTracker(t *testing.T) {
 			BytesPerPage: 40,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](cfg, newPageSizer(cfg, NewMetrics(nop.Registry{})))
 
 		col1Acceptor := new(uint64)
 		col2Acceptor := new([]byte)
@@ -128,7 +129,7 @@ func TestTrafficTracker(t *testing.T) {
 			BytesPerPage: 1,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](cfg, newPageSizer(cfg, NewMetrics(nop.Registry{})))
 		col1Acceptor := new(int32)
 		acceptors := []any{col1Acceptor}
 
@@ -138,4 +139,68 @@ func TestTrafficTracker(t *testing.T) {
 		require.True(t, errors.Is(err, common.ErrPageSizeExceeded))
 		require.False(t, ok)
 	})
+
+	t.Run("compressed pages", func(t *testing.T) {
+		cfg := &config.TPagingConfig{
+			RowsPerPage: 1,
+		}
+
+		tt := newTrafficTracker[any](cfg, newPageSizer(cfg, NewMetrics(nop.Registry{})))
+		col1Acceptor := new(string)
+		acceptors := []any{col1Acceptor}
+
+		*col1Acceptor = "aaaaaaaaaa" // 10 bytes
+
+		ok, err := tt.tryAddRow(acceptors)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// emulate buffer flushing
+		tt.addPage(7)
+		tt.refreshCounters()
+
+		ok, err = tt.tryAddRow(acceptors)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		tt.addPage(8)
+		tt.refreshCounters()
+
+		// raw and compressed sizes are tracked independently
+		require.Equal(t, uint64(20), tt.DumpStats(true).Bytes)
+		require.Equal(t, uint64(15), tt.compressedBytes())
+	})
+
+	t.Run("batches of rows", func(t *testing.T) {
+		cfg := &config.TPagingConfig{
+			BytesPerPage: 100,
+			RowsPerPage:  8,
+		}
+
+		tt := newTrafficTracker[any](cfg, newPageSizer(cfg, NewMetrics(nop.Registry{})))
+
+		// limited by bytes
+		rows, err := tt.tryAddRows(30, 10)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), rows)
+
+		rows, err = tt.tryAddRows(30, 10)
+		require.NoError(t, err)
+		require.Zero(t, rows)
+
+		tt.refreshCounters()
+
+		// limited by rows
+		rows, err = tt.tryAddRows(10, 10)
+		require.NoError(t, err)
+		require.Equal(t, uint64(8), rows)
+
+		tt.refreshCounters()
+
+		// a single row doesn't fit into the page
+		_, err = tt.tryAddRows(150, 10)
+		require.True(t, errors.Is(err, common.ErrPageSizeExceeded))
+
+		require.Equal(t, &api_service_protos.TReadSplitsResponse_TStats{Rows: 11, Bytes: 90 + 80}, tt.DumpStats(true))
+	})
 }
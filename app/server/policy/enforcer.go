@@ -0,0 +1,374 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// kindsWithRowFiltering lists the data sources pushing the WHERE clause down to the database.
+// The other data sources can't guarantee that the row filters are applied.
+var kindsWithRowFiltering = map[api_common.EGenericDataSourceKind]struct{}{
+	api_common.EGenericDataSourceKind_CLICKHOUSE:    {},
+	api_common.EGenericDataSourceKind_POSTGRESQL:    {},
+	api_common.EGenericDataSourceKind_YDB:           {},
+	api_common.EGenericDataSourceKind_MS_SQL_SERVER: {},
+	api_common.EGenericDataSourceKind_MYSQL:         {},
+	api_common.EGenericDataSourceKind_GREENPLUM:     {},
+	api_common.EGenericDataSourceKind_ORACLE:        {},
+	api_common.EGenericDataSourceKind_LOGGING:       {},
+}
+
+// kindsWithCaseInsensitiveNames lists the data sources that treat the names of databases, tables and columns
+// case-insensitively (by default), so the names in the requests may differ in case from the ones in config
+var kindsWithCaseInsensitiveNames = map[api_common.EGenericDataSourceKind]struct{}{
+	api_common.EGenericDataSourceKind_MS_SQL_SERVER: {},
+	api_common.EGenericDataSourceKind_MYSQL:         {},
+}
+
+type rule struct {
+	index   int
+	cfg     *config.TDataPolicyConfig_TRule
+	denied  map[string]struct{}
+	allowed map[string]struct{} // nil if all the columns are allowed
+	masks   map[string]*config.TDataPolicyConfig_TMask
+}
+
+func (r *rule) matches(kind api_common.EGenericDataSourceKind, database, table string, foldCase bool) bool {
+	if r.cfg.Kind != api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED && r.cfg.Kind != kind {
+		return false
+	}
+
+	return matchPattern(r.cfg.Database, database, foldCase) && matchPattern(r.cfg.Table, table, foldCase)
+}
+
+func (r *rule) hides(column string, foldCase bool) bool {
+	if _, exists := lookupColumn(r.denied, column, foldCase); exists {
+		return true
+	}
+
+	if r.allowed != nil {
+		if _, exists := lookupColumn(r.allowed, column, foldCase); !exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPattern(pattern, name string, foldCase bool) bool {
+	if pattern == "" {
+		return true
+	}
+
+	if foldCase {
+		pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+	}
+
+	// patterns are validated when the enforcer is created
+	matched, _ := path.Match(pattern, name)
+
+	return matched
+}
+
+// lookupColumn finds the column in the map keyed by the column names from config
+func lookupColumn[V any](columns map[string]V, column string, foldCase bool) (V, bool) {
+	if value, exists := columns[column]; exists || !foldCase {
+		return value, exists
+	}
+
+	for name, value := range columns {
+		if strings.EqualFold(name, column) {
+			return value, true
+		}
+	}
+
+	var zero V
+
+	return zero, false
+}
+
+// tablePolicy combines the rules matching the particular table
+type tablePolicy struct {
+	rules []*rule
+	// set for the data sources with case-insensitive names
+	foldCase bool
+}
+
+// hiddenBy returns the first rule hiding the column or nil
+func (tp *tablePolicy) hiddenBy(column string) *rule {
+	for _, r := range tp.rules {
+		if r.hides(column, tp.foldCase) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// maskedBy returns the mask of the column and the rule it comes from; the first matching rule wins
+func (tp *tablePolicy) maskedBy(column string) (*config.TDataPolicyConfig_TMask, *rule) {
+	for _, r := range tp.rules {
+		if mask, exists := lookupColumn(r.masks, column, tp.foldCase); exists {
+			return mask, r
+		}
+	}
+
+	return nil, nil
+}
+
+var _ Enforcer = (*enforcerImpl)(nil)
+
+type enforcerImpl struct {
+	rules []*rule
+	salt  string
+}
+
+func (e *enforcerImpl) matchRules(dsi *api_common.TGenericDataSourceInstance, table string) *tablePolicy {
+	_, foldCase := kindsWithCaseInsensitiveNames[dsi.GetKind()]
+	result := &tablePolicy{foldCase: foldCase}
+
+	for _, r := range e.rules {
+		if r.matches(dsi.GetKind(), dsi.GetDatabase(), table, foldCase) {
+			result.rules = append(result.rules, r)
+		}
+	}
+
+	return result
+}
+
+func (e *enforcerImpl) ApplyToSchema(
+	dsi *api_common.TGenericDataSourceInstance,
+	table string,
+	schema *api_service_protos.TSchema,
+) (*api_service_protos.TSchema, Decisions, error) {
+	tp := e.matchRules(dsi, table)
+	if len(tp.rules) == 0 {
+		return schema, nil, nil
+	}
+
+	result := proto.Clone(schema).(*api_service_protos.TSchema)
+	result.Columns = result.Columns[:0]
+
+	var decisions Decisions
+
+	for _, column := range schema.Columns {
+		if r := tp.hiddenBy(column.Name); r != nil {
+			decisions = append(decisions, fmt.Sprintf("column `%s` hidden by rule #%d", column.Name, r.index))
+
+			continue
+		}
+
+		mask, r := tp.maskedBy(column.Name)
+		if mask == nil {
+			result.Columns = append(result.Columns, column)
+
+			continue
+		}
+
+		if _, err := makeMasker(mask, e.salt, column.Type); err != nil {
+			return nil, nil, fmt.Errorf("rule #%d, column `%s`: %w", r.index, column.Name, err)
+		}
+
+		if mask.Method == config.TDataPolicyConfig_NULLIFY && column.Type.GetOptionalType() == nil {
+			column = proto.Clone(column).(*Ydb.Column)
+			column.Type = common.MakeOptionalType(column.Type)
+		}
+
+		decisions = append(decisions, fmt.Sprintf("column `%s` masked with %v by rule #%d", column.Name, mask.Method, r.index))
+		result.Columns = append(result.Columns, column)
+	}
+
+	return result, decisions, nil
+}
+
+func (e *enforcerImpl) ApplyToSelect(
+	slct *api_service_protos.TSelect,
+	filtering api_service_protos.TReadSplitsRequest_EFiltering,
+) (*SelectRestrictions, error) {
+	tp := e.matchRules(slct.GetDataSourceInstance(), slct.GetFrom().GetTable())
+	if len(tp.rules) == 0 {
+		return &SelectRestrictions{Select: slct}, nil
+	}
+
+	restrictions := &SelectRestrictions{}
+
+	if err := e.makeMaskers(tp, slct, restrictions); err != nil {
+		return nil, err
+	}
+
+	operands, err := e.filterPredicates(tp, slct, filtering, restrictions)
+	if err != nil {
+		return nil, err
+	}
+
+	var rowFilters []*api_service_protos.TPredicate
+
+	for _, r := range tp.rules {
+		if len(r.cfg.RowFilters) == 0 {
+			continue
+		}
+
+		if _, exists := kindsWithRowFiltering[slct.GetDataSourceInstance().GetKind()]; !exists {
+			return nil, fmt.Errorf("rule #%d: row filters are not supported for data source kind %v: %w",
+				r.index, slct.GetDataSourceInstance().GetKind(), common.ErrAccessDenied)
+		}
+
+		rowFilters = append(rowFilters, r.cfg.RowFilters...)
+		restrictions.Decisions = append(restrictions.Decisions, fmt.Sprintf("row filters added by rule #%d", r.index))
+	}
+
+	restrictions.Select = proto.Clone(slct).(*api_service_protos.TSelect)
+	restrictions.Select.Where = nil
+	restrictions.MandatoryFiltering = len(rowFilters) > 0
+
+	if where := makeConjunction(append(rowFilters, operands...)); where != nil {
+		restrictions.Select.Where = &api_service_protos.TSelect_TWhere{FilterTyped: where}
+	}
+
+	return restrictions, nil
+}
+
+func (e *enforcerImpl) RestrictsColumn(dsi *api_common.TGenericDataSourceInstance, table, column string) bool {
+	tp := e.matchRules(dsi, table)
+	if tp.hiddenBy(column) != nil {
+		return true
+	}
+
+	mask, _ := tp.maskedBy(column)
+
+	return mask != nil
+}
+
+// makeMaskers rejects the denied columns and prepares the maskers for the selected columns
+func (e *enforcerImpl) makeMaskers(
+	tp *tablePolicy,
+	slct *api_service_protos.TSelect,
+	restrictions *SelectRestrictions,
+) error {
+	items := slct.GetWhat().GetItems()
+	maskers := make([]paging.ColumnMasker, len(items))
+
+	for i, item := range items {
+		column := item.GetColumn()
+
+		if r := tp.hiddenBy(column.GetName()); r != nil {
+			return fmt.Errorf("column `%s` is hidden by rule #%d: %w", column.GetName(), r.index, common.ErrAccessDenied)
+		}
+
+		mask, r := tp.maskedBy(column.GetName())
+		if mask == nil {
+			continue
+		}
+
+		masker, err := makeMasker(mask, e.salt, column.GetType())
+		if err != nil {
+			return fmt.Errorf("rule #%d, column `%s`: %w", r.index, column.GetName(), err)
+		}
+
+		maskers[i] = masker
+		restrictions.Maskers = maskers
+		restrictions.Decisions = append(restrictions.Decisions,
+			fmt.Sprintf("column `%s` masked with %v by rule #%d", column.GetName(), mask.Method, r.index))
+	}
+
+	return nil
+}
+
+// filterPredicates returns the operands of the WHERE clause that can be pushed down.
+// The predicates on the masked columns are dropped, otherwise one could find out
+// the original values by filtering. It's only possible in the optional filtering mode,
+// since the client applies the filter to the masked values on its side.
+func (*enforcerImpl) filterPredicates(
+	tp *tablePolicy,
+	slct *api_service_protos.TSelect,
+	filtering api_service_protos.TReadSplitsRequest_EFiltering,
+	restrictions *SelectRestrictions,
+) ([]*api_service_protos.TPredicate, error) {
+	var result []*api_service_protos.TPredicate
+
+	for _, operand := range conjunctionOperands(slct.GetWhere().GetFilterTyped()) {
+		columns := make(map[string]struct{})
+		referencedColumns(operand.ProtoReflect(), columns)
+
+		var maskedColumn string
+
+		for column := range columns {
+			if r := tp.hiddenBy(column); r != nil {
+				return nil, fmt.Errorf("column `%s` is hidden by rule #%d: %w", column, r.index, common.ErrAccessDenied)
+			}
+
+			if mask, _ := tp.maskedBy(column); mask != nil {
+				maskedColumn = column
+			}
+		}
+
+		if maskedColumn == "" {
+			result = append(result, operand)
+
+			continue
+		}
+
+		if filtering == api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY {
+			return nil, fmt.Errorf("filtering by masked column `%s`: %w", maskedColumn, common.ErrAccessDenied)
+		}
+
+		restrictions.Decisions = append(restrictions.Decisions,
+			fmt.Sprintf("predicate on masked column `%s` is not pushed down", maskedColumn))
+	}
+
+	return result, nil
+}
+
+// NewEnforcer makes the enforcer applying the data policy; if config is empty, the requests are passed as is
+func NewEnforcer(cfg *config.TDataPolicyConfig) (Enforcer, error) {
+	if cfg == nil {
+		return enforcerNoop{}, nil
+	}
+
+	e := &enforcerImpl{salt: cfg.HashSalt}
+
+	for i, ruleCfg := range cfg.Rules {
+		r := &rule{
+			index:  i,
+			cfg:    ruleCfg,
+			denied: make(map[string]struct{}, len(ruleCfg.DeniedColumns)),
+			masks:  make(map[string]*config.TDataPolicyConfig_TMask, len(ruleCfg.Masks)),
+		}
+
+		for _, pattern := range []string{ruleCfg.Database, ruleCfg.Table} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("rule #%d: invalid pattern '%s': %w", i, pattern, err)
+			}
+		}
+
+		for _, column := range ruleCfg.DeniedColumns {
+			r.denied[column] = struct{}{}
+		}
+
+		if len(ruleCfg.AllowedColumns) > 0 {
+			r.allowed = make(map[string]struct{}, len(ruleCfg.AllowedColumns))
+
+			for _, column := range ruleCfg.AllowedColumns {
+				r.allowed[column] = struct{}{}
+			}
+		}
+
+		for _, mask := range ruleCfg.Masks {
+			r.masks[mask.Column] = mask
+		}
+
+		e.rules = append(e.rules, r)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,279 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func makeEqual(column string, value int32) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{
+				Operation: api_service_protos.TPredicate_TComparison_EQ,
+				LeftValue: &api_service_protos.TExpression{
+					Payload: &api_service_protos.TExpression_Column{Column: column},
+				},
+				RightValue: &api_service_protos.TExpression{
+					Payload: &api_service_protos.TExpression_TypedValue{
+						TypedValue: common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), value),
+					},
+				},
+			},
+		},
+	}
+}
+
+var testPolicyConfig = &config.TDataPolicyConfig{
+	Rules: []*config.TDataPolicyConfig_TRule{
+		{
+			Kind:          api_common.EGenericDataSourceKind_POSTGRESQL,
+			Table:         "users*",
+			DeniedColumns: []string{"password"},
+			Masks: []*config.TDataPolicyConfig_TMask{
+				{Column: "email", Method: config.TDataPolicyConfig_HASH},
+				{Column: "age", Method: config.TDataPolicyConfig_NULLIFY},
+			},
+			RowFilters: []*api_service_protos.TPredicate{makeEqual("tenant_id", 1)},
+		},
+	},
+	HashSalt: "salt",
+}
+
+var testDataSourceInstance = &api_common.TGenericDataSourceInstance{
+	Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+	Database: "db",
+}
+
+func TestApplyToSchema(t *testing.T) {
+	enforcer, err := NewEnforcer(testPolicyConfig)
+	require.NoError(t, err)
+
+	schema := &api_service_protos.TSchema{
+		Columns: []*Ydb.Column{
+			{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+			{Name: "email", Type: common.MakePrimitiveType(Ydb.Type_UTF8)},
+			{Name: "password", Type: common.MakePrimitiveType(Ydb.Type_UTF8)},
+			{Name: "age", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+		},
+	}
+
+	actual, decisions, err := enforcer.ApplyToSchema(testDataSourceInstance, "users_2024", schema)
+	require.NoError(t, err)
+	require.Len(t, decisions, 3)
+
+	expected := &api_service_protos.TSchema{
+		Columns: []*Ydb.Column{
+			{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+			{Name: "email", Type: common.MakePrimitiveType(Ydb.Type_UTF8)},
+			{Name: "age", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32))},
+		},
+	}
+	require.Equal(t, expected.String(), actual.String())
+
+	// source schema is kept intact
+	require.Len(t, schema.Columns, 4)
+
+	t.Run("other table", func(t *testing.T) {
+		actual, decisions, err := enforcer.ApplyToSchema(testDataSourceInstance, "orders", schema)
+		require.NoError(t, err)
+		require.Empty(t, decisions)
+		require.Equal(t, schema, actual)
+	})
+}
+
+func TestApplyToSelect(t *testing.T) {
+	enforcer, err := NewEnforcer(testPolicyConfig)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		table     string
+		columns   []string
+		where     *api_service_protos.TPredicate
+		filtering api_service_protos.TReadSplitsRequest_EFiltering
+		err       error
+		// the expectations below are checked if the request is admitted
+		expectedWhere      *api_service_protos.TPredicate
+		mandatoryFiltering bool
+		masked             []bool // nil if no columns are masked
+		decisions          int
+	}{
+		{
+			name:      "denied column",
+			table:     "users",
+			columns:   []string{"id", "password"},
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			err:       common.ErrAccessDenied,
+		},
+		{
+			name:      "masks and row filters",
+			table:     "users",
+			columns:   []string{"id", "email"},
+			where:     makeEqual("id", 42),
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			expectedWhere: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Conjunction{
+					Conjunction: &api_service_protos.TPredicate_TConjunction{
+						Operands: []*api_service_protos.TPredicate{makeEqual("tenant_id", 1), makeEqual("id", 42)},
+					},
+				},
+			},
+			mandatoryFiltering: true,
+			masked:             []bool{false, true},
+			decisions:          2,
+		},
+		{
+			name:               "predicate on masked column with optional filtering",
+			table:              "users",
+			columns:            []string{"id"},
+			where:              makeEqual("email", 1),
+			filtering:          api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			expectedWhere:      makeEqual("tenant_id", 1),
+			mandatoryFiltering: true,
+			decisions:          2,
+		},
+		{
+			name:      "predicate on masked column with mandatory filtering",
+			table:     "users",
+			columns:   []string{"id"},
+			where:     makeEqual("email", 1),
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+			err:       common.ErrAccessDenied,
+		},
+		{
+			name:      "no matching rules",
+			table:     "orders",
+			columns:   []string{"password"},
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slct := &api_service_protos.TSelect{
+				DataSourceInstance: testDataSourceInstance,
+				From:               &api_service_protos.TSelect_TFrom{Table: tc.table},
+				What:               &api_service_protos.TSelect_TWhat{},
+			}
+
+			for _, column := range tc.columns {
+				slct.What.Items = append(slct.What.Items, &api_service_protos.TSelect_TWhat_TItem{
+					Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+						Column: &Ydb.Column{Name: column, Type: common.MakePrimitiveType(Ydb.Type_UTF8)},
+					},
+				})
+			}
+
+			if tc.where != nil {
+				slct.Where = &api_service_protos.TSelect_TWhere{FilterTyped: tc.where}
+			}
+
+			source := proto.Clone(slct)
+
+			restrictions, err := enforcer.ApplyToSelect(slct, tc.filtering)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedWhere.String(), restrictions.Select.GetWhere().GetFilterTyped().String())
+			require.Equal(t, tc.mandatoryFiltering, restrictions.MandatoryFiltering)
+			require.Len(t, restrictions.Decisions, tc.decisions)
+
+			if tc.masked == nil {
+				require.Nil(t, restrictions.Maskers)
+			} else {
+				require.Len(t, restrictions.Maskers, len(tc.masked))
+
+				for i, masked := range tc.masked {
+					require.Equal(t, masked, restrictions.Maskers[i] != nil, tc.columns[i])
+				}
+			}
+
+			// source select is kept intact
+			require.True(t, proto.Equal(source, slct))
+		})
+	}
+}
+
+func TestNameCase(t *testing.T) {
+	enforcer, err := NewEnforcer(&config.TDataPolicyConfig{
+		Rules: []*config.TDataPolicyConfig_TRule{
+			{
+				Database:      "crm",
+				Table:         "users*",
+				DeniedColumns: []string{"password"},
+				Masks:         []*config.TDataPolicyConfig_TMask{{Column: "email", Method: config.TDataPolicyConfig_NULLIFY}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	makeSelect := func(kind api_common.EGenericDataSourceKind, columns ...string) *api_service_protos.TSelect {
+		slct := &api_service_protos.TSelect{
+			DataSourceInstance: &api_common.TGenericDataSourceInstance{Kind: kind, Database: "CRM"},
+			From:               &api_service_protos.TSelect_TFrom{Table: "Users"},
+			What:               &api_service_protos.TSelect_TWhat{},
+		}
+
+		for _, column := range columns {
+			slct.What.Items = append(slct.What.Items, &api_service_protos.TSelect_TWhat_TItem{
+				Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+					Column: &Ydb.Column{Name: column, Type: common.MakePrimitiveType(Ydb.Type_UTF8)},
+				},
+			})
+		}
+
+		return slct
+	}
+
+	// MySQL and MS SQL Server don't distinguish the names differing in case only
+	for _, kind := range []api_common.EGenericDataSourceKind{
+		api_common.EGenericDataSourceKind_MYSQL,
+		api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+	} {
+		_, err = enforcer.ApplyToSelect(makeSelect(kind, "id", "PassWord"), api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL)
+		require.ErrorIs(t, err, common.ErrAccessDenied, kind)
+
+		restrictions, err := enforcer.ApplyToSelect(makeSelect(kind, "EMAIL"), api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL)
+		require.NoError(t, err, kind)
+		require.NotNil(t, restrictions.Maskers[0], kind)
+
+		require.True(t, enforcer.RestrictsColumn(makeSelect(kind).DataSourceInstance, "USERS", "Email"), kind)
+	}
+
+	// PostgreSQL identifiers are quoted, so the names are case-sensitive
+	restrictions, err := enforcer.ApplyToSelect(
+		makeSelect(api_common.EGenericDataSourceKind_POSTGRESQL, "PassWord"),
+		api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+	)
+	require.NoError(t, err)
+	require.Empty(t, restrictions.Decisions)
+}
+
+func TestRestrictsColumn(t *testing.T) {
+	enforcer, err := NewEnforcer(testPolicyConfig)
+	require.NoError(t, err)
+
+	require.True(t, enforcer.RestrictsColumn(testDataSourceInstance, "users", "password"))
+	require.True(t, enforcer.RestrictsColumn(testDataSourceInstance, "users", "email"))
+	require.False(t, enforcer.RestrictsColumn(testDataSourceInstance, "users", "id"))
+	require.False(t, enforcer.RestrictsColumn(testDataSourceInstance, "orders", "password"))
+}
+
+func TestNewEnforcer(t *testing.T) {
+	_, err := NewEnforcer(&config.TDataPolicyConfig{
+		Rules: []*config.TDataPolicyConfig_TRule{{Table: "users["}},
+	})
+	require.Error(t, err)
+}
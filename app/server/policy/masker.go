@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// maskString is a masking function for the string values
+type maskString func(value string) string
+
+var _ paging.ColumnMasker = maskerString(nil)
+
+// maskerString applies masking function to the values of Utf8 and String columns
+type maskerString maskString
+
+func (m maskerString) Mask(value arrow.Array, builder array.Builder) error {
+	if value.IsNull(0) {
+		builder.AppendNull()
+
+		return nil
+	}
+
+	switch v := value.(type) {
+	case *array.String:
+		builder.(*array.StringBuilder).Append(m(v.Value(0)))
+	case *array.Binary:
+		builder.(*array.BinaryBuilder).Append([]byte(m(string(v.Value(0)))))
+	default:
+		return fmt.Errorf("unexpected array type %T: %w", value, common.ErrDataTypeNotSupported)
+	}
+
+	return nil
+}
+
+var _ paging.ColumnMasker = maskerNull{}
+
+// maskerNull replaces values of any type with NULL
+type maskerNull struct{}
+
+func (maskerNull) Mask(_ arrow.Array, builder array.Builder) error {
+	builder.AppendNull()
+
+	return nil
+}
+
+func makeHash(salt string) maskString {
+	return func(value string) string {
+		sum := sha256.Sum256([]byte(salt + value))
+
+		return hex.EncodeToString(sum[:])
+	}
+}
+
+func makePartialRedaction(keepPrefix, keepSuffix int) maskString {
+	return func(value string) string {
+		length := utf8.RuneCountInString(value)
+		if length <= keepPrefix+keepSuffix {
+			// nothing to hide without revealing the whole value
+			return strings.Repeat("*", length)
+		}
+
+		runes := []rune(value)
+		for i := keepPrefix; i < length-keepSuffix; i++ {
+			runes[i] = '*'
+		}
+
+		return string(runes)
+	}
+}
+
+// makeMasker checks if the masking method is applicable to the column type and returns the masker
+func makeMasker(mask *config.TDataPolicyConfig_TMask, salt string, ydbType *Ydb.Type) (paging.ColumnMasker, error) {
+	if mask.Method == config.TDataPolicyConfig_NULLIFY {
+		return maskerNull{}, nil
+	}
+
+	typeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+	if err != nil {
+		return nil, fmt.Errorf("column type: %w", err)
+	}
+
+	switch typeID {
+	case Ydb.Type_STRING, Ydb.Type_UTF8, Ydb.Type_JSON:
+	default:
+		return nil, fmt.Errorf("method %v is not applicable to the column of type %v: %w",
+			mask.Method, typeID, common.ErrDataTypeNotSupported)
+	}
+
+	switch mask.Method {
+	case config.TDataPolicyConfig_HASH:
+		return maskerString(makeHash(salt)), nil
+	case config.TDataPolicyConfig_PARTIAL:
+		return maskerString(makePartialRedaction(int(mask.KeepPrefix), int(mask.KeepSuffix))), nil
+	default:
+		return nil, fmt.Errorf("unknown masking method %v", mask.Method)
+	}
+}
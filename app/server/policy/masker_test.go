@@ -0,0 +1,38 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialRedaction(t *testing.T) {
+	testCases := []struct {
+		value      string
+		keepPrefix int
+		keepSuffix int
+		expected   string
+	}{
+		{value: "+79161234567", keepPrefix: 2, keepSuffix: 2, expected: "+7********67"},
+		{value: "john@example.com", keepPrefix: 1, expected: "j***************"},
+		{value: "Иванов", keepPrefix: 1, keepSuffix: 1, expected: "И****в"},
+		{value: "abc", keepPrefix: 2, keepSuffix: 1, expected: "***"},
+		{value: "", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.value, func(t *testing.T) {
+			require.Equal(t, tc.expected, makePartialRedaction(tc.keepPrefix, tc.keepSuffix)(tc.value))
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	hash := makeHash("salt")
+
+	require.Equal(t, hash("value"), hash("value"))
+	require.NotEqual(t, hash("value"), hash("other"))
+	require.NotEqual(t, hash("value"), makeHash("pepper")("value"))
+	require.Len(t, hash("value"), 64)
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"strings"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+)
+
+// Decisions describe the restrictions applied to the request in a human-readable form
+type Decisions []string
+
+func (d Decisions) String() string { return strings.Join(d, "; ") }
+
+// SelectRestrictions are the outcome of the data policy applied to the select
+type SelectRestrictions struct {
+	// Select with the row filters injected into the WHERE clause
+	Select *api_service_protos.TSelect
+	// Set if the row filters were injected: they must never be skipped during pushdown
+	MandatoryFiltering bool
+	// Maskers of the selected columns (nil items for the columns returned as is) or nil
+	Maskers []paging.ColumnMasker
+	Decisions
+}
+
+// Enforcer applies the data policy to the requests touching the sensitive data
+type Enforcer interface {
+	// ApplyToSchema hides the denied columns from the table schema
+	// and makes the columns masked with NULL optional.
+	ApplyToSchema(
+		dsi *api_common.TGenericDataSourceInstance,
+		table string,
+		schema *api_service_protos.TSchema,
+	) (*api_service_protos.TSchema, Decisions, error)
+	// ApplyToSelect rejects the select requesting the denied columns, injects the row filters
+	// and provides the maskers for the selected columns. Filtering mode of the request is required,
+	// because in the mandatory mode the predicates on the masked columns can't be dropped.
+	ApplyToSelect(
+		slct *api_service_protos.TSelect,
+		filtering api_service_protos.TReadSplitsRequest_EFiltering,
+	) (*SelectRestrictions, error)
+	// RestrictsColumn reports whether the column is hidden or masked, so that its values
+	// must not be revealed in any other way, e.g. within the key ranges of the table splits
+	RestrictsColumn(dsi *api_common.TGenericDataSourceInstance, table, column string) bool
+}
+
+var _ Enforcer = enforcerNoop{}
+
+type enforcerNoop struct{}
+
+func (enforcerNoop) ApplyToSchema(
+	_ *api_common.TGenericDataSourceInstance,
+	_ string,
+	schema *api_service_protos.TSchema,
+) (*api_service_protos.TSchema, Decisions, error) {
+	return schema, nil, nil
+}
+
+func (enforcerNoop) ApplyToSelect(
+	slct *api_service_protos.TSelect,
+	_ api_service_protos.TReadSplitsRequest_EFiltering,
+) (*SelectRestrictions, error) {
+	return &SelectRestrictions{Select: slct}, nil
+}
+
+func (enforcerNoop) RestrictsColumn(_ *api_common.TGenericDataSourceInstance, _, _ string) bool {
+	return false
+}
@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// referencedColumns collects the names of all the columns mentioned in the message
+func referencedColumns(msg protoreflect.Message, columns map[string]struct{}) {
+	if expression, ok := msg.Interface().(*api_service_protos.TExpression); ok {
+		if column := expression.GetColumn(); column != "" {
+			columns[column] = struct{}{}
+		}
+	}
+
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsMap():
+			// there are no maps in predicates
+		case field.IsList() && field.Message() != nil:
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				referencedColumns(list.Get(i).Message(), columns)
+			}
+		case field.Message() != nil:
+			referencedColumns(value.Message(), columns)
+		}
+
+		return true
+	})
+}
+
+// conjunctionOperands splits the predicate into the operands of the top-level conjunction
+func conjunctionOperands(predicate *api_service_protos.TPredicate) []*api_service_protos.TPredicate {
+	if predicate == nil {
+		return nil
+	}
+
+	if conjunction := predicate.GetConjunction(); conjunction != nil {
+		return conjunction.Operands
+	}
+
+	return []*api_service_protos.TPredicate{predicate}
+}
+
+// makeConjunction combines the operands back into a single predicate; nil if there are no operands
+func makeConjunction(operands []*api_service_protos.TPredicate) *api_service_protos.TPredicate {
+	switch len(operands) {
+	case 0:
+		return nil
+	case 1:
+		return operands[0]
+	default:
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Conjunction{
+				Conjunction: &api_service_protos.TPredicate_TConjunction{Operands: operands},
+			},
+		}
+	}
+}
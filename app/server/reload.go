@@ -0,0 +1,64 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+
+	app_config "github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// configReloader re-reads the config file and applies the reloadable sections
+// (datasources, paging, read limits and log level) to the running services.
+type configReloader struct {
+	configPath string
+	flags      *pflag.FlagSet // command line flags override the config file values
+	logLevel   zap.AtomicLevel
+	launcher   *Launcher
+	current    *app_config.TServerConfig
+}
+
+func (r *configReloader) reload() error {
+	// the new config is validated while parsing
+	next, err := config.NewConfigFromFile(r.configPath)
+	if err != nil {
+		return fmt.Errorf("new config: %w", err)
+	}
+
+	if err = overrideConfigWithFlags(next, r.flags); err != nil {
+		return fmt.Errorf("override config with flags: %w", err)
+	}
+
+	if err = config.CheckReloadable(r.current, next); err != nil {
+		return fmt.Errorf("check reloadable: %w", err)
+	}
+
+	if err = r.launcher.Reload(next); err != nil {
+		return fmt.Errorf("reload launcher: %w", err)
+	}
+
+	common.SetLogLevelFromConfig(r.logLevel, next.Logger)
+
+	r.current = next
+
+	return nil
+}
+
+func newConfigReloader(
+	configPath string,
+	flags *pflag.FlagSet,
+	logLevel zap.AtomicLevel,
+	launcher *Launcher,
+	cfg *app_config.TServerConfig,
+) *configReloader {
+	return &configReloader{
+		configPath: configPath,
+		flags:      flags,
+		logLevel:   logLevel,
+		launcher:   launcher,
+		current:    cfg,
+	}
+}
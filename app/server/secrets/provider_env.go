@@ -0,0 +1,35 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ Provider = (*providerEnv)(nil)
+
+// providerEnv reads secrets from the environment variables of the connector process
+type providerEnv struct {
+	variablePrefix string
+}
+
+func (p *providerEnv) Fetch(_ context.Context, name string) (string, error) {
+	if !strings.HasPrefix(name, p.variablePrefix) {
+		return "", fmt.Errorf("variable '%s' has no prefix '%s': %w", name, p.variablePrefix, common.ErrInvalidRequest)
+	}
+
+	value, exists := os.LookupEnv(name)
+	if !exists {
+		return "", fmt.Errorf("variable '%s' is not set", name)
+	}
+
+	return value, nil
+}
+
+func newProviderEnv(cfg *config.TSecretsConfig_TEnvProvider) Provider {
+	return &providerEnv{variablePrefix: cfg.VariablePrefix}
+}
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ Provider = (*providerFile)(nil)
+
+// providerFile reads secrets from the files located in the root directory,
+// e.g. the ones mounted from Kubernetes secrets
+type providerFile struct {
+	rootDir string // absolute path with symlinks evaluated
+}
+
+func (p *providerFile) Fetch(_ context.Context, path string) (string, error) {
+	fullPath := filepath.Join(p.rootDir, filepath.FromSlash(path))
+	if !p.contains(fullPath) {
+		return "", fmt.Errorf("path '%s' is outside of the root directory: %w", path, common.ErrInvalidRequest)
+	}
+
+	// Symlinks may point outside of the root directory too.
+	// Kubernetes mounts secrets as symlinks to the hidden directories within the same volume, so that's not the case.
+	fullPath, err := filepath.EvalSymlinks(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("evaluate symlinks: %w", err)
+	}
+
+	if !p.contains(fullPath) {
+		return "", fmt.Errorf("path '%s' is outside of the root directory: %w", path, common.ErrInvalidRequest)
+	}
+
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+
+	// Files are often written with a trailing newline
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func (p *providerFile) contains(path string) bool {
+	relPath, err := filepath.Rel(p.rootDir, path)
+
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
+func newProviderFile(cfg *config.TSecretsConfig_TFileProvider) (Provider, error) {
+	rootDir, err := filepath.Abs(cfg.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("absolute path: %w", err)
+	}
+
+	rootDir, err = filepath.EvalSymlinks(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate symlinks: %w", err)
+	}
+
+	return &providerFile{rootDir: rootDir}, nil
+}
@@ -0,0 +1,102 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ Provider = (*providerVault)(nil)
+
+// providerVault reads secrets from the HashiCorp Vault compatible HTTP API
+type providerVault struct {
+	address   *url.URL
+	tokenFile string
+	client    *http.Client
+}
+
+// vaultResponse covers both KV v1 (fields are kept in `data`) and KV v2 (fields are kept in `data.data`) responses
+type vaultResponse struct {
+	Data map[string]any `json:"data"`
+}
+
+func (p *providerVault) Fetch(ctx context.Context, path string) (string, error) {
+	path, field, found := strings.Cut(path, "#")
+	if !found || field == "" {
+		return "", fmt.Errorf("field is not specified in '%s': %w", path, common.ErrInvalidRequest)
+	}
+
+	if strings.Contains(path, "..") {
+		return "", fmt.Errorf("invalid path '%s': %w", path, common.ErrInvalidRequest)
+	}
+
+	token, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address.JoinPath("v1", path).String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+
+	request.Header.Set("X-Vault-Token", strings.TrimSpace(string(token)))
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("do request: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		// the body is not returned, because it's not supposed to be shown to the client
+		_, _ = io.Copy(io.Discard, response.Body)
+
+		return "", fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	var body vaultResponse
+
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode response: %w", err)
+	}
+
+	fields := body.Data
+	if nested, ok := fields["data"].(map[string]any); ok {
+		fields = nested
+	}
+
+	value, ok := fields[field].(string)
+	if !ok {
+		return "", fmt.Errorf("field '%s' is missing or is not a string", field)
+	}
+
+	return value, nil
+}
+
+func newProviderVault(cfg *config.TSecretsConfig_TVaultProvider) (Provider, error) {
+	address, err := url.Parse(cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("parse address: %w", err)
+	}
+
+	requestTimeout, err := common.DurationFromString(cfg.RequestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("parse request timeout: %w", err)
+	}
+
+	return &providerVault{
+		address:   address,
+		tokenFile: cfg.TokenFile,
+		client:    &http.Client{Timeout: requestTimeout},
+	}, nil
+}
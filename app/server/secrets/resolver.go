@@ -0,0 +1,200 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+var _ Resolver = (*resolverImpl)(nil)
+
+// resolverImpl picks the provider by the reference prefix and caches the fetched secrets
+type resolverImpl struct {
+	// providers are keyed by reference prefix; nil provider means that it is not configured
+	providers map[string]Provider
+	// bindings are keyed by reference
+	bindings map[string][]*config.TSecretsConfig_TBinding
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mutex sync.Mutex
+	cache map[string]cacheEntry
+}
+
+func (r *resolverImpl) ResolveCredentials(
+	ctx context.Context,
+	dsi *api_common.TGenericDataSourceInstance,
+) (*api_common.TGenericDataSourceInstance, error) {
+	if !r.hasReferences(dsi.GetCredentials()) {
+		return dsi, nil
+	}
+
+	resolved := proto.Clone(dsi).(*api_common.TGenericDataSourceInstance)
+
+	var fields []*string
+
+	switch payload := resolved.Credentials.Payload.(type) {
+	case *api_common.TGenericCredentials_Basic:
+		fields = []*string{&payload.Basic.Username, &payload.Basic.Password}
+	case *api_common.TGenericCredentials_Token:
+		fields = []*string{&payload.Token.Value}
+	}
+
+	for _, field := range fields {
+		value, err := r.resolve(ctx, dsi, *field)
+		if err != nil {
+			return nil, err
+		}
+
+		*field = value
+	}
+
+	return resolved, nil
+}
+
+func (r *resolverImpl) hasReferences(credentials *api_common.TGenericCredentials) bool {
+	switch {
+	case credentials.GetBasic() != nil:
+		return r.isReference(credentials.GetBasic().Username) || r.isReference(credentials.GetBasic().Password)
+	case credentials.GetToken() != nil:
+		return r.isReference(credentials.GetToken().Value)
+	default:
+		return false
+	}
+}
+
+func (r *resolverImpl) isReference(value string) bool {
+	_, _, found := r.splitReference(value)
+
+	return found
+}
+
+func (r *resolverImpl) splitReference(value string) (prefix, path string, found bool) {
+	for prefix := range r.providers {
+		if path, found := strings.CutPrefix(value, prefix); found {
+			return prefix, path, true
+		}
+	}
+
+	return "", "", false
+}
+
+// resolve returns the value of the referenced secret or the value itself if it is not a reference
+func (r *resolverImpl) resolve(ctx context.Context, dsi *api_common.TGenericDataSourceInstance, value string) (string, error) {
+	prefix, path, found := r.splitReference(value)
+	if !found {
+		return value, nil
+	}
+
+	provider := r.providers[prefix]
+	if provider == nil {
+		return "", fmt.Errorf("secret provider for '%s' references is not configured: %w", prefix, common.ErrInvalidRequest)
+	}
+
+	if !r.isBound(dsi, value) {
+		// the allowed data sources are not mentioned, since they are not supposed to be known to the client
+		return "", fmt.Errorf(
+			"secret '%s' is not allowed for %v data source '%s:%d' database '%s': %w",
+			value, dsi.Kind, dsi.GetEndpoint().GetHost(), dsi.GetEndpoint().GetPort(), dsi.Database, common.ErrInvalidRequest,
+		)
+	}
+
+	now := r.now()
+
+	r.mutex.Lock()
+	entry, exists := r.cache[value]
+	r.mutex.Unlock()
+
+	if exists && now.Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	secret, err := provider.Fetch(ctx, path)
+	if err != nil {
+		// the reference itself is not a secret, so it is safe to mention it
+		return "", fmt.Errorf("fetch secret '%s': %w", value, err)
+	}
+
+	if r.cacheTTL > 0 {
+		r.mutex.Lock()
+		r.cache[value] = cacheEntry{value: secret, expiresAt: now.Add(r.cacheTTL)}
+		r.mutex.Unlock()
+	}
+
+	return secret, nil
+}
+
+// isBound checks that the reference is allowed to be sent to the data source instance chosen by client
+func (r *resolverImpl) isBound(dsi *api_common.TGenericDataSourceInstance, reference string) bool {
+	for _, binding := range r.bindings[reference] {
+		if binding.Kind == dsi.Kind &&
+			strings.EqualFold(binding.Endpoint.GetHost(), dsi.GetEndpoint().GetHost()) &&
+			binding.Endpoint.GetPort() == dsi.GetEndpoint().GetPort() &&
+			(binding.Database == "" || binding.Database == dsi.Database) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewResolver makes a resolver according to config, the providers missing in the config are disabled
+func NewResolver(cfg *config.TSecretsConfig) (Resolver, error) {
+	if cfg == nil {
+		return NewResolverNoop(), nil
+	}
+
+	cacheTTL, err := common.DurationFromString(cfg.CacheTtl)
+	if err != nil {
+		return nil, fmt.Errorf("parse cache TTL: %w", err)
+	}
+
+	r := &resolverImpl{
+		providers: map[string]Provider{
+			prefixFile:  nil,
+			prefixEnv:   nil,
+			prefixVault: nil,
+		},
+		bindings: make(map[string][]*config.TSecretsConfig_TBinding),
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+		cache:    make(map[string]cacheEntry),
+	}
+
+	for _, binding := range cfg.Bindings {
+		r.bindings[binding.Reference] = append(r.bindings[binding.Reference], binding)
+	}
+
+	if cfg.File != nil {
+		r.providers[prefixFile], err = newProviderFile(cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("new file provider: %w", err)
+		}
+	}
+
+	if cfg.Env != nil {
+		r.providers[prefixEnv] = newProviderEnv(cfg.Env)
+	}
+
+	if cfg.Vault != nil {
+		r.providers[prefixVault], err = newProviderVault(cfg.Vault)
+		if err != nil {
+			return nil, fmt.Errorf("new vault provider: %w", err)
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,301 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	app_utils "github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var testEndpoint = &api_common.TGenericEndpoint{Host: "pg.example.net", Port: 5432}
+
+func makeBasicDataSourceInstance(username, password string) *api_common.TGenericDataSourceInstance {
+	return &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: testEndpoint,
+		Database: "crm",
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Basic{
+				Basic: &api_common.TGenericCredentials_TBasic{Username: username, Password: password},
+			},
+		},
+	}
+}
+
+// makeBindings allows the references to be used for the test PostgreSQL database
+func makeBindings(references ...string) []*config.TSecretsConfig_TBinding {
+	bindings := make([]*config.TSecretsConfig_TBinding, 0, len(references))
+
+	for _, reference := range references {
+		bindings = append(bindings, &config.TSecretsConfig_TBinding{
+			Reference: reference,
+			Kind:      api_common.EGenericDataSourceKind_POSTGRESQL,
+			Endpoint:  testEndpoint,
+			Database:  "crm",
+		})
+	}
+
+	return bindings
+}
+
+func TestResolverNoReferences(t *testing.T) {
+	resolver, err := NewResolver(&config.TSecretsConfig{
+		Env:      &config.TSecretsConfig_TEnvProvider{VariablePrefix: "TEST_"},
+		CacheTtl: "1m",
+	})
+	require.NoError(t, err)
+
+	dsi := makeBasicDataSourceInstance("user", "password")
+
+	resolved, err := resolver.ResolveCredentials(context.Background(), dsi)
+	require.NoError(t, err)
+	require.Same(t, dsi, resolved)
+
+	noop, err := NewResolver(nil)
+	require.NoError(t, err)
+
+	dsi = makeBasicDataSourceInstance("user", "env://TEST_PASSWORD")
+
+	resolved, err = noop.ResolveCredentials(context.Background(), dsi)
+	require.NoError(t, err)
+	require.Same(t, dsi, resolved)
+}
+
+func TestResolverFile(t *testing.T) {
+	tempDir := t.TempDir()
+	rootDir := filepath.Join(tempDir, "secrets")
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "pg"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "pg", "password"), []byte("secret\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "outside"), []byte("outside"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(tempDir, "outside"), filepath.Join(rootDir, "link")))
+
+	resolver, err := NewResolver(&config.TSecretsConfig{
+		File:     &config.TSecretsConfig_TFileProvider{RootDir: rootDir},
+		CacheTtl: "1m",
+		// the references pointing outside of the root dir are rejected even if they are bound
+		Bindings: makeBindings("secret://file/pg/password", "secret://file/../outside", "secret://file/link"),
+	})
+	require.NoError(t, err)
+
+	dsi := makeBasicDataSourceInstance("user", "secret://file/pg/password")
+
+	resolved, err := resolver.ResolveCredentials(context.Background(), dsi)
+	require.NoError(t, err)
+	require.Equal(t, "user", resolved.GetCredentials().GetBasic().Username)
+	require.Equal(t, "secret", resolved.GetCredentials().GetBasic().Password)
+
+	// the original instance stays untouched
+	require.Equal(t, "secret://file/pg/password", dsi.GetCredentials().GetBasic().Password)
+
+	for _, reference := range []string{"secret://file/../outside", "secret://file/link"} {
+		_, err = resolver.ResolveCredentials(context.Background(), makeBasicDataSourceInstance("user", reference))
+		require.ErrorIs(t, err, common.ErrInvalidRequest, reference)
+	}
+
+	// the provider is not configured
+	_, err = resolver.ResolveCredentials(context.Background(), makeBasicDataSourceInstance("user", "env://TEST_PASSWORD"))
+	require.ErrorIs(t, err, common.ErrInvalidRequest)
+}
+
+func TestResolverEnv(t *testing.T) {
+	t.Setenv("TEST_SECRET_TOKEN", "token")
+	t.Setenv("OTHER_TOKEN", "other")
+
+	resolver, err := NewResolver(&config.TSecretsConfig{
+		Env: &config.TSecretsConfig_TEnvProvider{VariablePrefix: "TEST_SECRET_"},
+		// caching is disabled to check the variable changes
+		CacheTtl: "0s",
+		Bindings: makeBindings("env://TEST_SECRET_TOKEN", "env://OTHER_TOKEN", "env://TEST_SECRET_MISSING"),
+	})
+	require.NoError(t, err)
+
+	dsi := &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: testEndpoint,
+		Database: "crm",
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Token{
+				Token: &api_common.TGenericCredentials_TToken{Type: "IAM", Value: "env://TEST_SECRET_TOKEN"},
+			},
+		},
+	}
+
+	resolved, err := resolver.ResolveCredentials(context.Background(), dsi)
+	require.NoError(t, err)
+	require.Equal(t, "token", resolved.GetCredentials().GetToken().Value)
+
+	dsi.GetCredentials().GetToken().Value = "env://OTHER_TOKEN"
+
+	_, err = resolver.ResolveCredentials(context.Background(), dsi)
+	require.ErrorIs(t, err, common.ErrInvalidRequest)
+
+	dsi.GetCredentials().GetToken().Value = "env://TEST_SECRET_MISSING"
+
+	_, err = resolver.ResolveCredentials(context.Background(), dsi)
+	require.Error(t, err)
+}
+
+func TestResolverVault(t *testing.T) {
+	var requests atomic.Int32
+
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		var data map[string]any
+
+		switch r.URL.Path {
+		case "/v1/kv/pg":
+			// KV v1
+			data = map[string]any{"data": map[string]any{"username": "user", "password": "v1-password"}}
+		case "/v1/secret/data/pg":
+			// KV v2
+			data = map[string]any{"data": map[string]any{"data": map[string]any{"password": "v2-password"}}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(data))
+	}))
+	defer stub.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("vault-token\n"), 0o600))
+
+	resolver, err := NewResolver(&config.TSecretsConfig{
+		Vault: &config.TSecretsConfig_TVaultProvider{
+			Address:        stub.URL,
+			TokenFile:      tokenFile,
+			RequestTimeout: "5s",
+		},
+		CacheTtl: "1m",
+		Bindings: makeBindings(
+			"vault://kv/pg#username", "vault://kv/pg#password", "vault://secret/data/pg#password",
+			"vault://kv/pg#missing", "vault://kv/unknown#password", "vault://kv/pg", "vault://kv/../sys#key",
+		),
+	})
+	require.NoError(t, err)
+
+	clock := app_utils.NewManualClock()
+	resolver.(*resolverImpl).now = clock.Now
+
+	resolve := func(username, password string) (*api_common.TGenericCredentials_TBasic, error) {
+		resolved, err := resolver.ResolveCredentials(context.Background(), makeBasicDataSourceInstance(username, password))
+		if err != nil {
+			return nil, err
+		}
+
+		return resolved.GetCredentials().GetBasic(), nil
+	}
+
+	basic, err := resolve("vault://kv/pg#username", "vault://kv/pg#password")
+	require.NoError(t, err)
+	require.Equal(t, "user", basic.Username)
+	require.Equal(t, "v1-password", basic.Password)
+	require.Equal(t, int32(2), requests.Load())
+
+	basic, err = resolve("user", "vault://secret/data/pg#password")
+	require.NoError(t, err)
+	require.Equal(t, "v2-password", basic.Password)
+	require.Equal(t, int32(3), requests.Load())
+
+	// cached values are used until TTL expires
+	_, err = resolve("vault://kv/pg#username", "vault://kv/pg#password")
+	require.NoError(t, err)
+	require.Equal(t, int32(3), requests.Load())
+
+	clock.Advance(2 * time.Minute)
+
+	_, err = resolve("vault://kv/pg#username", "vault://kv/pg#password")
+	require.NoError(t, err)
+	require.Equal(t, int32(5), requests.Load())
+
+	for _, reference := range []string{"vault://kv/pg#missing", "vault://kv/unknown#password", "vault://kv/pg", "vault://kv/../sys#key"} {
+		_, err = resolve("user", reference)
+		require.Error(t, err, reference)
+	}
+}
+
+func TestResolverBindings(t *testing.T) {
+	t.Setenv("TEST_SECRET_PASSWORD", "password")
+	t.Setenv("TEST_SECRET_OTHER", "other")
+
+	bindings := makeBindings("env://TEST_SECRET_PASSWORD")
+	// the database is not restricted
+	bindings = append(bindings, &config.TSecretsConfig_TBinding{
+		Reference: "env://TEST_SECRET_PASSWORD",
+		Kind:      api_common.EGenericDataSourceKind_CLICKHOUSE,
+		Endpoint:  &api_common.TGenericEndpoint{Host: "ch.example.net", Port: 9000},
+	})
+
+	resolver, err := NewResolver(&config.TSecretsConfig{
+		Env:      &config.TSecretsConfig_TEnvProvider{VariablePrefix: "TEST_SECRET_"},
+		CacheTtl: "1m",
+		Bindings: bindings,
+	})
+	require.NoError(t, err)
+
+	resolve := func(modify func(dsi *api_common.TGenericDataSourceInstance)) error {
+		dsi := makeBasicDataSourceInstance("user", "env://TEST_SECRET_PASSWORD")
+		modify(dsi)
+
+		_, err := resolver.ResolveCredentials(context.Background(), dsi)
+
+		return err
+	}
+
+	require.NoError(t, resolve(func(*api_common.TGenericDataSourceInstance) {}))
+	require.NoError(t, resolve(func(dsi *api_common.TGenericDataSourceInstance) {
+		dsi.Endpoint = &api_common.TGenericEndpoint{Host: "PG.example.net", Port: 5432}
+	}))
+	require.NoError(t, resolve(func(dsi *api_common.TGenericDataSourceInstance) {
+		dsi.Kind = api_common.EGenericDataSourceKind_CLICKHOUSE
+		dsi.Endpoint = &api_common.TGenericEndpoint{Host: "ch.example.net", Port: 9000}
+		dsi.Database = "any"
+	}))
+
+	// the secret cached for the allowed endpoint must not be sent anywhere else
+	for name, modify := range map[string]func(dsi *api_common.TGenericDataSourceInstance){
+		"host not on the list": func(dsi *api_common.TGenericDataSourceInstance) {
+			dsi.Endpoint = &api_common.TGenericEndpoint{Host: "attacker.example.net", Port: 5432}
+		},
+		"other port": func(dsi *api_common.TGenericDataSourceInstance) {
+			dsi.Endpoint = &api_common.TGenericEndpoint{Host: "pg.example.net", Port: 5433}
+		},
+		"no endpoint": func(dsi *api_common.TGenericDataSourceInstance) {
+			dsi.Endpoint = nil
+		},
+		"other database": func(dsi *api_common.TGenericDataSourceInstance) {
+			dsi.Database = "billing"
+		},
+		"other kind": func(dsi *api_common.TGenericDataSourceInstance) {
+			dsi.Kind = api_common.EGenericDataSourceKind_GREENPLUM
+		},
+		"reference not on the list": func(dsi *api_common.TGenericDataSourceInstance) {
+			dsi.GetCredentials().GetBasic().Password = "env://TEST_SECRET_OTHER"
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := resolve(modify)
+			require.ErrorIs(t, err, common.ErrInvalidRequest)
+			require.NotContains(t, err.Error(), "ch.example.net")
+		})
+	}
+}
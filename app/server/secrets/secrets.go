@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+)
+
+// Prefixes of the secret references, one per provider
+const (
+	prefixFile  = "secret://file/"
+	prefixEnv   = "env://"
+	prefixVault = "vault://"
+)
+
+// Resolver replaces secret references met in data source credentials with the values of the secrets
+type Resolver interface {
+	// ResolveCredentials returns the copy of the data source instance having the secret references resolved.
+	// The instance is returned as is if its credentials contain no references.
+	// The original instance is never modified, because it may be sent back to the client
+	// (e. g. as a part of split description).
+	ResolveCredentials(
+		ctx context.Context,
+		dsi *api_common.TGenericDataSourceInstance,
+	) (*api_common.TGenericDataSourceInstance, error)
+}
+
+// Provider fetches secrets from the particular kind of secret storage
+type Provider interface {
+	// Fetch returns the value of the secret; path is a part of the reference following the provider prefix
+	Fetch(ctx context.Context, path string) (string, error)
+}
+
+var _ Resolver = resolverNoop{}
+
+type resolverNoop struct{}
+
+func (resolverNoop) ResolveCredentials(
+	_ context.Context,
+	dsi *api_common.TGenericDataSourceInstance,
+) (*api_common.TGenericDataSourceInstance, error) {
+	return dsi, nil
+}
+
+// NewResolverNoop returns the resolver passing credentials as is
+func NewResolverNoop() Resolver {
+	return resolverNoop{}
+}
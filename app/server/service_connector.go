@@ -10,18 +10,21 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service "github.com/ydb-platform/fq-connector-go/api/service"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/cache"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
-	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
 )
 
 type serviceConnector struct {
@@ -29,12 +32,20 @@ type serviceConnector struct {
 	dataSourceCollection *DataSourceCollection
 	cfg                  *config.TServerConfig
 	grpcServer           *grpc.Server
+	healthReporter       *healthReporter
+	drainer              *drainer
 	listener             net.Listener
 	logger               *zap.Logger
 }
 
-func (*serviceConnector) ListTables(_ *api_service_protos.TListTablesRequest, _ api_service.Connector_ListTablesServer) error {
-	return nil
+// ListTables is not implemented by the data sources; the error is returned explicitly,
+// so that the client does not take the empty stream for the empty list of tables
+func (*serviceConnector) ListTables(request *api_service_protos.TListTablesRequest, stream api_service.Connector_ListTablesServer) error {
+	err := fmt.Errorf("list tables: %w", common.ErrMethodNotSupported)
+
+	return stream.Send(&api_service_protos.TListTablesResponse{
+		Error: common.NewAPIErrorFromStdError(err, request.GetDataSourceInstance().GetKind()),
+	})
 }
 
 func (s *serviceConnector) DescribeTable(
@@ -189,15 +200,41 @@ func (s *serviceConnector) Start() error {
 	return nil
 }
 
-func makeGRPCOptions(logger *zap.Logger, cfg *config.TServerConfig, registry *solomon.Registry) ([]grpc.ServerOption, error) {
+func makeGRPCOptions(
+	logger *zap.Logger,
+	cfg *config.TServerConfig,
+	registry metrics.Registry,
+	d *drainer,
+) ([]grpc.ServerOption, error) {
 	var (
 		opts      []grpc.ServerOption
 		tlsConfig *config.TServerTLSConfig
 	)
 
-	unaryInterceptors := []grpc.UnaryServerInterceptor{UnaryServerMetrics(logger, registry), utils.UnaryServerMetadata(logger)}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		UnaryServerTracing(),
+		UnaryServerMetrics(logger, registry),
+		utils.UnaryServerMetadata(logger),
+		UnaryServerDrain(d),
+	}
 
-	streamInterceptors := []grpc.StreamServerInterceptor{StreamServerMetrics(logger, registry), utils.StreamServerMetadata(logger)}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		StreamServerTracing(),
+		StreamServerMetrics(logger, registry),
+		utils.StreamServerMetadata(logger),
+		StreamServerDrain(d),
+	}
+
+	authCfg := cfg.GetConnectorServer().GetAuth()
+	if authCfg != nil {
+		authenticator, err := newClientAuthenticator(authCfg)
+		if err != nil {
+			return nil, fmt.Errorf("new client authenticator: %w", err)
+		}
+
+		unaryInterceptors = append(unaryInterceptors, UnaryServerAuth(authenticator))
+		streamInterceptors = append(streamInterceptors, StreamServerAuth(authenticator))
+	}
 
 	opts = append(opts, grpc.ChainUnaryInterceptor(unaryInterceptors...), grpc.ChainStreamInterceptor(streamInterceptors...))
 
@@ -211,6 +248,10 @@ func makeGRPCOptions(logger *zap.Logger, cfg *config.TServerConfig, registry *so
 	case cfg.GetTls() != nil:
 		tlsConfig = cfg.GetTls()
 	default:
+		if authCfg.GetMtls() != nil {
+			return nil, fmt.Errorf("mutual TLS requires server TLS config")
+		}
+
 		logger.Warn("server will use insecure connections")
 
 		return opts, nil
@@ -226,22 +267,61 @@ func makeGRPCOptions(logger *zap.Logger, cfg *config.TServerConfig, registry *so
 	}
 
 	// for security reasons we do not allow TLS < 1.2, see YQ-1877
-	creds := credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+	serverTLSConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	if mtlsCfg := authCfg.GetMtls(); mtlsCfg != nil {
+		logger.Info("server will require client certificates", zap.String("client_ca", mtlsCfg.ClientCa))
+
+		serverTLSConfig.ClientCAs, err = loadClientCAPool(mtlsCfg.ClientCa)
+		if err != nil {
+			return nil, fmt.Errorf("load client CA pool: %w", err)
+		}
+
+		// the certificate is required by the auth interceptors for all the methods except for health checks,
+		// because probes and load balancers usually have no client certificates
+		serverTLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	creds := credentials.NewTLS(serverTLSConfig)
 	opts = append(opts, grpc.Creds(creds))
 
 	return opts, nil
 }
 
 func (s *serviceConnector) Stop() {
-	s.grpcServer.GracefulStop()
+	// make probes and load balancers stop sending new requests
+	s.healthReporter.shutdown()
+
+	drainTimeout := common.MustDurationFromString(s.cfg.ConnectorServer.DrainTimeout)
+
+	s.logger.Info("draining GRPC server", zap.Duration("timeout", drainTimeout))
+
+	if inFlight := s.drainer.drain(drainTimeout); inFlight > 0 {
+		s.logger.Warn("drain timeout expired, interrupting in-flight requests", zap.Int("in_flight_requests", inFlight))
+		s.grpcServer.Stop()
+	} else {
+		s.grpcServer.GracefulStop()
+	}
+
 	common.LogCloserError(s.logger, s.dataSourceCollection, "closing data source collection")
 }
 
+// Reload applies the reloadable sections of the config to the new requests
+func (s *serviceConnector) Reload(cfg *config.TServerConfig) error {
+	if err := s.dataSourceCollection.Reload(cfg); err != nil {
+		return fmt.Errorf("reload data source collection: %w", err)
+	}
+
+	return nil
+}
+
 func newServiceConnector(
 	logger *zap.Logger,
 	cfg *config.TServerConfig,
-	registry *solomon.Registry,
+	registry metrics.Registry,
 	observationStorage observation.Storage,
+	queryRegistry *observation.QueryRegistry,
+	metadataCache *cache.MetadataCache,
 ) (utils.Service, error) {
 	queryLoggerFactory := common.NewQueryLoggerFactory(cfg.Logger)
 
@@ -264,7 +344,9 @@ func newServiceConnector(
 		return nil, fmt.Errorf("net listen: %w", err)
 	}
 
-	options, err := makeGRPCOptions(logger, cfg, registry)
+	d := newDrainer()
+
+	options, err := makeGRPCOptions(logger, cfg, registry, d)
 	if err != nil {
 		return nil, fmt.Errorf("make GRPC options: %w", err)
 	}
@@ -272,12 +354,19 @@ func newServiceConnector(
 	grpcServer := grpc.NewServer(options...)
 	reflection.Register(grpcServer)
 
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
 	dataSourceCollection, err := NewDataSourceCollection(
+		logger,
 		queryLoggerFactory,
 		memory.DefaultAllocator,
 		paging.NewReadLimiterFactory(cfg.ReadLimit),
 		conversion.NewCollection(cfg.Conversion),
 		observationStorage,
+		queryRegistry,
+		metadataCache,
+		registry,
 		cfg,
 	)
 	if err != nil {
@@ -288,11 +377,20 @@ func newServiceConnector(
 		dataSourceCollection: dataSourceCollection,
 		logger:               logger,
 		grpcServer:           grpcServer,
-		listener:             listener,
-		cfg:                  cfg,
+		healthReporter: newHealthReporter(
+			logger,
+			healthServer,
+			dataSourceCollection,
+			common.MustDurationFromString(cfg.ConnectorServer.ReadinessCheckInterval),
+		),
+		drainer:  d,
+		listener: listener,
+		cfg:      cfg,
 	}
 
 	api_service.RegisterConnectorServer(grpcServer, s)
 
+	s.healthReporter.start()
+
 	return s, nil
 }
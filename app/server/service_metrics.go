@@ -2,27 +2,39 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 
+	"github.com/prometheus/common/expfmt"
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
-	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+	"github.com/ydb-platform/fq-connector-go/library/go/httputil/headers"
 )
 
 type serviceMetrics struct {
 	httpServer *http.Server
 	logger     *zap.Logger
-	registry   *solomon.Registry
+	registry   metrics.Registry
 }
 
 func (s *serviceMetrics) Start() error {
 	s.logger.Info("starting HTTP server", zap.String("address", s.httpServer.Addr))
 
-	if err := s.httpServer.ListenAndServe(); err != nil {
+	var err error
+
+	if s.httpServer.TLSConfig != nil {
+		// certificates are already loaded into TLS config
+		err = s.httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = s.httpServer.ListenAndServe()
+	}
+
+	if err != nil {
 		return fmt.Errorf("http metrics server listen and serve: %w", err)
 	}
 
@@ -39,24 +51,123 @@ func (s *serviceMetrics) Stop() {
 	}
 }
 
+// metricsHandler chooses the exposition format according to the `Accept` header of the request
+type metricsHandler struct {
+	logger         *zap.Logger
+	registry       *utils.MetricsRegistry
+	solomonHandler http.Handler
+	// used if the client accepts any format
+	defaultFormat config.TMetricsServerConfig_EFormat
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.negotiateFormat(r.Header) == config.TMetricsServerConfig_PROMETHEUS {
+		h.servePrometheus(w, r)
+
+		return
+	}
+
+	// JSON or SPACK are chosen by the handler itself
+	h.solomonHandler.ServeHTTP(w, r)
+}
+
+func (h *metricsHandler) negotiateFormat(header http.Header) config.TMetricsServerConfig_EFormat {
+	for _, value := range header.Values(headers.AcceptKey) {
+		types, err := headers.ParseAccept(value)
+		if err != nil {
+			h.logger.Warn("can't parse accept header", zap.Error(err), zap.String("header", value))
+
+			continue
+		}
+
+		for _, acceptableType := range types {
+			switch acceptableType.Type {
+			case headers.TypeApplicationJSON, headers.TypeApplicationXSolomonSpack:
+				return config.TMetricsServerConfig_SOLOMON
+			case headers.TypeTextPlain, headers.ContentType(expfmt.OpenMetricsType):
+				return config.TMetricsServerConfig_PROMETHEUS
+			}
+		}
+	}
+
+	return h.defaultFormat
+}
+
+func (h *metricsHandler) servePrometheus(w http.ResponseWriter, r *http.Request) {
+	families, err := h.registry.Prometheus().Gather()
+	if err != nil {
+		h.logger.Error("gather prometheus metrics", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	// text format or OpenMetrics are chosen according to the `Accept` header
+	format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+
+	w.Header().Set(headers.ContentTypeKey, string(format))
+
+	encoder := expfmt.NewEncoder(w, format)
+
+	for _, family := range families {
+		if err := encoder.Encode(family); err != nil {
+			h.logger.Error("encode metric family", zap.Error(err))
+
+			return
+		}
+	}
+
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		if err := closer.Close(); err != nil {
+			h.logger.Error("close encoder", zap.Error(err))
+		}
+	}
+}
+
+func makeMetricsHandler(logger *zap.Logger, cfg *config.TMetricsServerConfig, registry *utils.MetricsRegistry) http.Handler {
+	defaultFormat := cfg.GetFormat()
+	if defaultFormat == config.TMetricsServerConfig_FORMAT_UNSPECIFIED {
+		defaultFormat = config.TMetricsServerConfig_SOLOMON
+	}
+
+	return &metricsHandler{
+		logger:         logger,
+		registry:       registry,
+		solomonHandler: NewHTTPPullerHandler(logger, registry.Solomon(), WithSpack()),
+		defaultFormat:  defaultFormat,
+	}
+}
+
 func newServiceMetrics(
 	logger *zap.Logger,
 	cfg *config.TMetricsServerConfig,
-	registry *solomon.Registry) utils.Service {
+	registry *utils.MetricsRegistry,
+) (utils.Service, error) {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", NewHTTPPullerHandler(logger, registry, WithSpack()))
+	mux.Handle("/metrics", makeMetricsHandler(logger, cfg, registry))
 
 	httpServer := &http.Server{
 		Addr:    common.EndpointToString(cfg.Endpoint),
 		Handler: mux,
 	}
 
-	// TODO: TLS
-	logger.Warn("server will use insecure connections")
+	if cfg.Tls != nil {
+		logger.Info("server will use TLS connections")
+
+		cert, err := tls.LoadX509KeyPair(cfg.Tls.Cert, cfg.Tls.Key)
+		if err != nil {
+			return nil, fmt.Errorf("load X509 key pair: %w", err)
+		}
+
+		// for security reasons we do not allow TLS < 1.2, see YQ-1877
+		httpServer.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	} else {
+		logger.Warn("server will use insecure connections")
+	}
 
 	return &serviceMetrics{
 		httpServer: httpServer,
 		logger:     logger,
 		registry:   registry,
-	}
+	}, nil
 }
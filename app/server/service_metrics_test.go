@@ -0,0 +1,118 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	testCases := []struct {
+		name        string
+		format      config.TMetricsServerConfig_EFormat
+		accept      string
+		contentType string
+		body        string
+		suffix      string
+	}{
+		{
+			name:        "solomon json",
+			format:      config.TMetricsServerConfig_SOLOMON,
+			contentType: "application/json",
+			body:        `"name":"admission.admitted_requests"`,
+		},
+		{
+			name:        "prometheus text",
+			format:      config.TMetricsServerConfig_PROMETHEUS,
+			contentType: "text/plain; version=0.0.4; charset=utf-8",
+			body:        "admission_admitted_requests 1",
+		},
+		{
+			name:        "openmetrics",
+			format:      config.TMetricsServerConfig_PROMETHEUS,
+			accept:      "application/openmetrics-text; version=1.0.0",
+			contentType: "application/openmetrics-text; version=1.0.0; charset=utf-8",
+			body:        "admission_admitted_requests 1.0\n",
+			suffix:      "# EOF\n",
+		},
+		{
+			name:        "default format",
+			contentType: "application/json",
+			body:        `"name":"admission.admitted_requests"`,
+		},
+		{
+			name:        "prometheus requested from solomon by default",
+			format:      config.TMetricsServerConfig_SOLOMON,
+			accept:      "text/plain;version=0.0.4;q=0.3,*/*;q=0.1",
+			contentType: "text/plain; version=0.0.4; charset=utf-8",
+			body:        `admission_rejected_requests{limit="user"} 2`,
+		},
+		{
+			name:        "solomon requested from prometheus by default",
+			format:      config.TMetricsServerConfig_PROMETHEUS,
+			accept:      "application/json",
+			contentType: "application/json",
+			body:        `"name":"admission.admitted_requests"`,
+		},
+		{
+			name:        "prometheus histogram",
+			format:      config.TMetricsServerConfig_PROMETHEUS,
+			contentType: "text/plain; version=0.0.4; charset=utf-8",
+			body:        "admission_sizes_bucket{le=\"10\"} 2\nadmission_sizes_bucket{le=\"100\"} 3\nadmission_sizes_bucket{le=\"+Inf\"} 4",
+		},
+		{
+			name:        "prometheus histogram sum",
+			format:      config.TMetricsServerConfig_PROMETHEUS,
+			contentType: "text/plain; version=0.0.4; charset=utf-8",
+			body:        "admission_sizes_sum 556\nadmission_sizes_count 4",
+		},
+		{
+			name:        "solomon rated counter",
+			format:      config.TMetricsServerConfig_SOLOMON,
+			contentType: "application/json",
+			body:        `{"type":"RATE","labels":{"name":"admission.throttled_requests"},"value":3}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			registry := utils.NewMetricsRegistry()
+			admission := registry.WithPrefix("admission")
+			admission.Counter("admitted_requests").Inc()
+			admission.CounterVec("rejected_requests", []string{"limit"}).With(map[string]string{"limit": "user"}).Add(2)
+
+			throttled := admission.Counter("throttled_requests")
+			utils.Rated(throttled)
+			throttled.Add(3)
+
+			sizes := admission.Histogram("sizes", metrics.NewBuckets(10, 100))
+			for _, value := range []float64{1, 5, 50, 500} {
+				sizes.RecordValue(value)
+			}
+
+			handler := makeMetricsHandler(zap.NewNop(), &config.TMetricsServerConfig{Format: tc.format}, registry)
+
+			request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tc.accept != "" {
+				request.Header.Set("Accept", tc.accept)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, tc.contentType, recorder.Header().Get("Content-Type"))
+			require.Contains(t, recorder.Body.String(), tc.body)
+			require.True(t, strings.HasSuffix(recorder.Body.String(), tc.suffix))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package splitcache
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+)
+
+// errSplitTooLarge is returned when the pages of the split exceed the size of the whole cache
+var errSplitTooLarge = fmt.Errorf("split is too large to be cached")
+
+// Pages are stored one after another, every page is prefixed with the size of the serialized message.
+
+var _ paging.PageReader = (*Reader)(nil)
+
+// Reader provides the cached pages of the split
+type Reader struct {
+	file   *os.File
+	reader *bufio.Reader
+}
+
+func (r *Reader) ReadPage() (*api_service_protos.TReadSplitsResponse, error) {
+	size, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		// io.EOF is passed as is to mark the end of pages
+		return nil, err
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.reader, data); err != nil {
+		return nil, fmt.Errorf("read page: %w", err)
+	}
+
+	response := &api_service_protos.TReadSplitsResponse{}
+	if err := proto.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("unmarshal page: %w", err)
+	}
+
+	return response, nil
+}
+
+func (r *Reader) Close() error { return r.file.Close() }
+
+// Writer saves the pages of the split into a temporary file.
+// The pages become available to the readers only after the commit.
+type Writer struct {
+	store    *storeDisk
+	key      Key
+	ttl      time.Duration
+	file     *os.File
+	writer   *bufio.Writer
+	size     uint64
+	maxBytes uint64
+	buf      []byte
+}
+
+func (w *Writer) WritePage(response *api_service_protos.TReadSplitsResponse) error {
+	data, err := proto.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("marshal page: %w", err)
+	}
+
+	w.buf = binary.AppendUvarint(w.buf[:0], uint64(len(data)))
+	w.size += uint64(len(w.buf) + len(data))
+
+	if w.size > w.maxBytes {
+		return errSplitTooLarge
+	}
+
+	if _, err := w.writer.Write(w.buf); err != nil {
+		return fmt.Errorf("write page size: %w", err)
+	}
+
+	if _, err := w.writer.Write(data); err != nil {
+		return fmt.Errorf("write page: %w", err)
+	}
+
+	return nil
+}
+
+// Commit makes the pages available to the readers
+func (w *Writer) Commit() error {
+	if err := w.writer.Flush(); err != nil {
+		w.Abort()
+
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		w.remove()
+
+		return fmt.Errorf("close: %w", err)
+	}
+
+	if err := w.store.commit(w); err != nil {
+		w.remove()
+
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
+
+// Abort drops the pages written so far
+func (w *Writer) Abort() {
+	_ = w.file.Close()
+
+	w.remove()
+}
+
+func (w *Writer) remove() {
+	if err := os.Remove(w.file.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		w.store.logger.Error("remove temporary file", zap.String("path", w.file.Name()), zap.Error(err))
+	}
+}
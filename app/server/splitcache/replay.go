@@ -0,0 +1,64 @@
+package splitcache
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/observation"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ datasource.DataSource[any] = (*dataSourceReplay[any])(nil)
+
+// dataSourceReplay substitutes the real data source when the split is cached:
+// it feeds the cached pages to the streamer without touching the data source.
+type dataSourceReplay[T paging.Acceptor] struct {
+	sinkFactory *paging.ReplaySinkFactory[T]
+}
+
+func (*dataSourceReplay[T]) DescribeTable(
+	context.Context,
+	*zap.Logger,
+	*api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TDescribeTableResponse, error) {
+	return nil, fmt.Errorf("cached splits can only be read: %w", common.ErrMethodNotSupported)
+}
+
+func (*dataSourceReplay[T]) ListSplits(
+	context.Context,
+	*zap.Logger,
+	*api_service_protos.TListSplitsRequest,
+	*api_service_protos.TSelect,
+	chan<- *datasource.ListSplitResult,
+) error {
+	return fmt.Errorf("cached splits can only be read: %w", common.ErrMethodNotSupported)
+}
+
+func (ds *dataSourceReplay[T]) ReadSplit(
+	ctx context.Context,
+	_ *zap.Logger,
+	_ observation.IncomingQueryID,
+	_ *api_service_protos.TReadSplitsRequest,
+	_ *api_service_protos.TSplit,
+	_ paging.SinkFactory[T],
+) error {
+	return ds.sinkFactory.Replay(ctx)
+}
+
+// NewReplay makes the pair of the data source and the sink factory replaying the cached pages of the split
+func NewReplay[T paging.Acceptor](
+	logger *zap.Logger,
+	cfg *config.TPagingConfig,
+	metrics *paging.Metrics,
+	reader *Reader,
+) (datasource.DataSource[T], paging.SinkFactory[T]) {
+	sinkFactory := paging.NewReplaySinkFactory[T](logger, cfg, metrics, reader)
+
+	return &dataSourceReplay[T]{sinkFactory: sinkFactory}, sinkFactory
+}
@@ -0,0 +1,88 @@
+package splitcache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// Key identifies the pages of the split
+type Key [sha256.Size]byte
+
+func (k Key) String() string { return fmt.Sprintf("%x", k[:]) }
+
+// MakeKey builds the key from everything affecting the contents of the pages.
+// The split contains the data source instance including credentials,
+// so that the clients never share the entries unless they use the same credentials.
+func MakeKey(
+	split *api_service_protos.TSplit,
+	request *api_service_protos.TReadSplitsRequest,
+	compression config.TPagingConfig_EArrowCompression,
+	readLimit *config.TServerReadLimit,
+) (Key, error) {
+	// the same split may have different ids in different queries
+	split = proto.Clone(split).(*api_service_protos.TSplit)
+	split.Id = 0
+
+	hash := sha256.New()
+	marshaler := proto.MarshalOptions{Deterministic: true}
+
+	var buf [8]byte
+
+	for _, msg := range []proto.Message{split, readLimit} {
+		data, err := marshaler.Marshal(msg)
+		if err != nil {
+			return Key{}, fmt.Errorf("marshal %T: %w", msg, err)
+		}
+
+		// length prefix prevents the collisions of the adjacent messages
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(data)))
+		hash.Write(buf[:])
+		hash.Write(data)
+	}
+
+	for _, value := range []int32{int32(request.GetFiltering()), int32(request.GetFormat()), int32(compression)} {
+		binary.LittleEndian.PutUint32(buf[:4], uint32(value))
+		hash.Write(buf[:4])
+	}
+
+	var key Key
+
+	copy(key[:], hash.Sum(nil))
+
+	return key, nil
+}
+
+// Store keeps the pages of the splits on the local disk
+type Store interface {
+	// Open returns the reader of the cached pages or nil if the split is not cached
+	Open(key Key) (*Reader, error)
+	// Create starts saving the pages of the split. Returns nil if the splits of this data source kind are not cached.
+	Create(key Key, kind api_common.EGenericDataSourceKind) (*Writer, error)
+}
+
+var _ Store = storeNoop{}
+
+type storeNoop struct{}
+
+func (storeNoop) Open(Key) (*Reader, error) { return nil, nil }
+
+func (storeNoop) Create(Key, api_common.EGenericDataSourceKind) (*Writer, error) { return nil, nil }
+
+// NewStore makes the split cache; if config is empty, nothing is cached
+func NewStore(logger *zap.Logger, cfg *config.TSplitCacheConfig, registry metrics.Registry) (Store, error) {
+	if cfg == nil {
+		return storeNoop{}, nil
+	}
+
+	return newStoreDisk(logger, cfg, registry, time.Now)
+}
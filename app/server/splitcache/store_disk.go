@@ -0,0 +1,179 @@
+package splitcache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils/lru"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+const (
+	fileSuffixPages     = ".pages"
+	fileSuffixTemporary = ".tmp"
+)
+
+type storeMetrics struct {
+	bytes metrics.IntGauge
+}
+
+var _ Store = (*storeDisk)(nil)
+
+// storeDisk keeps the index of the cached splits in memory and their pages in the files named after the keys.
+// The size of each split in the index is the size of its pages, the files are deleted once the splits leave the index.
+type storeDisk struct {
+	dir      string
+	maxBytes uint64
+	ttls     map[api_common.EGenericDataSourceKind]time.Duration
+	metrics  *storeMetrics
+	logger   *zap.Logger
+
+	mutex sync.Mutex
+	index *lru.Index[Key, struct{}]
+}
+
+func (s *storeDisk) Open(key Key) (*Reader, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	defer s.updateGauges()
+
+	if _, exists := s.index.Get(key); !exists {
+		return nil, nil
+	}
+
+	// the file is opened under the lock, so that it can't be evicted in between;
+	// once it's opened, the eviction doesn't affect the reader
+	file, err := os.Open(s.path(key, fileSuffixPages))
+	if err != nil {
+		s.index.Remove(key)
+
+		return nil, fmt.Errorf("open pages: %w", err)
+	}
+
+	return &Reader{file: file, reader: bufio.NewReader(file)}, nil
+}
+
+func (s *storeDisk) Create(key Key, kind api_common.EGenericDataSourceKind) (*Writer, error) {
+	ttl, exists := s.ttls[kind]
+	if !exists {
+		return nil, nil
+	}
+
+	file, err := os.CreateTemp(s.dir, "*"+fileSuffixTemporary)
+	if err != nil {
+		return nil, fmt.Errorf("create temporary file: %w", err)
+	}
+
+	return &Writer{
+		store:    s,
+		key:      key,
+		ttl:      ttl,
+		file:     file,
+		writer:   bufio.NewWriter(file),
+		maxBytes: s.maxBytes,
+	}, nil
+}
+
+// commit makes the pages written into the temporary file available to the readers
+func (s *storeDisk) commit(w *Writer) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the split could be read by the concurrent request, its file is replaced
+	if err := os.Rename(w.file.Name(), s.path(w.key, fileSuffixPages)); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	s.index.Put(w.key, struct{}{}, w.size, w.ttl)
+	s.updateGauges()
+
+	return nil
+}
+
+// removePages is called when the split leaves the index
+func (s *storeDisk) removePages(key Key, _ struct{}) {
+	if err := os.Remove(s.path(key, fileSuffixPages)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.logger.Error("remove cached pages", zap.Stringer("key", key), zap.Error(err))
+	}
+}
+
+func (s *storeDisk) updateGauges() {
+	s.metrics.bytes.Set(int64(s.index.Size()))
+}
+
+func (s *storeDisk) path(key Key, suffix string) string {
+	return filepath.Join(s.dir, key.String()+suffix)
+}
+
+// cleanDir drops the files left by the previous run of the server
+func cleanDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !(strings.HasSuffix(name, fileSuffixPages) || strings.HasSuffix(name, fileSuffixTemporary)) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("remove '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func newStoreDisk(
+	logger *zap.Logger,
+	cfg *config.TSplitCacheConfig,
+	registry metrics.Registry,
+	now func() time.Time,
+) (*storeDisk, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("make dir: %w", err)
+	}
+
+	if err := cleanDir(cfg.Dir); err != nil {
+		return nil, fmt.Errorf("clean dir: %w", err)
+	}
+
+	ttls := make(map[api_common.EGenericDataSourceKind]time.Duration, len(cfg.DataSources))
+
+	for _, ds := range cfg.DataSources {
+		ttl, err := common.DurationFromString(ds.Ttl)
+		if err != nil {
+			return nil, fmt.Errorf("parse TTL of %v: %w", ds.Kind, err)
+		}
+
+		ttls[ds.Kind] = ttl
+	}
+
+	s := &storeDisk{
+		dir:      cfg.Dir,
+		maxBytes: cfg.MaxBytes,
+		ttls:     ttls,
+		metrics: &storeMetrics{
+			bytes: registry.IntGauge("bytes"),
+		},
+		logger: logger,
+	}
+
+	s.index = lru.NewIndex[Key, struct{}](cfg.MaxBytes, now, s.removePages, registry)
+
+	return s, nil
+}
@@ -0,0 +1,264 @@
+package splitcache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	app_utils "github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+// testPages is the split of three pages, each of them takes 100 bytes in the store
+var testPages = []*api_service_protos.TReadSplitsResponse{
+	{
+		Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{ArrowIpcStreaming: make([]byte, 100)},
+		Stats:   &api_service_protos.TReadSplitsResponse_TStats{Rows: 10, Bytes: 100},
+	},
+	{
+		Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{ArrowIpcStreaming: make([]byte, 100)},
+		Stats:   &api_service_protos.TReadSplitsResponse_TStats{Rows: 10, Bytes: 100},
+	},
+	{
+		Payload: &api_service_protos.TReadSplitsResponse_ArrowIpcStreaming{ArrowIpcStreaming: make([]byte, 100)},
+		Stats:   &api_service_protos.TReadSplitsResponse_TStats{Rows: 10, Bytes: 100},
+		Error:   common.NewSuccess(),
+	},
+}
+
+func writeTestPages(t *testing.T, store Store, key Key) {
+	writer, err := store.Create(key, api_common.EGenericDataSourceKind_CLICKHOUSE)
+	require.NoError(t, err)
+	require.NotNil(t, writer)
+
+	for _, page := range testPages {
+		require.NoError(t, writer.WritePage(page))
+	}
+
+	require.NoError(t, writer.Commit())
+}
+
+func readTestPages(t *testing.T, reader *Reader) []*api_service_protos.TReadSplitsResponse {
+	defer func() { require.NoError(t, reader.Close()) }()
+
+	var result []*api_service_protos.TReadSplitsResponse
+
+	for {
+		page, err := reader.ReadPage()
+		if errors.Is(err, io.EOF) {
+			return result
+		}
+
+		require.NoError(t, err)
+
+		result = append(result, page)
+	}
+}
+
+func TestMakeKey(t *testing.T) {
+	split := &api_service_protos.TSplit{
+		Select: &api_service_protos.TSelect{From: &api_service_protos.TSelect_TFrom{Table: "table"}},
+		Id:     1,
+	}
+	request := &api_service_protos.TReadSplitsRequest{Format: api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING}
+
+	key, err := MakeKey(split, request, config.TPagingConfig_NONE, nil)
+	require.NoError(t, err)
+
+	// the split id doesn't matter
+	other, err := MakeKey(&api_service_protos.TSplit{Select: split.Select, Id: 2}, request, config.TPagingConfig_NONE, nil)
+	require.NoError(t, err)
+	require.Equal(t, key, other)
+
+	other, err = MakeKey(split, request, config.TPagingConfig_ZSTD, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	other, err = MakeKey(split, request, config.TPagingConfig_NONE, &config.TServerReadLimit{Rows: 10})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+}
+
+func TestStoreDisk(t *testing.T) {
+	clock := app_utils.NewManualClock()
+	cfg := &config.TSplitCacheConfig{
+		Dir:      t.TempDir(),
+		MaxBytes: 1 << 20,
+		DataSources: []*config.TSplitCacheConfig_TDataSource{
+			{Kind: api_common.EGenericDataSourceKind_CLICKHOUSE, Ttl: "1m"},
+		},
+	}
+
+	t.Run("write and read", func(t *testing.T) {
+		store, err := newStoreDisk(zap.NewNop(), cfg, nop.Registry{}, clock.Now)
+		require.NoError(t, err)
+		key := Key{1}
+
+		reader, err := store.Open(key)
+		require.NoError(t, err)
+		require.Nil(t, reader)
+
+		writeTestPages(t, store, key)
+
+		reader, err = store.Open(key)
+		require.NoError(t, err)
+		require.NotNil(t, reader)
+
+		actual := readTestPages(t, reader)
+		require.Len(t, actual, len(testPages))
+
+		for i := range testPages {
+			require.Equal(t, testPages[i].String(), actual[i].String())
+		}
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		// there's room for two splits only
+		store, err := newStoreDisk(
+			zap.NewNop(),
+			&config.TSplitCacheConfig{Dir: cfg.Dir, MaxBytes: 700, DataSources: cfg.DataSources},
+			nop.Registry{},
+			clock.Now,
+		)
+		require.NoError(t, err)
+		keys := []Key{{1}, {2}, {3}}
+
+		writeTestPages(t, store, keys[0])
+		writeTestPages(t, store, keys[1])
+
+		// make the first split the most recently used one
+		reader, err := store.Open(keys[0])
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+
+		writeTestPages(t, store, keys[2])
+
+		reader, err = store.Open(keys[1])
+		require.NoError(t, err)
+		require.Nil(t, reader)
+
+		_, err = os.Stat(store.path(keys[1], fileSuffixPages))
+		require.True(t, errors.Is(err, os.ErrNotExist))
+
+		for _, key := range []Key{keys[0], keys[2]} {
+			reader, err = store.Open(key)
+			require.NoError(t, err)
+			require.Len(t, readTestPages(t, reader), len(testPages))
+		}
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		store, err := newStoreDisk(zap.NewNop(), cfg, nop.Registry{}, clock.Now)
+		require.NoError(t, err)
+		key := Key{1}
+
+		writeTestPages(t, store, key)
+
+		clock.Advance(time.Minute)
+
+		reader, err := store.Open(key)
+		require.NoError(t, err)
+		require.Nil(t, reader)
+		require.Zero(t, store.index.Size())
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		store, err := newStoreDisk(
+			zap.NewNop(),
+			&config.TSplitCacheConfig{Dir: cfg.Dir, MaxBytes: 200, DataSources: cfg.DataSources},
+			nop.Registry{},
+			clock.Now,
+		)
+		require.NoError(t, err)
+
+		writer, err := store.Create(Key{1}, api_common.EGenericDataSourceKind_CLICKHOUSE)
+		require.NoError(t, err)
+		require.NoError(t, writer.WritePage(testPages[0]))
+		require.ErrorIs(t, writer.WritePage(testPages[1]), errSplitTooLarge)
+
+		writer.Abort()
+
+		entries, err := os.ReadDir(store.dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("data source kind not cached", func(t *testing.T) {
+		store, err := newStoreDisk(zap.NewNop(), cfg, nop.Registry{}, clock.Now)
+		require.NoError(t, err)
+
+		writer, err := store.Create(Key{1}, api_common.EGenericDataSourceKind_POSTGRESQL)
+		require.NoError(t, err)
+		require.Nil(t, writer)
+	})
+}
+
+func TestNewStoreCleansDir(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"outdated" + fileSuffixPages, "unfinished" + fileSuffixTemporary, "foreign.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	_, err := NewStore(zap.NewNop(), &config.TSplitCacheConfig{Dir: dir, MaxBytes: 1024}, nop.Registry{})
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "foreign.txt", entries[0].Name())
+}
+
+func TestReplay(t *testing.T) {
+	store, err := newStoreDisk(zap.NewNop(), &config.TSplitCacheConfig{
+		Dir:      t.TempDir(),
+		MaxBytes: 1 << 20,
+		DataSources: []*config.TSplitCacheConfig_TDataSource{
+			{Kind: api_common.EGenericDataSourceKind_CLICKHOUSE, Ttl: "1m"},
+		},
+	}, nop.Registry{}, time.Now)
+	require.NoError(t, err)
+
+	key := Key{1}
+
+	writeTestPages(t, store, key)
+
+	reader, err := store.Open(key)
+	require.NoError(t, err)
+
+	defer reader.Close()
+
+	cfg := &config.TPagingConfig{PrefetchQueueCapacity: 1}
+	dataSource, sinkFactory := NewReplay[any](zap.NewNop(), cfg, paging.NewMetrics(nop.Registry{}), reader)
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		errChan <- dataSource.ReadSplit(context.Background(), zap.NewNop(), 0, nil, nil, sinkFactory)
+	}()
+
+	var results []*paging.ReadResult[any]
+
+	for result := range sinkFactory.ResultQueue() {
+		results = append(results, result)
+	}
+
+	require.NoError(t, <-errChan)
+	require.Len(t, results, len(testPages))
+	require.False(t, results[0].IsTerminalMessage)
+	require.True(t, results[len(results)-1].IsTerminalMessage)
+	require.Equal(t, uint64(30), sinkFactory.FinalStats().Rows)
+	require.Equal(t, uint64(300), sinkFactory.FinalStats().Bytes)
+}
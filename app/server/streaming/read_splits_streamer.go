@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	api_service "github.com/ydb-platform/fq-connector-go/api/service"
@@ -12,6 +15,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -55,22 +59,34 @@ func (s *ReadSplitsStreamer[T]) writeDataToStream() error {
 			}
 		case <-s.stream.Context().Done():
 			// handle request termination
-			return s.stream.Context().Err()
+			return context.Cause(s.stream.Context())
 		}
 	}
 }
 
 func (s *ReadSplitsStreamer[T]) sendResultToStream(result *paging.ReadResult[T]) error {
-	// buffer must be explicitly marked as unused,
-	// otherwise memory will leak
-	defer result.ColumnarBuffer.Release()
+	// the page no longer occupies the memory budget once it's passed to GRPC
+	defer result.Release()
 
-	resp, err := result.ColumnarBuffer.ToResponse()
-	if err != nil {
-		return fmt.Errorf("buffer to response: %w", err)
-	}
+	_, span := utils.StartSpan(
+		s.stream.Context(),
+		"ReadSplitsStreamer.sendResultToStream",
+		trace.WithAttributes(
+			attribute.Int64("rows", int64(result.Stats.GetRows())),
+			attribute.Int64("bytes", int64(result.Stats.GetBytes())),
+		),
+	)
+
+	err := s.doSendResultToStream(result)
+
+	utils.EndSpan(span, err)
+
+	return err
+}
 
-	resp.Stats = result.Stats
+func (s *ReadSplitsStreamer[T]) doSendResultToStream(result *paging.ReadResult[T]) error {
+	// page has already been serialized by the sink
+	resp := result.Response
 
 	// if stream is finished, assign successful operation code
 	if result.IsTerminalMessage {
@@ -79,10 +95,17 @@ func (s *ReadSplitsStreamer[T]) sendResultToStream(result *paging.ReadResult[T])
 
 	dumpReadSplitsResponse(result.Logger, resp)
 
+	startTime := time.Now()
+
 	if err := s.stream.Send(resp); err != nil {
 		return fmt.Errorf("stream send: %w", err)
 	}
 
+	// client throughput is used to choose the size of the next pages
+	if !result.IsTerminalMessage {
+		s.sinkFactory.PageSizer().ObserveSend(time.Since(startTime))
+	}
+
 	return nil
 }
 
@@ -111,7 +134,10 @@ func (s *ReadSplitsStreamer[T]) Run() error {
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 
-	defer wg.Wait()
+	defer func() {
+		wg.Wait()
+		s.releasePendingResults()
+	}()
 
 	// Launch reading from the data source.
 	// Subscriber goroutine controls publisher goroutine lifetime.
@@ -132,6 +158,23 @@ func (s *ReadSplitsStreamer[T]) Run() error {
 	return nil
 }
 
+// releasePendingResults drops the pages that haven't been sent because of an error,
+// so that they don't occupy the memory budget. It must be called when the publisher is terminated.
+func (s *ReadSplitsStreamer[T]) releasePendingResults() {
+	for {
+		select {
+		case result, ok := <-s.sinkFactory.ResultQueue():
+			if !ok {
+				return
+			}
+
+			result.Release()
+		default:
+			return
+		}
+	}
+}
+
 func NewReadSplitsStreamer[T paging.Acceptor](
 	logger *zap.Logger,
 	queryID observation.IncomingQueryID,
@@ -29,6 +29,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
 )
 
 var _ api_service.Connector_ReadSplitsServer = (*streamMock)(nil)
@@ -116,14 +117,15 @@ type testCaseStreaming struct {
 	src                 [][]any
 	rowsPerPage         int
 	bufferQueueCapacity int
+	compression         config.TPagingConfig_EArrowCompression
 	scanErr             error
 	sendErr             error
 }
 
 func (tc testCaseStreaming) name() string {
 	return fmt.Sprintf(
-		"totalRows_%d_rowsPerBlock_%d_bufferQueueCapacity_%d_scanErr_%v_sendErr_%v",
-		len(tc.src), tc.rowsPerPage, tc.bufferQueueCapacity, tc.scanErr != nil, tc.sendErr != nil)
+		"totalRows_%d_rowsPerBlock_%d_bufferQueueCapacity_%d_compression_%v_scanErr_%v_sendErr_%v",
+		len(tc.src), tc.rowsPerPage, tc.bufferQueueCapacity, tc.compression, tc.scanErr != nil, tc.sendErr != nil)
 }
 
 func (tc testCaseStreaming) messageParams() (sentMessages, rowsInLastMessage int) {
@@ -253,20 +255,26 @@ func (tc testCaseStreaming) execute(t *testing.T) {
 	observationStorage, err := observation.NewStorage(logger, nil)
 	require.NoError(t, err)
 
-	dataSource := rdbms.NewDataSource(logger, dataSourcePreset, converterCollection, observationStorage)
+	dataSource := rdbms.NewDataSource(logger, dataSourcePreset, converterCollection, observationStorage, observation.NewQueryRegistry())
+
+	pagingCfg := &config.TPagingConfig{RowsPerPage: uint64(tc.rowsPerPage), MemoryBudgetBytes: 1 << 20}
+	memoryAccountant := paging.NewMemoryAccountant(pagingCfg, memory.NewGoAllocator(), nop.Registry{})
 
 	columnarBufferFactory, err := paging.NewColumnarBufferFactory[any](
 		logger,
-		memory.NewGoAllocator(),
+		memoryAccountant.Allocator(),
 		api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING,
-		split.Select.What)
+		tc.compression,
+		split.Select.What,
+		nil)
 	require.NoError(t, err)
 
-	pagingCfg := &config.TPagingConfig{RowsPerPage: uint64(tc.rowsPerPage)}
 	readLimiterFactory := paging.NewReadLimiterFactory(nil)
 	readLimiter := readLimiterFactory.MakeReadLimiter(logger)
 
-	sinkFactory := paging.NewSinkFactory(ctx, logger, pagingCfg, columnarBufferFactory, readLimiter)
+	sinkFactory := paging.NewSinkFactory(
+		ctx, logger, pagingCfg, columnarBufferFactory, readLimiter, paging.ThrottlerNoop{},
+		paging.NewMetrics(nop.Registry{}), memoryAccountant)
 
 	request := &api_service_protos.TReadSplitsRequest{}
 	streamer := NewReadSplitsStreamer(logger, observation.IncomingQueryID(0), stream, request, split, sinkFactory, dataSource)
@@ -310,18 +318,27 @@ func TestStreaming(t *testing.T) {
 		10,
 	}
 
+	compressionValues := []config.TPagingConfig_EArrowCompression{
+		config.TPagingConfig_NONE,
+		config.TPagingConfig_LZ4_FRAME,
+		config.TPagingConfig_ZSTD,
+	}
+
 	var testCases []testCaseStreaming
 
 	for _, src := range srcValues {
 		for _, rowsPerBlock := range rowsPerBlockValues {
 			for _, bufferQueueCapacity := range bufferQueueCapacityValues {
-				tc := testCaseStreaming{
-					src:                 src,
-					rowsPerPage:         rowsPerBlock,
-					bufferQueueCapacity: bufferQueueCapacity,
+				for _, compression := range compressionValues {
+					tc := testCaseStreaming{
+						src:                 src,
+						rowsPerPage:         rowsPerBlock,
+						bufferQueueCapacity: bufferQueueCapacity,
+						compression:         compression,
+					}
+
+					testCases = append(testCases, tc)
 				}
-
-				testCases = append(testCases, tc)
 			}
 		}
 	}
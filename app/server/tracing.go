@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+// tracing owns the global tracer provider and the resources of the span exporter
+type tracing struct {
+	provider *sdktrace.TracerProvider
+	file     *os.File // nil unless spans are written to file
+	logger   *zap.Logger
+}
+
+// shutdown flushes the buffered spans
+func (t *tracing) shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := t.provider.Shutdown(ctx); err != nil {
+		t.logger.Error("shutdown tracer provider", zap.Error(err))
+	}
+
+	if t.file != nil {
+		if err := t.file.Close(); err != nil {
+			t.logger.Error("close tracing file", zap.Error(err))
+		}
+	}
+}
+
+func newSpanExporter(cfg *config.TTracingConfig) (sdktrace.SpanExporter, *os.File, error) {
+	switch exporter := cfg.Exporter.(type) {
+	case *config.TTracingConfig_Stdout:
+		var opts []stdouttrace.Option
+		if exporter.Stdout.PrettyPrint {
+			opts = append(opts, stdouttrace.WithPrettyPrint())
+		}
+
+		spanExporter, err := stdouttrace.New(opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new stdout exporter: %w", err)
+		}
+
+		return spanExporter, nil, nil
+	case *config.TTracingConfig_File:
+		file, err := os.OpenFile(exporter.File.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open file '%s': %w", exporter.File.Path, err)
+		}
+
+		return newOTLPFileExporter(file), file, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported exporter type: %T", exporter)
+	}
+}
+
+// setupTracing registers the global tracer provider and the W3C trace context propagator.
+// Tracing remains a no-op if the config is empty.
+func setupTracing(logger *zap.Logger, cfg *config.TTracingConfig) (*tracing, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	spanExporter, file, err := newSpanExporter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("new span exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	logger.Info("tracing is enabled", zap.Float64("sampling_ratio", cfg.SamplingRatio))
+
+	return &tracing{
+		provider: provider,
+		file:     file,
+		logger:   logger,
+	}, nil
+}
@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	otel_codes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// The types below follow the JSON encoding of the OTLP `ExportTraceServiceRequest` message:
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+// The 64-bit integers are encoded as strings, trace and span IDs are hex-encoded.
+
+type otlpTracesData struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource      `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+	SchemaURL  string            `json:"schemaUrl,omitempty"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope     otlpScope  `json:"scope"`
+	Spans     []otlpSpan `json:"spans"`
+	SchemaURL string     `json:"schemaUrl,omitempty"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID                string         `json:"traceId"`
+	SpanID                 string         `json:"spanId"`
+	TraceState             string         `json:"traceState,omitempty"`
+	ParentSpanID           string         `json:"parentSpanId,omitempty"`
+	Name                   string         `json:"name"`
+	Kind                   int            `json:"kind"`
+	StartTimeUnixNano      string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano        string         `json:"endTimeUnixNano"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+	Events                 []otlpEvent    `json:"events,omitempty"`
+	DroppedEventsCount     int            `json:"droppedEventsCount,omitempty"`
+	Links                  []otlpLink     `json:"links,omitempty"`
+	DroppedLinksCount      int            `json:"droppedLinksCount,omitempty"`
+	Status                 otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano           string         `json:"timeUnixNano"`
+	Name                   string         `json:"name"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID                string         `json:"traceId"`
+	SpanID                 string         `json:"spanId"`
+	TraceState             string         `json:"traceState,omitempty"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+}
+
+type otlpStatus struct {
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+// OTLP status codes differ from the ones of OpenTelemetry API
+const (
+	otlpStatusCodeOK    = 1
+	otlpStatusCodeError = 2
+)
+
+var _ sdktrace.SpanExporter = (*otlpFileExporter)(nil)
+
+// otlpFileExporter writes spans in OTLP JSON format, one `ExportTraceServiceRequest` per line,
+// the same way as the `file` exporter of OpenTelemetry Collector does
+type otlpFileExporter struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+func (e *otlpFileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	data := makeOTLPTracesData(spans)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if err := e.encoder.Encode(data); err != nil {
+		return fmt.Errorf("encode spans: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown does nothing, the writer is owned by the caller
+func (*otlpFileExporter) Shutdown(context.Context) error { return nil }
+
+func newOTLPFileExporter(w io.Writer) *otlpFileExporter {
+	return &otlpFileExporter{encoder: json.NewEncoder(w)}
+}
+
+// makeOTLPTracesData groups the spans by resource and instrumentation scope
+func makeOTLPTracesData(spans []sdktrace.ReadOnlySpan) *otlpTracesData {
+	var (
+		data           = &otlpTracesData{}
+		resourceSpans  = make(map[attribute.Distinct]*otlpResourceSpans)
+		scopeSpansKeys = make(map[attribute.Distinct]map[instrumentation.Scope]*otlpScopeSpans)
+	)
+
+	for _, span := range spans {
+		res := span.Resource()
+		resourceKey := res.Equivalent()
+
+		rs, exists := resourceSpans[resourceKey]
+		if !exists {
+			rs = &otlpResourceSpans{
+				Resource:  otlpResource{Attributes: makeOTLPAttributes(res.Attributes())},
+				SchemaURL: res.SchemaURL(),
+			}
+			resourceSpans[resourceKey] = rs
+			scopeSpansKeys[resourceKey] = make(map[instrumentation.Scope]*otlpScopeSpans)
+			data.ResourceSpans = append(data.ResourceSpans, rs)
+		}
+
+		scope := span.InstrumentationScope()
+
+		ss, exists := scopeSpansKeys[resourceKey][scope]
+		if !exists {
+			ss = &otlpScopeSpans{
+				Scope:     otlpScope{Name: scope.Name, Version: scope.Version},
+				SchemaURL: scope.SchemaURL,
+			}
+			scopeSpansKeys[resourceKey][scope] = ss
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+
+		ss.Spans = append(ss.Spans, makeOTLPSpan(span))
+	}
+
+	return data
+}
+
+func makeOTLPSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	sc := span.SpanContext()
+
+	result := otlpSpan{
+		TraceID:                sc.TraceID().String(),
+		SpanID:                 sc.SpanID().String(),
+		TraceState:             sc.TraceState().String(),
+		Name:                   span.Name(),
+		Kind:                   int(span.SpanKind()), // the values of OTLP and API enums are the same
+		StartTimeUnixNano:      formatUnixNano(span.StartTime().UnixNano()),
+		EndTimeUnixNano:        formatUnixNano(span.EndTime().UnixNano()),
+		Attributes:             makeOTLPAttributes(span.Attributes()),
+		DroppedAttributesCount: span.DroppedAttributes(),
+		DroppedEventsCount:     span.DroppedEvents(),
+		DroppedLinksCount:      span.DroppedLinks(),
+		Status:                 makeOTLPStatus(span.Status()),
+	}
+
+	if span.Parent().HasSpanID() {
+		result.ParentSpanID = span.Parent().SpanID().String()
+	}
+
+	for _, event := range span.Events() {
+		result.Events = append(result.Events, otlpEvent{
+			TimeUnixNano:           formatUnixNano(event.Time.UnixNano()),
+			Name:                   event.Name,
+			Attributes:             makeOTLPAttributes(event.Attributes),
+			DroppedAttributesCount: event.DroppedAttributeCount,
+		})
+	}
+
+	for _, link := range span.Links() {
+		result.Links = append(result.Links, otlpLink{
+			TraceID:                link.SpanContext.TraceID().String(),
+			SpanID:                 link.SpanContext.SpanID().String(),
+			TraceState:             link.SpanContext.TraceState().String(),
+			Attributes:             makeOTLPAttributes(link.Attributes),
+			DroppedAttributesCount: link.DroppedAttributeCount,
+		})
+	}
+
+	return result
+}
+
+func makeOTLPStatus(status sdktrace.Status) otlpStatus {
+	switch status.Code {
+	case otel_codes.Ok:
+		return otlpStatus{Code: otlpStatusCodeOK}
+	case otel_codes.Error:
+		return otlpStatus{Code: otlpStatusCodeError, Message: status.Description}
+	default:
+		return otlpStatus{}
+	}
+}
+
+func makeOTLPAttributes(attrs []attribute.KeyValue) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	result := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		result = append(result, otlpKeyValue{Key: string(attr.Key), Value: makeOTLPAnyValue(attr.Value)})
+	}
+
+	return result
+}
+
+func makeOTLPAnyValue(value attribute.Value) otlpAnyValue {
+	switch value.Type() {
+	case attribute.BOOL:
+		v := value.AsBool()
+
+		return otlpAnyValue{BoolValue: &v}
+	case attribute.INT64:
+		v := strconv.FormatInt(value.AsInt64(), 10)
+
+		return otlpAnyValue{IntValue: &v}
+	case attribute.FLOAT64:
+		v := value.AsFloat64()
+
+		return otlpAnyValue{DoubleValue: &v}
+	case attribute.BOOLSLICE:
+		return makeOTLPArrayValue(value.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return makeOTLPArrayValue(value.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return makeOTLPArrayValue(value.AsFloat64Slice(), attribute.Float64Value)
+	case attribute.STRINGSLICE:
+		return makeOTLPArrayValue(value.AsStringSlice(), attribute.StringValue)
+	default:
+		v := value.Emit()
+
+		return otlpAnyValue{StringValue: &v}
+	}
+}
+
+func makeOTLPArrayValue[T any](items []T, makeValue func(T) attribute.Value) otlpAnyValue {
+	values := make([]otlpAnyValue, 0, len(items))
+	for _, item := range items {
+		values = append(values, makeOTLPAnyValue(makeValue(item)))
+	}
+
+	return otlpAnyValue{ArrayValue: &otlpArrayValue{Values: values}}
+}
+
+func formatUnixNano(ns int64) string {
+	return strconv.FormatUint(uint64(ns), 10)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otel_codes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestOTLPFileExporter(t *testing.T) {
+	var buf bytes.Buffer
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(newOTLPFileExporter(&buf)),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "connector"))),
+	)
+
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttributes(attribute.Int64("rows", 42), attribute.StringSlice("tables", []string{"a", "b"}))
+	child.RecordError(fmt.Errorf("connection refused"))
+	child.SetStatus(otel_codes.Error, "query failed")
+	child.End()
+	parent.End()
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	// every batch is written on its own line
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var data map[string]any
+
+	require.NoError(t, json.Unmarshal(lines[0], &data))
+
+	resourceSpans := data["resourceSpans"].([]any)[0].(map[string]any)
+	require.Equal(t,
+		[]any{map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "connector"}}},
+		resourceSpans["resource"].(map[string]any)["attributes"],
+	)
+
+	scopeSpans := resourceSpans["scopeSpans"].([]any)[0].(map[string]any)
+	require.Equal(t, map[string]any{"name": "test"}, scopeSpans["scope"])
+
+	span := scopeSpans["spans"].([]any)[0].(map[string]any)
+	require.Equal(t, "child", span["name"])
+	require.Equal(t, child.SpanContext().TraceID().String(), span["traceId"])
+	require.Equal(t, parent.SpanContext().SpanID().String(), span["parentSpanId"])
+	require.Equal(t, float64(1), span["kind"])
+	require.IsType(t, "", span["startTimeUnixNano"])
+	require.Equal(t, map[string]any{"code": float64(2), "message": "query failed"}, span["status"])
+	require.Equal(t,
+		[]any{
+			map[string]any{"key": "rows", "value": map[string]any{"intValue": "42"}},
+			map[string]any{"key": "tables", "value": map[string]any{"arrayValue": map[string]any{"values": []any{
+				map[string]any{"stringValue": "a"},
+				map[string]any{"stringValue": "b"},
+			}}}},
+		},
+		span["attributes"],
+	)
+	require.Equal(t, "exception", span["events"].([]any)[0].(map[string]any)["name"])
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "time"
+
+// ManualClock is the time source moved forward explicitly, it's used to check the expiration in tests
+type ManualClock struct {
+	now time.Time
+}
+
+func (c *ManualClock) Now() time.Time { return c.now }
+
+func (c *ManualClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func NewManualClock() *ManualClock {
+	return &ManualClock{now: time.Now()}
+}
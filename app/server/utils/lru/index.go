@@ -0,0 +1,149 @@
+package lru
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+type element[K comparable, V any] struct {
+	key       K
+	value     V
+	size      uint64
+	expiresAt time.Time
+}
+
+type indexMetrics struct {
+	hits      metrics.Counter
+	misses    metrics.Counter
+	evictions metrics.Counter // entries dropped because of the size limit
+	entries   metrics.IntGauge
+}
+
+// Index keeps the entries in the order of their use and evicts the least recently used ones
+// when their total size exceeds the limit. The expired entries are dropped lazily when they are requested.
+// Index is not safe for concurrent use, the owner is expected to guard it.
+type Index[K comparable, V any] struct {
+	maxSize  uint64
+	now      func() time.Time
+	onRemove func(key K, value V) // called for every entry leaving the index except the replaced ones
+	metrics  *indexMetrics
+
+	order    *list.List // the most recently used entries are in front
+	elements map[K]*list.Element
+	size     uint64
+}
+
+// Get returns the value if it exists and hasn't expired yet
+func (ix *Index[K, V]) Get(key K) (V, bool) {
+	if item, exists := ix.elements[key]; exists {
+		e := item.Value.(*element[K, V])
+		if ix.now().Before(e.expiresAt) {
+			ix.order.MoveToFront(item)
+			ix.metrics.hits.Inc()
+
+			return e.value, true
+		}
+
+		ix.remove(item)
+	}
+
+	ix.metrics.misses.Inc()
+
+	var value V
+
+	return value, false
+}
+
+// Put adds the entry replacing the previous value of the key
+// and evicts the least recently used entries if the size limit is exceeded.
+func (ix *Index[K, V]) Put(key K, value V, size uint64, ttl time.Duration) {
+	if item, exists := ix.elements[key]; exists {
+		ix.forget(item)
+	}
+
+	ix.elements[key] = ix.order.PushFront(&element[K, V]{key: key, value: value, size: size, expiresAt: ix.now().Add(ttl)})
+	ix.size += size
+
+	for ix.size > ix.maxSize {
+		ix.remove(ix.order.Back())
+		ix.metrics.evictions.Inc()
+	}
+
+	ix.metrics.entries.Set(int64(ix.order.Len()))
+}
+
+// Remove drops the entry of the key if it exists
+func (ix *Index[K, V]) Remove(key K) {
+	if item, exists := ix.elements[key]; exists {
+		ix.remove(item)
+	}
+}
+
+// RemoveIf drops the entries matching the predicate and returns their number
+func (ix *Index[K, V]) RemoveIf(predicate func(key K, value V) bool) int {
+	var removed int
+
+	for item := ix.order.Front(); item != nil; {
+		next := item.Next()
+		e := item.Value.(*element[K, V])
+
+		if predicate(e.key, e.value) {
+			ix.remove(item)
+			removed++
+		}
+
+		item = next
+	}
+
+	return removed
+}
+
+// Len returns the number of entries
+func (ix *Index[K, V]) Len() int { return ix.order.Len() }
+
+// Size returns the total size of entries
+func (ix *Index[K, V]) Size() uint64 { return ix.size }
+
+func (ix *Index[K, V]) remove(item *list.Element) {
+	e := ix.forget(item)
+
+	if ix.onRemove != nil {
+		ix.onRemove(e.key, e.value)
+	}
+}
+
+func (ix *Index[K, V]) forget(item *list.Element) *element[K, V] {
+	e := item.Value.(*element[K, V])
+
+	ix.order.Remove(item)
+	delete(ix.elements, e.key)
+	ix.size -= e.size
+	ix.metrics.entries.Set(int64(ix.order.Len()))
+
+	return e
+}
+
+// NewIndex creates the index bounded by the total size of entries.
+// The hits, misses, evictions and the number of entries are reported to the registry.
+func NewIndex[K comparable, V any](
+	maxSize uint64,
+	now func() time.Time,
+	onRemove func(key K, value V),
+	registry metrics.Registry,
+) *Index[K, V] {
+	return &Index[K, V]{
+		maxSize:  maxSize,
+		now:      now,
+		onRemove: onRemove,
+		metrics: &indexMetrics{
+			hits:      registry.Counter("hits"),
+			misses:    registry.Counter("misses"),
+			evictions: registry.Counter("evictions"),
+			entries:   registry.IntGauge("entries"),
+		},
+		order:    list.New(),
+		elements: make(map[K]*list.Element),
+	}
+}
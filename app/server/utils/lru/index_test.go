@@ -0,0 +1,117 @@
+package lru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	app_utils "github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/nop"
+)
+
+func TestIndex(t *testing.T) {
+	type put struct {
+		key  string
+		size uint64
+	}
+
+	testCases := []struct {
+		name    string
+		maxSize uint64
+		puts    []put
+		get     []string // keys requested after the puts
+		exists  []string
+		removed []string
+	}{
+		{
+			name:    "eviction of the least recently used",
+			maxSize: 2,
+			puts:    []put{{"a", 1}, {"b", 1}},
+			get:     []string{"a"},
+			exists:  []string{"a", "c"},
+			removed: []string{"b"},
+		},
+		{
+			name:    "eviction of several entries",
+			maxSize: 3,
+			puts:    []put{{"a", 1}, {"b", 1}},
+			exists:  []string{"c"},
+			removed: []string{"a", "b"},
+		},
+		{
+			name:    "replacement",
+			maxSize: 3,
+			puts:    []put{{"a", 1}, {"b", 1}, {"a", 1}},
+			exists:  []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var removed []string
+
+			ix := NewIndex[string, string](tc.maxSize, app_utils.NewManualClock().Now, func(key, _ string) {
+				removed = append(removed, key)
+			}, nop.Registry{})
+
+			for _, p := range tc.puts {
+				ix.Put(p.key, p.key, p.size, time.Minute)
+			}
+
+			for _, key := range tc.get {
+				_, exists := ix.Get(key)
+				require.True(t, exists)
+			}
+
+			// the entry taking the rest of the room
+			ix.Put("c", "c", tc.maxSize-uint64(len(tc.exists)-1), time.Minute)
+
+			for _, key := range tc.exists {
+				value, exists := ix.Get(key)
+				require.True(t, exists, key)
+				require.Equal(t, key, value)
+			}
+
+			require.Equal(t, tc.removed, removed)
+			require.Equal(t, tc.maxSize, ix.Size())
+		})
+	}
+}
+
+func TestIndexExpiration(t *testing.T) {
+	clock := app_utils.NewManualClock()
+
+	var removed []string
+
+	ix := NewIndex[string, string](10, clock.Now, func(key, _ string) { removed = append(removed, key) }, nop.Registry{})
+	ix.Put("a", "a", 1, time.Minute)
+
+	clock.Advance(59 * time.Second)
+
+	_, exists := ix.Get("a")
+	require.True(t, exists)
+
+	clock.Advance(time.Second)
+
+	_, exists = ix.Get("a")
+	require.False(t, exists)
+	require.Equal(t, []string{"a"}, removed)
+	require.Zero(t, ix.Len())
+	require.Zero(t, ix.Size())
+}
+
+func TestIndexRemoveIf(t *testing.T) {
+	ix := NewIndex[string, int](10, app_utils.NewManualClock().Now, nil, nop.Registry{})
+
+	for i, key := range []string{"a", "b", "c", "d"} {
+		ix.Put(key, i, 1, time.Minute)
+	}
+
+	require.Equal(t, 2, ix.RemoveIf(func(_ string, value int) bool { return value%2 == 0 }))
+	require.Equal(t, 0, ix.RemoveIf(func(_ string, value int) bool { return value%2 == 0 }))
+	require.Equal(t, 2, ix.Len())
+
+	_, exists := ix.Get("b")
+	require.True(t, exists)
+}
@@ -20,12 +20,17 @@ type wrappedStream struct {
 // which must be used for log annotation
 type metainfo struct {
 	testName string // used only in integration tests (Go)
+	userID   string // used for admission control
+	// codec requested for the compression of Arrow IPC pages
+	arrowCompression string
 }
 
 type loggerKey int
 
 const (
 	loggerKeyRequest loggerKey = iota
+	userIDKeyRequest
+	arrowCompressionKeyRequest
 )
 
 func extractMetadata(ctx context.Context) metainfo {
@@ -41,6 +46,16 @@ func extractMetadata(ctx context.Context) metainfo {
 		m.testName = testNames[0]
 	}
 
+	userIDs := md[common.UserID]
+	if len(userIDs) != 0 {
+		m.userID = userIDs[0]
+	}
+
+	arrowCompressions := md[common.ArrowCompression]
+	if len(arrowCompressions) != 0 {
+		m.arrowCompression = arrowCompressions[0]
+	}
+
 	return m
 }
 
@@ -84,9 +99,15 @@ func insertMetadataToContext(serverContext context.Context, logger *zap.Logger,
 		fields = append(fields, zap.String("test_name", metainfo.testName))
 	}
 
+	if metainfo.userID != "" {
+		fields = append(fields, zap.String("user_id", metainfo.userID))
+	}
+
 	newLogger := logger.With(fields...)
 
 	ctx := context.WithValue(serverContext, loggerKeyRequest, newLogger)
+	ctx = context.WithValue(ctx, userIDKeyRequest, metainfo.userID)
+	ctx = context.WithValue(ctx, arrowCompressionKeyRequest, metainfo.arrowCompression)
 
 	return ctx
 }
@@ -95,3 +116,17 @@ func LoggerMustFromContext(ctx context.Context) *zap.Logger {
 	logger := ctx.Value(loggerKeyRequest).(*zap.Logger)
 	return logger
 }
+
+// UserIDFromContext returns the user identifier passed by client in the request metadata.
+// Empty string is returned if the user is unknown.
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDKeyRequest).(string)
+	return userID
+}
+
+// ArrowCompressionFromContext returns the name of the Arrow IPC compression codec
+// requested by client in the request metadata. Empty string is returned if client has no preference.
+func ArrowCompressionFromContext(ctx context.Context) string {
+	arrowCompression, _ := ctx.Value(arrowCompressionKeyRequest).(string)
+	return arrowCompression
+}
@@ -0,0 +1,280 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/prometheus"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
+)
+
+var _ metrics.Registry = (*MetricsRegistry)(nil)
+
+// MetricsRegistry registers every sensor both in Solomon and Prometheus registries,
+// so that the metrics can be exposed in the native format of each monitoring system.
+type MetricsRegistry struct {
+	solomon    metrics.Registry
+	prometheus metrics.Registry
+
+	// root registries are used for the exposition of the metrics
+	solomonRoot    *solomon.Registry
+	prometheusRoot *prometheus.Registry
+}
+
+// Solomon returns the root Solomon registry.
+func (r *MetricsRegistry) Solomon() *solomon.Registry { return r.solomonRoot }
+
+// Prometheus returns the root Prometheus registry.
+func (r *MetricsRegistry) Prometheus() *prometheus.Registry { return r.prometheusRoot }
+
+func (r *MetricsRegistry) WithTags(tags map[string]string) metrics.Registry {
+	return r.sub(r.solomon.WithTags(tags), r.prometheus.WithTags(tags))
+}
+
+func (r *MetricsRegistry) WithPrefix(prefix string) metrics.Registry {
+	return r.sub(r.solomon.WithPrefix(prefix), r.prometheus.WithPrefix(prefix))
+}
+
+func (r *MetricsRegistry) ComposeName(parts ...string) string {
+	return r.solomon.ComposeName(parts...)
+}
+
+func (r *MetricsRegistry) Counter(name string) metrics.Counter {
+	return counter{dualSensor[metrics.Counter]{r.solomon.Counter(name), r.prometheus.Counter(name)}}
+}
+
+func (r *MetricsRegistry) CounterVec(name string, labels []string) metrics.CounterVec {
+	return counterVec{dualSensor[metrics.CounterVec]{r.solomon.CounterVec(name, labels), r.prometheus.CounterVec(name, labels)}}
+}
+
+func (r *MetricsRegistry) FuncCounter(name string, function func() int64) metrics.FuncCounter {
+	r.prometheus.FuncCounter(name, function)
+
+	return r.solomon.FuncCounter(name, function)
+}
+
+func (r *MetricsRegistry) Gauge(name string) metrics.Gauge {
+	return gauge{dualSensor[metrics.Gauge]{r.solomon.Gauge(name), r.prometheus.Gauge(name)}}
+}
+
+func (r *MetricsRegistry) GaugeVec(name string, labels []string) metrics.GaugeVec {
+	return gaugeVec{dualSensor[metrics.GaugeVec]{r.solomon.GaugeVec(name, labels), r.prometheus.GaugeVec(name, labels)}}
+}
+
+func (r *MetricsRegistry) FuncGauge(name string, function func() float64) metrics.FuncGauge {
+	r.prometheus.FuncGauge(name, function)
+
+	return r.solomon.FuncGauge(name, function)
+}
+
+func (r *MetricsRegistry) IntGauge(name string) metrics.IntGauge {
+	return intGauge{dualSensor[metrics.IntGauge]{r.solomon.IntGauge(name), r.prometheus.IntGauge(name)}}
+}
+
+func (r *MetricsRegistry) IntGaugeVec(name string, labels []string) metrics.IntGaugeVec {
+	return intGaugeVec{dualSensor[metrics.IntGaugeVec]{r.solomon.IntGaugeVec(name, labels), r.prometheus.IntGaugeVec(name, labels)}}
+}
+
+func (r *MetricsRegistry) FuncIntGauge(name string, function func() int64) metrics.FuncIntGauge {
+	r.prometheus.FuncIntGauge(name, function)
+
+	return r.solomon.FuncIntGauge(name, function)
+}
+
+func (r *MetricsRegistry) Timer(name string) metrics.Timer {
+	return timer{dualSensor[metrics.Timer]{r.solomon.Timer(name), r.prometheus.Timer(name)}}
+}
+
+func (r *MetricsRegistry) TimerVec(name string, labels []string) metrics.TimerVec {
+	return timerVec{dualSensor[metrics.TimerVec]{r.solomon.TimerVec(name, labels), r.prometheus.TimerVec(name, labels)}}
+}
+
+func (r *MetricsRegistry) Histogram(name string, buckets metrics.Buckets) metrics.Histogram {
+	return histogram{dualSensor[metrics.Histogram]{r.solomon.Histogram(name, buckets), r.prometheus.Histogram(name, buckets)}}
+}
+
+func (r *MetricsRegistry) HistogramVec(name string, buckets metrics.Buckets, labels []string) metrics.HistogramVec {
+	return histogramVec{dualSensor[metrics.HistogramVec]{
+		r.solomon.HistogramVec(name, buckets, labels),
+		r.prometheus.HistogramVec(name, buckets, labels),
+	}}
+}
+
+func (r *MetricsRegistry) DurationHistogram(name string, buckets metrics.DurationBuckets) metrics.Timer {
+	return timer{dualSensor[metrics.Timer]{r.solomon.DurationHistogram(name, buckets), r.prometheus.DurationHistogram(name, buckets)}}
+}
+
+func (r *MetricsRegistry) DurationHistogramVec(name string, buckets metrics.DurationBuckets, labels []string) metrics.TimerVec {
+	return timerVec{dualSensor[metrics.TimerVec]{
+		r.solomon.DurationHistogramVec(name, buckets, labels),
+		r.prometheus.DurationHistogramVec(name, buckets, labels),
+	}}
+}
+
+func (r *MetricsRegistry) sub(solomonRegistry, prometheusRegistry metrics.Registry) *MetricsRegistry {
+	return &MetricsRegistry{
+		solomon:        solomonRegistry,
+		prometheus:     prometheusRegistry,
+		solomonRoot:    r.solomonRoot,
+		prometheusRoot: r.prometheusRoot,
+	}
+}
+
+// dualSensor keeps the instances of the same sensor registered in both registries
+type dualSensor[T any] struct {
+	solomon    T
+	prometheus T
+}
+
+func (s dualSensor[T]) solomonSensor() any { return s.solomon }
+
+type counter struct {
+	dualSensor[metrics.Counter]
+}
+
+func (c counter) Inc() {
+	c.solomon.Inc()
+	c.prometheus.Inc()
+}
+
+func (c counter) Add(delta int64) {
+	c.solomon.Add(delta)
+	c.prometheus.Add(delta)
+}
+
+type gauge struct {
+	dualSensor[metrics.Gauge]
+}
+
+func (g gauge) Set(value float64) {
+	g.solomon.Set(value)
+	g.prometheus.Set(value)
+}
+
+func (g gauge) Add(value float64) {
+	g.solomon.Add(value)
+	g.prometheus.Add(value)
+}
+
+type intGauge struct {
+	dualSensor[metrics.IntGauge]
+}
+
+func (g intGauge) Set(value int64) {
+	g.solomon.Set(value)
+	g.prometheus.Set(value)
+}
+
+func (g intGauge) Add(value int64) {
+	g.solomon.Add(value)
+	g.prometheus.Add(value)
+}
+
+type timer struct {
+	dualSensor[metrics.Timer]
+}
+
+func (t timer) RecordDuration(value time.Duration) {
+	t.solomon.RecordDuration(value)
+	t.prometheus.RecordDuration(value)
+}
+
+type histogram struct {
+	dualSensor[metrics.Histogram]
+}
+
+func (h histogram) RecordValue(value float64) {
+	h.solomon.RecordValue(value)
+	h.prometheus.RecordValue(value)
+}
+
+type counterVec struct {
+	dualSensor[metrics.CounterVec]
+}
+
+func (v counterVec) With(tags map[string]string) metrics.Counter {
+	return counter{dualSensor[metrics.Counter]{v.solomon.With(tags), v.prometheus.With(tags)}}
+}
+
+func (v counterVec) Reset() {
+	v.solomon.Reset()
+	v.prometheus.Reset()
+}
+
+type gaugeVec struct {
+	dualSensor[metrics.GaugeVec]
+}
+
+func (v gaugeVec) With(tags map[string]string) metrics.Gauge {
+	return gauge{dualSensor[metrics.Gauge]{v.solomon.With(tags), v.prometheus.With(tags)}}
+}
+
+func (v gaugeVec) Reset() {
+	v.solomon.Reset()
+	v.prometheus.Reset()
+}
+
+type intGaugeVec struct {
+	dualSensor[metrics.IntGaugeVec]
+}
+
+func (v intGaugeVec) With(tags map[string]string) metrics.IntGauge {
+	return intGauge{dualSensor[metrics.IntGauge]{v.solomon.With(tags), v.prometheus.With(tags)}}
+}
+
+func (v intGaugeVec) Reset() {
+	v.solomon.Reset()
+	v.prometheus.Reset()
+}
+
+type timerVec struct {
+	dualSensor[metrics.TimerVec]
+}
+
+func (v timerVec) With(tags map[string]string) metrics.Timer {
+	return timer{dualSensor[metrics.Timer]{v.solomon.With(tags), v.prometheus.With(tags)}}
+}
+
+func (v timerVec) Reset() {
+	v.solomon.Reset()
+	v.prometheus.Reset()
+}
+
+type histogramVec struct {
+	dualSensor[metrics.HistogramVec]
+}
+
+func (v histogramVec) With(tags map[string]string) metrics.Histogram {
+	return histogram{dualSensor[metrics.Histogram]{v.solomon.With(tags), v.prometheus.With(tags)}}
+}
+
+func (v histogramVec) Reset() {
+	v.solomon.Reset()
+	v.prometheus.Reset()
+}
+
+// Rated marks the counter or histogram as rated in Solomon,
+// in Prometheus the rates are calculated by queries.
+func Rated(sensor any) {
+	if s, ok := sensor.(interface{ solomonSensor() any }); ok {
+		sensor = s.solomonSensor()
+	}
+
+	solomon.Rated(sensor)
+}
+
+// NewMetricsRegistry creates the registry that is able to expose metrics in all supported formats
+func NewMetricsRegistry() *MetricsRegistry {
+	solomonRoot := solomon.NewRegistry(&solomon.RegistryOpts{
+		Separator:  '.',
+		UseNameTag: true,
+	})
+	prometheusRoot := prometheus.NewRegistry(prometheus.NewRegistryOpts())
+
+	return &MetricsRegistry{
+		solomon:        solomonRoot,
+		prometheus:     prometheusRoot,
+		solomonRoot:    solomonRoot,
+		prometheusRoot: prometheusRoot,
+	}
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ydb-platform/fq-connector-go"
+
+// StartSpan starts a new span with the globally registered tracer provider.
+// Until the tracing is configured, the span is a no-op.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan marks the span as failed if the error is not nil and finishes it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -1,9 +1,11 @@
 package validate
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -30,13 +32,37 @@ var helmCmd = &cobra.Command{
 	},
 }
 
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Validate server configuration file",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateServerConfigurationFile(cmd, args); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
 const (
-	fileFlag = "file"
-	keyFlag  = "key"
+	fileFlag         = "file"
+	keyFlag          = "key"
+	configFlag       = "config"
+	probeFlag        = "probe"
+	probeTimeoutFlag = "probe-timeout"
 )
 
 func init() {
 	Cmd.AddCommand(helmCmd)
+	Cmd.AddCommand(serverCmd)
+
+	serverCmd.Flags().StringP(configFlag, "c", "", "Path to server config file")
+	serverCmd.Flags().Bool(probeFlag, false, "Check connectivity to the endpoints mentioned in the config")
+	serverCmd.Flags().Duration(probeTimeoutFlag, 5*time.Second, "Timeout for the probe of a single endpoint")
+
+	if err := serverCmd.MarkFlagRequired(configFlag); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
 	helmCmd.Flags().StringP(fileFlag, "f", "", "Path to Helm file")
 	helmCmd.Flags().StringP(keyFlag, "k", "", "Key by which the Connector config is stored within the Helm file")
@@ -112,6 +138,56 @@ func validateHelmConfigurationFile(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+func validateServerConfigurationFile(cmd *cobra.Command, _ []string) error {
+	configPath, err := cmd.Flags().GetString(configFlag)
+	if err != nil {
+		return fmt.Errorf("get config flag: %v", err)
+	}
+
+	probe, err := cmd.Flags().GetBool(probeFlag)
+	if err != nil {
+		return fmt.Errorf("get probe flag: %v", err)
+	}
+
+	probeTimeout, err := cmd.Flags().GetDuration(probeTimeoutFlag)
+	if err != nil {
+		return fmt.Errorf("get probe timeout flag: %v", err)
+	}
+
+	cfg, problems := config.ValidateConfigFile(configPath)
+	for _, problem := range problems {
+		fmt.Println("INVALID:", problem)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("config file '%s' has %d problem(s)", configPath, len(problems))
+	}
+
+	fmt.Println("config file is valid")
+
+	if !probe {
+		return nil
+	}
+
+	var failed int
+
+	for _, result := range runProbes(context.Background(), makeProbeTargets(cfg), probeTimeout) {
+		if result.err != nil {
+			failed++
+
+			fmt.Printf("UNREACHABLE: %s: %v\n", result.description, result.err)
+		} else {
+			fmt.Printf("OK: %s\n", result.description)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d endpoint(s) are unreachable", failed)
+	}
+
+	return nil
+}
+
 // parseYAMLFile will read a YAML file and decode it into a map
 func parseYAMLFile(filename string) (map[string]any, error) {
 	file, err := os.Open(filename)
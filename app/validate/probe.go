@@ -0,0 +1,160 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// probeTarget is a single external dependency of the server that can be checked before the start
+type probeTarget struct {
+	description string
+	probe       func(ctx context.Context) error
+}
+
+// probeResult keeps the outcome of the probe of a single target
+type probeResult struct {
+	description string
+	err         error
+}
+
+// makeProbeTargets lists the endpoints the server is going to connect to
+func makeProbeTargets(cfg *config.TServerConfig) []probeTarget {
+	var targets []probeTarget
+
+	if logging := cfg.GetDatasources().GetLogging(); logging != nil {
+		if static := logging.GetStatic(); static != nil {
+			for _, database := range static.Databases {
+				targets = append(targets, makeEndpointProbeTarget(
+					fmt.Sprintf("logging static database '%s'", database.Name), database.Endpoint))
+			}
+		}
+
+		if dynamic := logging.GetDynamic(); dynamic != nil {
+			targets = append(targets, makeEndpointProbeTarget("logging dynamic resolver", dynamic.LoggingEndpoint))
+		}
+	}
+
+	if storage := cfg.GetObservation().GetStorage(); storage != nil {
+		if sqlite := storage.GetSqlite(); sqlite != nil {
+			targets = append(targets, probeTarget{
+				description: fmt.Sprintf("observation storage SQLite '%s'", sqlite.Path),
+				probe:       func(context.Context) error { return probeSQLitePath(sqlite.Path) },
+			})
+		}
+
+		if postgresql := storage.GetPostgresql(); postgresql != nil {
+			targets = append(targets, probeTarget{
+				description: "observation storage PostgreSQL",
+				probe:       func(ctx context.Context) error { return probePostgreSQLDSN(ctx, postgresql.Dsn) },
+			})
+		}
+	}
+
+	return targets
+}
+
+func makeEndpointProbeTarget(description string, endpoint *api_common.TGenericEndpoint) probeTarget {
+	address := common.EndpointToString(endpoint)
+
+	return probeTarget{
+		description: fmt.Sprintf("%s (%s)", description, address),
+		probe:       func(ctx context.Context) error { return probeAddress(ctx, address) },
+	}
+}
+
+// runProbes checks all the targets one by one and returns the outcome for each of them
+func runProbes(ctx context.Context, targets []probeTarget, timeout time.Duration) []probeResult {
+	results := make([]probeResult, 0, len(targets))
+
+	for _, target := range targets {
+		probeCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := target.probe(probeCtx)
+
+		cancel()
+
+		results = append(results, probeResult{description: target.description, err: err})
+	}
+
+	return results
+}
+
+// probeAddress only establishes TCP connection, because the credentials
+// that are required for the real requests are usually unavailable at this stage
+func probeAddress(ctx context.Context, address string) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("close connection: %w", err)
+	}
+
+	return nil
+}
+
+// probeSQLitePath checks that the database file can be created or opened by the server
+func probeSQLitePath(path string) error {
+	info, err := os.Stat(path)
+	if err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("path is a directory")
+		}
+
+		file, err := os.OpenFile(path, os.O_RDWR, 0)
+		if err != nil {
+			return fmt.Errorf("open database file: %w", err)
+		}
+
+		return file.Close()
+	}
+
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("stat database file: %w", err)
+	}
+
+	// the file is created on the first start, so the directory must be writable
+	file, err := os.CreateTemp(filepath.Dir(path), ".probe-*")
+	if err != nil {
+		return fmt.Errorf("create file in database directory: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close probe file: %w", err)
+	}
+
+	if err := os.Remove(file.Name()); err != nil {
+		return fmt.Errorf("remove probe file: %w", err)
+	}
+
+	return nil
+}
+
+// probePostgreSQLDSN checks that the DSN can be parsed and the database host is reachable
+func probePostgreSQLDSN(ctx context.Context, dsn string) error {
+	pgCfg, err := pgconn.ParseConfig(dsn)
+	if err != nil {
+		return fmt.Errorf("parse DSN: %w", err)
+	}
+
+	address := net.JoinHostPort(pgCfg.Host, strconv.Itoa(int(pgCfg.Port)))
+
+	if err := probeAddress(ctx, address); err != nil {
+		return fmt.Errorf("probe '%s': %w", address, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func TestProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	port := uint32(listener.Addr().(*net.TCPAddr).Port)
+
+	cfg := &config.TServerConfig{
+		Datasources: &config.TDatasourcesConfig{
+			Logging: &config.TLoggingConfig{
+				Resolving: &config.TLoggingConfig_Static{
+					Static: &config.TLoggingConfig_TStaticResolving{
+						Databases: []*config.TLoggingConfig_TStaticResolving_TDatabase{
+							{Name: "alive", Endpoint: &api_common.TGenericEndpoint{Host: "127.0.0.1", Port: port}},
+							{Name: "dead", Endpoint: &api_common.TGenericEndpoint{Host: "127.0.0.1", Port: 1}},
+						},
+					},
+				},
+			},
+		},
+		Observation: &config.TObservationConfig{
+			Storage: &config.TObservationConfig_TStorage{
+				Payload: &config.TObservationConfig_TStorage_Sqlite{
+					Sqlite: &config.TObservationConfig_TStorage_TSQLite{
+						Path: filepath.Join(t.TempDir(), "observation.db"),
+					},
+				},
+			},
+		},
+	}
+
+	results := runProbes(context.Background(), makeProbeTargets(cfg), time.Second)
+	require.Len(t, results, 3)
+	require.NoError(t, results[0].err)
+	require.Error(t, results[1].err)
+	require.NoError(t, results[2].err)
+
+	// the directory of the database doesn't exist
+	cfg.Observation.Storage.GetSqlite().Path = filepath.Join(t.TempDir(), "missing", "observation.db")
+
+	results = runProbes(context.Background(), makeProbeTargets(cfg), time.Second)
+	require.Error(t, results[2].err)
+}
@@ -10,13 +10,14 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service "github.com/ydb-platform/fq-connector-go/api/service"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 )
 
 type StreamResponse interface {
-	*api_service_protos.TListSplitsResponse | *api_service_protos.TReadSplitsResponse
+	*api_service_protos.TListSplitsResponse | *api_service_protos.TReadSplitsResponse | *api_service_protos.TListTablesResponse
 
 	GetError() *api_service_protos.TError
 }
@@ -36,6 +37,34 @@ type ClientStreaming struct {
 	wg sync.WaitGroup
 }
 
+// ListTables lists tables of the data source instance; empty pattern means no filtering
+func (c *ClientStreaming) ListTables(
+	ctx context.Context,
+	dsi *api_common.TGenericDataSourceInstance,
+	pattern string,
+) (<-chan *StreamResult[*api_service_protos.TListTablesResponse], error) {
+	request := &api_service_protos.TListTablesRequest{
+		DataSourceInstance: dsi,
+	}
+
+	if pattern != "" {
+		request.Filtering = &api_service_protos.TListTablesRequest_Pattern{Pattern: pattern}
+	}
+
+	rcvStream, err := c.client.ListTables(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	out := make(chan *StreamResult[*api_service_protos.TListTablesResponse])
+
+	c.wg.Add(1)
+
+	go streamToChannel[*api_service_protos.TListTablesResponse](ctx, rcvStream, out, &c.wg)
+
+	return out, nil
+}
+
 func (c *ClientStreaming) ListSplits(
 	ctx context.Context,
 	slct *api_service_protos.TSelect,
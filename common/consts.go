@@ -3,4 +3,7 @@ package common
 const (
 	ForbidRetries = "forbid_retries"
 	TestName      = "test_name"
+	UserID        = "user_id"
+	// ArrowCompression is the codec client wants to be used for the pages returned by ReadSplits
+	ArrowCompression = "arrow_compression"
 )
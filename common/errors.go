@@ -44,6 +44,9 @@ var (
 	ErrUnimplementedArithmeticalExpression = fmt.Errorf("unimplemented arithmetical expression")
 	ErrEmptyTableName                      = fmt.Errorf("empty table name")
 	ErrPageSizeExceeded                    = fmt.Errorf("page size exceeded, check service configuration")
+	ErrTooManyRequests                     = fmt.Errorf("too many requests")
+	ErrQueryCanceled                       = fmt.Errorf("query canceled")
+	ErrAccessDenied                        = fmt.Errorf("access denied by data policy")
 )
 
 var (
@@ -366,6 +369,8 @@ func newAPIErrorFromConnectorError(err error) *api_service_protos.TError {
 		status = ydb_proto.StatusIds_BAD_REQUEST
 	case errors.Is(err, ErrDataSourceNotSupported):
 		status = ydb_proto.StatusIds_UNSUPPORTED
+	case errors.Is(err, ErrMethodNotSupported):
+		status = ydb_proto.StatusIds_UNSUPPORTED
 	case errors.Is(err, ErrDataTypeNotSupported):
 		status = ydb_proto.StatusIds_UNSUPPORTED
 	case errors.Is(err, ErrDataTypeMismatch):
@@ -388,6 +393,12 @@ func newAPIErrorFromConnectorError(err error) *api_service_protos.TError {
 		status = ydb_proto.StatusIds_INTERNAL_ERROR
 	case errors.Is(err, ErrUnsupportedExpression):
 		status = ydb_proto.StatusIds_UNSUPPORTED
+	case errors.Is(err, ErrTooManyRequests):
+		status = ydb_proto.StatusIds_OVERLOADED
+	case errors.Is(err, ErrQueryCanceled):
+		status = ydb_proto.StatusIds_CANCELLED
+	case errors.Is(err, ErrAccessDenied):
+		status = ydb_proto.StatusIds_UNAUTHORIZED
 	default:
 		status = ydb_proto.StatusIds_INTERNAL_ERROR
 	}
@@ -70,15 +70,33 @@ func NewLoggerFromConfig(cfg *config.TLoggerConfig) (*zap.Logger, error) {
 		return NewDefaultLogger(), nil
 	}
 
+	zapLogger, _, err := NewLoggerWithLevelFromConfig(cfg)
+
+	return zapLogger, err
+}
+
+// NewLoggerWithLevelFromConfig returns the logger along with its level,
+// so that the level could be changed at runtime. The logger is the same as NewDefaultLogger if config is nil.
+func NewLoggerWithLevelFromConfig(cfg *config.TLoggerConfig) (*zap.Logger, zap.AtomicLevel, error) {
 	loggerCfg := newDefaultLoggerConfig()
-	loggerCfg.Level.SetLevel(convertToZapLogLevel(cfg.GetLogLevel()))
+	SetLogLevelFromConfig(loggerCfg.Level, cfg)
 
 	zapLogger, err := loggerCfg.Build()
 	if err != nil {
-		return nil, fmt.Errorf("new logger: %w", err)
+		return nil, loggerCfg.Level, fmt.Errorf("new logger: %w", err)
 	}
 
-	return zapLogger, nil
+	return zapLogger, loggerCfg.Level, nil
+}
+
+// SetLogLevelFromConfig changes the level of the logger made with NewLoggerWithLevelFromConfig,
+// the level is kept if config is nil
+func SetLogLevelFromConfig(level zap.AtomicLevel, cfg *config.TLoggerConfig) {
+	if cfg == nil {
+		return
+	}
+
+	level.SetLevel(convertToZapLogLevel(cfg.GetLogLevel()))
 }
 
 func NewDefaultLogger() *zap.Logger {
@@ -145,6 +163,11 @@ func (f *QueryLoggerFactory) Make(logger *zap.Logger) QueryLogger {
 	return QueryLogger{Logger: logger, enabled: f.enableQueryLogging}
 }
 
+// Enabled reports whether the query texts are allowed to be written to logs and traces
+func (f *QueryLoggerFactory) Enabled() bool {
+	return f.enableQueryLogging
+}
+
 type QueryLogger struct {
 	*zap.Logger
 	enabled bool
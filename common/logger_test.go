@@ -0,0 +1,25 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+)
+
+func TestNewLoggerWithLevelFromConfig(t *testing.T) {
+	// the default logger is made if config is missing
+	logger, level, err := NewLoggerWithLevelFromConfig(nil)
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+	require.Equal(t, zapcore.DebugLevel, level.Level())
+
+	SetLogLevelFromConfig(level, &config.TLoggerConfig{LogLevel: config.ELogLevel_ERROR})
+	require.Equal(t, zapcore.ErrorLevel, level.Level())
+
+	// the level is kept if config disappears on reload
+	SetLogLevelFromConfig(level, nil)
+	require.Equal(t, zapcore.ErrorLevel, level.Level())
+}
@@ -320,6 +320,15 @@ def main():
             [ydb_github_root, connector_github_root, protobuf_includes],
             False,
         )
+        run_protoc(
+            connector_github_root.joinpath("app/server/datasource/rdbms/utils").rglob(
+                "*.proto"
+            ),
+            connector_github_root.joinpath("app/server/datasource/rdbms/utils"),
+            "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils",
+            [connector_github_root, protobuf_includes],
+            False,
+        )
 
     finally:
         # Revert changes in YDB sources